@@ -0,0 +1,112 @@
+// Package backoff provides strategies producing the delays between
+// attempts of an operation. A Backoff satisfies retry.Backoff and
+// mutex.Strategy, so the same strategies drive the retry executor, lock
+// re-acquisition and hand-written loops.
+package backoff
+
+import (
+	"iter"
+	"math"
+	"math/rand/v2"
+	"time"
+)
+
+// Backoff produces a sequence of delays. Implementations are stateful, so
+// create a new one for every operation being retried.
+type Backoff interface {
+	// Next returns the delay before the next attempt, or false if no
+	// further attempts should be made.
+	Next() (time.Duration, bool)
+}
+
+// Func adapts a function to Backoff.
+type Func func() (time.Duration, bool)
+
+// Next calls f.
+func (f Func) Next() (time.Duration, bool) {
+	return f()
+}
+
+// Constant returns a Backoff that always waits d and never stops.
+func Constant(d time.Duration) Backoff {
+	return Func(func() (time.Duration, bool) { return d, true })
+}
+
+// Exponential returns a Backoff that waits initial first and multiplies
+// the delay by factor after every attempt, never stopping. Combine it with
+// Capped and Limit to bound it.
+//
+// Example:
+//
+//	policy := retry.Policy{
+//		MaxAttempts: 5,
+//		Backoff: func() retry.Backoff {
+//			return backoff.Capped(backoff.Exponential(100*time.Millisecond, 2), 5*time.Second)
+//		},
+//	}
+func Exponential(initial time.Duration, factor float64) Backoff {
+	next := float64(initial)
+	return Func(func() (time.Duration, bool) {
+		d := toDuration(next)
+		next *= factor
+		return d, true
+	})
+}
+
+// DecorrelatedJitter returns a Backoff that picks each delay at random
+// between base and three times the previous delay, capped at ceiling, as
+// described in AWS's "Exponential Backoff and Jitter". Spreading delays
+// this way keeps many clients from retrying in lockstep. It never stops.
+func DecorrelatedJitter(base, ceiling time.Duration) Backoff {
+	prev := base
+	return Func(func() (time.Duration, bool) {
+		hi := min(toDuration(float64(prev)*3), ceiling)
+		d := hi
+		if hi > base {
+			d = base + rand.N(hi-base+1)
+		}
+		prev = d
+		return d, true
+	})
+}
+
+// Capped returns a Backoff whose delays are those of b, limited to
+// ceiling.
+func Capped(b Backoff, ceiling time.Duration) Backoff {
+	return Func(func() (time.Duration, bool) {
+		d, ok := b.Next()
+		return min(d, ceiling), ok
+	})
+}
+
+// Limit returns a Backoff that stops after n delays of b, allowing at
+// most n retries.
+func Limit(b Backoff, n int) Backoff {
+	return Func(func() (time.Duration, bool) {
+		if n <= 0 {
+			return 0, false
+		}
+		n--
+		return b.Next()
+	})
+}
+
+// All returns the delays of b as a sequence, ending when b stops.
+func All(b Backoff) iter.Seq[time.Duration] {
+	return func(yield func(time.Duration) bool) {
+		for {
+			d, ok := b.Next()
+			if !ok || !yield(d) {
+				return
+			}
+		}
+	}
+}
+
+// toDuration converts f to a Duration, saturating instead of overflowing.
+func toDuration(f float64) time.Duration {
+	if f >= math.MaxInt64 {
+		return math.MaxInt64
+	}
+	return time.Duration(f)
+}
@@ -0,0 +1,95 @@
+package backoff
+
+import (
+	"math"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/zodimo/go-zbase-std/iterx"
+	"github.com/zodimo/go-zbase-std/mutex"
+	"github.com/zodimo/go-zbase-std/retry"
+)
+
+// Compile-time checks that strategies plug into retry and mutex.
+var (
+	_ retry.Backoff  = Constant(0)
+	_ mutex.Strategy = Constant(0)
+)
+
+func TestConstant(t *testing.T) {
+	// Arrange
+	b := Constant(time.Second)
+
+	// Act
+	got := slices.Collect(iterx.Take(All(b), 3))
+
+	// Assert
+	if want := []time.Duration{time.Second, time.Second, time.Second}; !slices.Equal(got, want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+}
+
+func TestExponential(t *testing.T) {
+	// Arrange
+	b := Exponential(100*time.Millisecond, 2)
+
+	// Act
+	got := slices.Collect(iterx.Take(All(b), 4))
+
+	// Assert
+	want := []time.Duration{100 * time.Millisecond, 200 * time.Millisecond, 400 * time.Millisecond, 800 * time.Millisecond}
+	if !slices.Equal(got, want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+}
+
+func TestExponential_Saturates(t *testing.T) {
+	// Arrange
+	b := Exponential(time.Hour, 1000)
+
+	// Act
+	var last time.Duration
+	for range 10 {
+		last, _ = b.Next()
+	}
+
+	// Assert
+	if last != math.MaxInt64 {
+		t.Errorf("expected the delay to saturate, got %v", last)
+	}
+}
+
+func TestCappedAndLimit(t *testing.T) {
+	// Arrange
+	b := Limit(Capped(Exponential(time.Second, 3), 5*time.Second), 3)
+
+	// Act
+	got := slices.Collect(All(b))
+
+	// Assert
+	if want := []time.Duration{time.Second, 3 * time.Second, 5 * time.Second}; !slices.Equal(got, want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+	if _, ok := b.Next(); ok {
+		t.Error("expected the limited backoff to stay stopped")
+	}
+}
+
+func TestDecorrelatedJitter(t *testing.T) {
+	// Arrange
+	base, ceiling := 10*time.Millisecond, time.Second
+	b := DecorrelatedJitter(base, ceiling)
+
+	// Act
+	got := slices.Collect(iterx.Take(All(b), 100))
+
+	// Assert
+	prev := base
+	for i, d := range got {
+		if d < base || d > min(3*prev, ceiling) {
+			t.Fatalf("expected delay %d between %v and %v, got %v", i, base, min(3*prev, ceiling), d)
+		}
+		prev = d
+	}
+}
@@ -0,0 +1,258 @@
+// Package breaker provides a context-aware circuit breaker that stops
+// calling a failing dependency until it has had time to recover.
+package breaker
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/zodimo/go-zbase-std/clock"
+	"github.com/zodimo/go-zbase-std/errcode"
+)
+
+// OpenError is returned by Do, without calling the operation, while the
+// circuit is open or while the half-open circuit has its maximum number of
+// probes in flight.
+var OpenError = errcode.New(errcode.Unavailable, "breaker: circuit open")
+
+// State is the state of a circuit breaker.
+type State int
+
+const (
+	// Closed lets every call through and counts consecutive failures.
+	Closed State = iota
+
+	// Open rejects every call with OpenError until the cool-down elapses.
+	Open
+
+	// HalfOpen lets a limited number of probe calls through to decide
+	// whether to close or reopen the circuit.
+	HalfOpen
+)
+
+// String returns the name of the state.
+func (s State) String() string {
+	switch s {
+	case Closed:
+		return "closed"
+	case Open:
+		return "open"
+	case HalfOpen:
+		return "half-open"
+	default:
+		return "unknown"
+	}
+}
+
+// Breaker is a circuit breaker. It trips open after a number of
+// consecutive failures, rejects calls for a cool-down period, and then
+// lets probe calls through half-open to test whether the dependency has
+// recovered. It is safe for concurrent use.
+type Breaker struct {
+	mu         sync.Mutex
+	state      State
+	generation uint64    // Incremented on every state change.
+	failures   int       // Consecutive failures while closed.
+	successes  int       // Successful probes while half-open.
+	probes     int       // Probes in flight while half-open.
+	openedAt   time.Time // When the circuit last opened.
+
+	threshold     int
+	coolDown      time.Duration
+	probeLimit    int
+	isFailure     func(error) bool
+	onStateChange StateChangeFunc
+	clock         clock.Clock
+}
+
+// New creates a closed Breaker configured by opts. Without options it trips
+// after 5 consecutive failures, cools down for 30 seconds and closes again
+// after 1 successful probe.
+//
+// Parameters:
+//   - opts: Options configuring thresholds, cool-down and callbacks.
+//
+// Returns:
+//   - *Breaker: The new, closed circuit breaker.
+//
+// Example:
+//
+//	b := breaker.New(breaker.WithFailureThreshold(3), breaker.WithCoolDown(10*time.Second))
+//	err := b.Do(ctx, func(ctx context.Context) error {
+//		return client.Ping(ctx)
+//	})
+func New(opts ...Option) *Breaker {
+	b := &Breaker{
+		threshold:  5,
+		coolDown:   30 * time.Second,
+		probeLimit: 1,
+		isFailure:  func(error) bool { return true },
+		clock:      clock.System(),
+	}
+	for _, opt := range opts {
+		opt(b)
+	}
+	return b
+}
+
+// State returns the current state of the circuit. An open circuit whose
+// cool-down has elapsed is reported, and moved, to HalfOpen.
+func (b *Breaker) State() State {
+	b.mu.Lock()
+	changed := b.coolDownElapsed()
+	state := b.state
+	b.mu.Unlock()
+	changed()
+	return state
+}
+
+// Do calls fn if the circuit allows it and records the outcome. Errors
+// returned while ctx is done are not counted as failures, as they reflect
+// the caller giving up rather than the dependency failing.
+//
+// Parameters:
+//   - ctx: The context passed to fn.
+//   - fn: The operation protected by the breaker.
+//
+// Returns:
+//   - error: The error of fn, OpenError if the circuit rejected the call,
+//     or ctx's error if ctx is done before the call.
+func (b *Breaker) Do(ctx context.Context, fn func(context.Context) error) error {
+	_, err := DoValue(ctx, b, func(ctx context.Context) (struct{}, error) {
+		return struct{}{}, fn(ctx)
+	})
+	return err
+}
+
+// Wrap returns fn protected by the breaker, for composing with other
+// helpers such as retry.Do:
+//
+//	err := retry.Do(ctx, policy, b.Wrap(op))
+func (b *Breaker) Wrap(fn func(context.Context) error) func(context.Context) error {
+	return func(ctx context.Context) error {
+		return b.Do(ctx, fn)
+	}
+}
+
+// DoValue calls fn through the breaker like Do, returning its value.
+//
+// Parameters:
+//   - ctx: The context passed to fn.
+//   - b: The breaker protecting the call.
+//   - fn: The operation protected by the breaker.
+//
+// Returns:
+//   - T: The value of fn, or the zero value of T.
+//   - error: As described for Do.
+func DoValue[T any](ctx context.Context, b *Breaker, fn func(context.Context) (T, error)) (value T, err error) {
+	if err := ctx.Err(); err != nil {
+		return value, err
+	}
+	generation, err := b.allow()
+	if err != nil {
+		return value, err
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			b.record(generation, true)
+			panic(r)
+		}
+		if err != nil && ctx.Err() != nil {
+			b.abandon(generation)
+			return
+		}
+		b.record(generation, err != nil && b.isFailure(err))
+	}()
+	return fn(ctx)
+}
+
+// Retryable reports whether err may be retried by a retry.Policy wrapping
+// calls to a breaker: calls rejected by an open circuit are not retried.
+func Retryable(err error) bool {
+	return !errors.Is(err, OpenError)
+}
+
+// allow admits a call, returning the generation it belongs to.
+func (b *Breaker) allow() (uint64, error) {
+	b.mu.Lock()
+	changed := b.coolDownElapsed()
+	generation, err := b.generation, error(nil)
+	switch b.state {
+	case Open:
+		err = OpenError
+	case HalfOpen:
+		if b.probes >= b.probeLimit {
+			err = OpenError
+		} else {
+			b.probes++
+		}
+	}
+	b.mu.Unlock()
+	changed()
+	return generation, err
+}
+
+// record counts the outcome of a call admitted in generation. Outcomes of
+// calls admitted before the last state change are ignored.
+func (b *Breaker) record(generation uint64, failed bool) {
+	b.mu.Lock()
+	changed := func() {}
+	if generation == b.generation {
+		switch b.state {
+		case Closed:
+			if !failed {
+				b.failures = 0
+			} else if b.failures++; b.failures >= b.threshold {
+				changed = b.setState(Open)
+			}
+		case HalfOpen:
+			b.probes--
+			if failed {
+				changed = b.setState(Open)
+			} else if b.successes++; b.successes >= b.probeLimit {
+				changed = b.setState(Closed)
+			}
+		}
+	}
+	b.mu.Unlock()
+	changed()
+}
+
+// abandon releases the probe slot of a call whose caller gave up.
+func (b *Breaker) abandon(generation uint64) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if generation == b.generation && b.state == HalfOpen {
+		b.probes--
+	}
+}
+
+// coolDownElapsed moves an open circuit whose cool-down has elapsed to
+// HalfOpen. b.mu must be held; the returned function runs the state change
+// callback and must be called once b.mu is released.
+func (b *Breaker) coolDownElapsed() func() {
+	if b.state == Open && b.clock.Now().Sub(b.openedAt) >= b.coolDown {
+		return b.setState(HalfOpen)
+	}
+	return func() {}
+}
+
+// setState moves the circuit to state and resets the counters. b.mu must be
+// held; the returned function runs the state change callback and must be
+// called once b.mu is released.
+func (b *Breaker) setState(state State) func() {
+	from := b.state
+	b.state = state
+	b.generation++
+	b.failures, b.successes, b.probes = 0, 0, 0
+	if state == Open {
+		b.openedAt = b.clock.Now()
+	}
+	return func() {
+		if b.onStateChange != nil {
+			b.onStateChange(from, state)
+		}
+	}
+}
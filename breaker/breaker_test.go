@@ -0,0 +1,239 @@
+package breaker
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/zodimo/go-zbase-std/clock"
+	"github.com/zodimo/go-zbase-std/retry"
+)
+
+var failure = errors.New("dependency down")
+
+func fail(context.Context) error    { return failure }
+func succeed(context.Context) error { return nil }
+
+func TestBreaker_TripsAfterThreshold(t *testing.T) {
+	// Arrange
+	b := New(WithFailureThreshold(3))
+	ctx := context.Background()
+
+	// Act
+	for range 3 {
+		_ = b.Do(ctx, fail)
+	}
+	called := false
+	err := b.Do(ctx, func(context.Context) error { called = true; return nil })
+
+	// Assert
+	if !errors.Is(err, OpenError) {
+		t.Errorf("expected OpenError, got %v", err)
+	}
+	if called {
+		t.Error("expected an open circuit not to call the operation")
+	}
+	if b.State() != Open {
+		t.Errorf("expected state open, got %v", b.State())
+	}
+}
+
+func TestBreaker_SuccessResetsFailures(t *testing.T) {
+	// Arrange
+	b := New(WithFailureThreshold(2))
+	ctx := context.Background()
+
+	// Act
+	_ = b.Do(ctx, fail)
+	_ = b.Do(ctx, succeed)
+	_ = b.Do(ctx, fail)
+
+	// Assert
+	if b.State() != Closed {
+		t.Errorf("expected non-consecutive failures to keep the circuit closed, got %v", b.State())
+	}
+}
+
+func TestBreaker_HalfOpenAfterCoolDown(t *testing.T) {
+	// Arrange
+	c := clock.NewFakeClock(time.Unix(0, 0))
+	b := New(WithFailureThreshold(1), WithCoolDown(time.Minute), WithClock(c))
+	ctx := context.Background()
+	_ = b.Do(ctx, fail)
+
+	// Act
+	c.Advance(30 * time.Second)
+	stillOpen := b.State()
+	c.Advance(30 * time.Second)
+	probing := b.State()
+	err := b.Do(ctx, succeed)
+
+	// Assert
+	if stillOpen != Open || probing != HalfOpen {
+		t.Errorf("expected open then half-open, got %v then %v", stillOpen, probing)
+	}
+	if err != nil || b.State() != Closed {
+		t.Errorf("expected a successful probe to close the circuit, got %v in %v", err, b.State())
+	}
+}
+
+func TestBreaker_FailedProbeReopens(t *testing.T) {
+	// Arrange
+	c := clock.NewFakeClock(time.Unix(0, 0))
+	b := New(WithFailureThreshold(1), WithCoolDown(time.Minute), WithClock(c))
+	ctx := context.Background()
+	_ = b.Do(ctx, fail)
+	c.Advance(time.Minute)
+
+	// Act
+	_ = b.Do(ctx, fail)
+
+	// Assert
+	if b.State() != Open {
+		t.Errorf("expected a failed probe to reopen the circuit, got %v", b.State())
+	}
+}
+
+func TestBreaker_HalfOpenProbeLimit(t *testing.T) {
+	// Arrange
+	c := clock.NewFakeClock(time.Unix(0, 0))
+	b := New(WithFailureThreshold(1), WithCoolDown(time.Minute), WithClock(c))
+	ctx := context.Background()
+	_ = b.Do(ctx, fail)
+	c.Advance(time.Minute)
+	release := make(chan struct{})
+	probing := make(chan struct{})
+	done := make(chan error)
+	go func() {
+		done <- b.Do(ctx, func(context.Context) error { close(probing); <-release; return nil })
+	}()
+	<-probing
+
+	// Act
+	err := b.Do(ctx, succeed)
+	close(release)
+
+	// Assert
+	if !errors.Is(err, OpenError) {
+		t.Errorf("expected calls beyond the probe limit to be rejected, got %v", err)
+	}
+	if err := <-done; err != nil {
+		t.Errorf("expected the probe to succeed, got %v", err)
+	}
+	if b.State() != Closed {
+		t.Errorf("expected the circuit to close, got %v", b.State())
+	}
+}
+
+func TestBreaker_OnStateChange(t *testing.T) {
+	// Arrange
+	var transitions []string
+	c := clock.NewFakeClock(time.Unix(0, 0))
+	b := New(
+		WithFailureThreshold(1),
+		WithCoolDown(time.Minute),
+		WithClock(c),
+		WithOnStateChange(func(from, to State) { transitions = append(transitions, from.String()+"->"+to.String()) }),
+	)
+	ctx := context.Background()
+
+	// Act
+	_ = b.Do(ctx, fail)
+	c.Advance(time.Minute)
+	_ = b.Do(ctx, succeed)
+
+	// Assert
+	expected := []string{"closed->open", "open->half-open", "half-open->closed"}
+	if len(transitions) != len(expected) {
+		t.Fatalf("expected transitions %v, got %v", expected, transitions)
+	}
+	for i := range expected {
+		if transitions[i] != expected[i] {
+			t.Fatalf("expected transitions %v, got %v", expected, transitions)
+		}
+	}
+}
+
+func TestBreaker_FailurePredicate(t *testing.T) {
+	// Arrange
+	invalid := errors.New("invalid input")
+	b := New(WithFailureThreshold(1), WithFailurePredicate(func(err error) bool { return !errors.Is(err, invalid) }))
+
+	// Act
+	err := b.Do(context.Background(), func(context.Context) error { return invalid })
+
+	// Assert
+	if !errors.Is(err, invalid) {
+		t.Errorf("expected the error to pass through, got %v", err)
+	}
+	if b.State() != Closed {
+		t.Errorf("expected ignored errors not to trip the circuit, got %v", b.State())
+	}
+}
+
+func TestBreaker_CallerCancellationNotCounted(t *testing.T) {
+	// Arrange
+	b := New(WithFailureThreshold(1))
+	ctx, cancel := context.WithCancel(context.Background())
+
+	// Act
+	err := b.Do(ctx, func(ctx context.Context) error { cancel(); return ctx.Err() })
+	rejected := b.Do(ctx, succeed)
+
+	// Assert
+	if !errors.Is(err, context.Canceled) || !errors.Is(rejected, context.Canceled) {
+		t.Errorf("expected context.Canceled, got %v and %v", err, rejected)
+	}
+	if b.State() != Closed {
+		t.Errorf("expected cancellation not to trip the circuit, got %v", b.State())
+	}
+}
+
+func TestBreaker_PanicCountsAsFailure(t *testing.T) {
+	// Arrange
+	b := New(WithFailureThreshold(1))
+
+	// Act
+	func() {
+		defer func() { _ = recover() }()
+		_ = b.Do(context.Background(), func(context.Context) error { panic("boom") })
+	}()
+
+	// Assert
+	if b.State() != Open {
+		t.Errorf("expected a panic to trip the circuit, got %v", b.State())
+	}
+}
+
+func TestDoValue(t *testing.T) {
+	// Arrange
+	b := New()
+
+	// Act
+	value, err := DoValue(context.Background(), b, func(context.Context) (int, error) { return 7, nil })
+
+	// Assert
+	if err != nil || value != 7 {
+		t.Errorf("expected (7, nil), got (%v, %v)", value, err)
+	}
+}
+
+func TestBreaker_ComposesWithRetry(t *testing.T) {
+	// Arrange
+	b := New(WithFailureThreshold(2))
+	calls := 0
+	op := func(context.Context) error { calls++; return failure }
+	policy := retry.Policy{MaxAttempts: 5, Retryable: Retryable}
+
+	// Act
+	err := retry.Do(context.Background(), policy, b.Wrap(op))
+
+	// Assert
+	if !errors.Is(err, OpenError) {
+		t.Errorf("expected retries to stop at the open circuit, got %v", err)
+	}
+	if calls != 2 {
+		t.Errorf("expected the operation to be called until the circuit tripped, got %d calls", calls)
+	}
+}
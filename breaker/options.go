@@ -0,0 +1,62 @@
+package breaker
+
+import (
+	"time"
+
+	"github.com/zodimo/go-zbase-std/clock"
+)
+
+// Option configures a Breaker created by New.
+type Option func(*Breaker)
+
+// StateChangeFunc is called after a Breaker changes state. It runs in the
+// goroutine that caused the change, outside the breaker's lock.
+type StateChangeFunc func(from, to State)
+
+// WithFailureThreshold trips the circuit after n consecutive failures.
+// Values below 1 are treated as 1.
+func WithFailureThreshold(n int) Option {
+	return func(b *Breaker) {
+		b.threshold = max(n, 1)
+	}
+}
+
+// WithCoolDown keeps a tripped circuit open for d before probing.
+func WithCoolDown(d time.Duration) Option {
+	return func(b *Breaker) {
+		b.coolDown = d
+	}
+}
+
+// WithHalfOpenProbes lets up to n concurrent probes through a half-open
+// circuit and closes it once n probes have succeeded. Values below 1 are
+// treated as 1.
+func WithHalfOpenProbes(n int) Option {
+	return func(b *Breaker) {
+		b.probeLimit = max(n, 1)
+	}
+}
+
+// WithFailurePredicate counts only the errors for which isFailure returns
+// true as failures; other errors are passed through as successes. This
+// keeps errors such as validation failures from tripping the circuit.
+func WithFailurePredicate(isFailure func(error) bool) Option {
+	return func(b *Breaker) {
+		b.isFailure = isFailure
+	}
+}
+
+// WithOnStateChange calls fn after every state change.
+func WithOnStateChange(fn StateChangeFunc) Option {
+	return func(b *Breaker) {
+		b.onStateChange = fn
+	}
+}
+
+// WithClock makes the breaker read time from the given clock instead of the
+// system clock, so cool-downs can be tested without sleeping.
+func WithClock(c clock.Clock) Option {
+	return func(b *Breaker) {
+		b.clock = c
+	}
+}
@@ -0,0 +1,252 @@
+// Package broadcast provides an in-process publish/subscribe topic that
+// fans each published value out to every subscriber.
+package broadcast
+
+import (
+	"context"
+	"sync"
+
+	"github.com/zodimo/go-zbase-std/errcode"
+)
+
+// ClosedError is returned by Publish once the topic has been closed.
+var ClosedError = errcode.New(errcode.Unavailable, "broadcast: topic closed")
+
+// OverflowError is returned by Publish when a subscriber using the Error
+// policy had a full buffer. Such subscribers are unsubscribed.
+var OverflowError = errcode.New(errcode.Unavailable, "broadcast: subscriber buffer full")
+
+// defaultBuffer is the buffer size of a subscription without WithBuffer.
+const defaultBuffer = 16
+
+// Policy decides what Publish does when a subscriber's buffer is full.
+type Policy int
+
+const (
+	// Block makes Publish wait until the subscriber has room or is
+	// unsubscribed. No value is lost, but a slow subscriber slows every
+	// publisher.
+	Block Policy = iota
+
+	// DropOldest makes Publish discard the oldest buffered value to make
+	// room, so the subscriber always sees the most recent values.
+	DropOldest
+
+	// Error makes Publish unsubscribe the subscriber, closing its channel,
+	// and return OverflowError.
+	Error
+)
+
+// Topic delivers every published value to each of its subscribers. Values
+// from a single publisher are delivered in order; values published
+// concurrently may be delivered to different subscribers in different
+// orders. It is safe for concurrent use. The zero value is an open topic
+// without subscribers.
+type Topic[T any] struct {
+	mu     sync.Mutex
+	subs   map[*subscriber[T]]struct{}
+	closed bool
+}
+
+// subscriber is a single subscription to a Topic.
+type subscriber[T any] struct {
+	ch     chan T
+	policy Policy
+	done   chan struct{} // Closed when the subscription ends.
+	stop   func() bool   // Stops the context.AfterFunc unsubscribing it.
+
+	mu     sync.Mutex // Serialises sends with closing ch.
+	closed bool
+}
+
+// SubscribeOption configures a subscription.
+type SubscribeOption func(*subscribeConfig)
+
+// subscribeConfig holds the settings of a subscription.
+type subscribeConfig struct {
+	buffer int
+	policy Policy
+}
+
+// WithBuffer sets the number of values buffered for the subscriber. Values
+// below 0 are treated as 0.
+func WithBuffer(n int) SubscribeOption {
+	return func(c *subscribeConfig) {
+		c.buffer = max(n, 0)
+	}
+}
+
+// WithPolicy sets what Publish does when the subscriber's buffer is full.
+// A DropOldest subscriber always buffers at least one value.
+func WithPolicy(p Policy) SubscribeOption {
+	return func(c *subscribeConfig) {
+		c.policy = p
+	}
+}
+
+// New creates an open topic without subscribers.
+//
+// Example:
+//
+//	changes := broadcast.New[RegistryEvent]()
+//	events := changes.Subscribe(ctx, broadcast.WithPolicy(broadcast.DropOldest))
+//	go func() {
+//		for event := range events {
+//			log.Println(event)
+//		}
+//	}()
+//	_ = changes.Publish(RegistryEvent{Key: "orders"})
+func New[T any]() *Topic[T] {
+	return &Topic[T]{}
+}
+
+// Subscribe returns a channel receiving every value published from now on.
+// The subscription ends, and the channel is closed, when ctx is done, when
+// the topic is closed, or when an Error-policy subscriber overflows.
+// Without options the channel buffers 16 values and the policy is Block.
+//
+// Parameters:
+//   - ctx: Ends the subscription when done.
+//   - opts: Options setting the buffer size and overflow policy.
+//
+// Returns:
+//   - <-chan T: The channel of published values.
+func (t *Topic[T]) Subscribe(ctx context.Context, opts ...SubscribeOption) <-chan T {
+	cfg := subscribeConfig{buffer: defaultBuffer, policy: Block}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	if cfg.policy == DropOldest {
+		cfg.buffer = max(cfg.buffer, 1)
+	}
+	sub := &subscriber[T]{
+		ch:     make(chan T, cfg.buffer),
+		policy: cfg.policy,
+		done:   make(chan struct{}),
+	}
+	t.mu.Lock()
+	if t.closed || ctx.Err() != nil {
+		t.mu.Unlock()
+		sub.close()
+		return sub.ch
+	}
+	if t.subs == nil {
+		t.subs = make(map[*subscriber[T]]struct{})
+	}
+	t.subs[sub] = struct{}{}
+	sub.stop = context.AfterFunc(ctx, func() { t.unsubscribe(sub) })
+	t.mu.Unlock()
+	return sub.ch
+}
+
+// Publish delivers value to every current subscriber according to its
+// policy.
+//
+// Parameters:
+//   - value: The value to deliver.
+//
+// Returns:
+//   - error: ClosedError if the topic is closed; OverflowError if an
+//     Error-policy subscriber was full; nil otherwise.
+func (t *Topic[T]) Publish(value T) error {
+	t.mu.Lock()
+	if t.closed {
+		t.mu.Unlock()
+		return ClosedError
+	}
+	subs := make([]*subscriber[T], 0, len(t.subs))
+	for sub := range t.subs {
+		subs = append(subs, sub)
+	}
+	t.mu.Unlock()
+
+	var err error
+	for _, sub := range subs {
+		if !sub.deliver(value) {
+			t.unsubscribe(sub)
+			err = OverflowError
+		}
+	}
+	return err
+}
+
+// Len returns the number of current subscribers.
+func (t *Topic[T]) Len() int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return len(t.subs)
+}
+
+// Close ends every subscription and makes later calls to Publish fail with
+// ClosedError. Subscriptions made after Close receive a closed channel.
+func (t *Topic[T]) Close() {
+	t.mu.Lock()
+	t.closed = true
+	subs := t.subs
+	t.subs = nil
+	t.mu.Unlock()
+	for sub := range subs {
+		sub.stop()
+		sub.close()
+	}
+}
+
+// unsubscribe removes sub from the topic and closes its channel.
+func (t *Topic[T]) unsubscribe(sub *subscriber[T]) {
+	t.mu.Lock()
+	_, ok := t.subs[sub]
+	delete(t.subs, sub)
+	t.mu.Unlock()
+	if ok {
+		sub.stop()
+		sub.close()
+	}
+}
+
+// deliver sends value to the subscriber according to its policy. It
+// reports false if an Error-policy subscriber was full.
+func (s *subscriber[T]) deliver(value T) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		return true
+	}
+	switch s.policy {
+	case DropOldest:
+		for {
+			select {
+			case s.ch <- value:
+				return true
+			default:
+			}
+			select {
+			case <-s.ch:
+			default:
+			}
+		}
+	case Error:
+		select {
+		case s.ch <- value:
+			return true
+		default:
+			return false
+		}
+	default:
+		select {
+		case s.ch <- value:
+		case <-s.done:
+		}
+		return true
+	}
+}
+
+// close ends the subscription. It wakes a Publish blocked on the
+// subscriber before closing the channel, so the two cannot race. It is
+// called once, by whoever removed the subscriber from its topic.
+func (s *subscriber[T]) close() {
+	close(s.done)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.closed = true
+	close(s.ch)
+}
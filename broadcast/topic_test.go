@@ -0,0 +1,195 @@
+package broadcast
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+// drain receives every value from ch until it is closed.
+func drain[T any](ch <-chan T) []T {
+	var out []T
+	for v := range ch {
+		out = append(out, v)
+	}
+	return out
+}
+
+func TestTopic_FansOutToEverySubscriber(t *testing.T) {
+	// Arrange
+	topic := New[int]()
+	a := topic.Subscribe(context.Background())
+	b := topic.Subscribe(context.Background())
+
+	// Act
+	for i := 1; i <= 3; i++ {
+		if err := topic.Publish(i); err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+	}
+	topic.Close()
+
+	// Assert
+	for name, ch := range map[string]<-chan int{"a": a, "b": b} {
+		if got := drain(ch); len(got) != 3 || got[0] != 1 || got[2] != 3 {
+			t.Errorf("expected subscriber %s to receive [1 2 3], got %v", name, got)
+		}
+	}
+}
+
+func TestTopic_UnsubscribesOnContextCancel(t *testing.T) {
+	// Arrange
+	var topic Topic[string]
+	ctx, cancel := context.WithCancel(context.Background())
+	ch := topic.Subscribe(ctx)
+
+	// Act
+	cancel()
+
+	// Assert
+	select {
+	case _, ok := <-ch:
+		if ok {
+			t.Error("expected no values after cancellation")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("expected the channel to be closed after cancellation")
+	}
+	if topic.Len() != 0 {
+		t.Errorf("expected no subscribers, got %d", topic.Len())
+	}
+}
+
+func TestTopic_DropOldest(t *testing.T) {
+	// Arrange
+	topic := New[int]()
+	ch := topic.Subscribe(context.Background(), WithBuffer(2), WithPolicy(DropOldest))
+
+	// Act
+	for i := 1; i <= 5; i++ {
+		_ = topic.Publish(i)
+	}
+	topic.Close()
+
+	// Assert
+	if got := drain(ch); len(got) != 2 || got[0] != 4 || got[1] != 5 {
+		t.Errorf("expected the most recent values [4 5], got %v", got)
+	}
+}
+
+func TestTopic_ErrorPolicyUnsubscribesOnOverflow(t *testing.T) {
+	// Arrange
+	topic := New[int]()
+	slow := topic.Subscribe(context.Background(), WithBuffer(1), WithPolicy(Error))
+	other := topic.Subscribe(context.Background(), WithBuffer(4))
+
+	// Act
+	first := topic.Publish(1)
+	second := topic.Publish(2)
+
+	// Assert
+	if first != nil || !errors.Is(second, OverflowError) {
+		t.Errorf("expected nil then OverflowError, got %v, %v", first, second)
+	}
+	if got := drain(slow); len(got) != 1 || got[0] != 1 {
+		t.Errorf("expected the slow subscriber to be closed after [1], got %v", got)
+	}
+	if topic.Len() != 1 || len(other) != 2 {
+		t.Errorf("expected the other subscriber to keep receiving, got %d subscribers and %d values", topic.Len(), len(other))
+	}
+}
+
+func TestTopic_BlockWaitsForRoom(t *testing.T) {
+	// Arrange
+	topic := New[int]()
+	ch := topic.Subscribe(context.Background(), WithBuffer(0))
+	published := make(chan error)
+
+	// Act
+	go func() { published <- topic.Publish(1) }()
+
+	// Assert
+	select {
+	case <-published:
+		t.Fatal("expected Publish to block until the subscriber receives")
+	case <-time.After(20 * time.Millisecond):
+	}
+	if v := <-ch; v != 1 {
+		t.Errorf("expected 1, got %d", v)
+	}
+	if err := <-published; err != nil {
+		t.Errorf("expected no error, got %v", err)
+	}
+}
+
+func TestTopic_CancelReleasesBlockedPublish(t *testing.T) {
+	// Arrange
+	topic := New[int]()
+	ctx, cancel := context.WithCancel(context.Background())
+	_ = topic.Subscribe(ctx, WithBuffer(0))
+	published := make(chan error)
+	go func() { published <- topic.Publish(1) }()
+
+	// Act
+	time.Sleep(10 * time.Millisecond)
+	cancel()
+
+	// Assert
+	select {
+	case err := <-published:
+		if err != nil {
+			t.Errorf("expected no error, got %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("expected Publish to return once the subscriber was cancelled")
+	}
+}
+
+func TestTopic_Closed(t *testing.T) {
+	// Arrange
+	topic := New[int]()
+	topic.Close()
+
+	// Act
+	err := topic.Publish(1)
+	ch := topic.Subscribe(context.Background())
+
+	// Assert
+	if !errors.Is(err, ClosedError) {
+		t.Errorf("expected ClosedError, got %v", err)
+	}
+	if _, ok := <-ch; ok {
+		t.Error("expected a closed channel from a closed topic")
+	}
+}
+
+func TestTopic_ConcurrentUse(t *testing.T) {
+	// Arrange
+	topic := New[int]()
+	var wg sync.WaitGroup
+
+	// Act
+	for i := range 20 {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			ctx, cancel := context.WithCancel(context.Background())
+			ch := topic.Subscribe(ctx, WithPolicy(DropOldest))
+			cancel()
+			drain(ch)
+		}()
+		go func() {
+			defer wg.Done()
+			_ = topic.Publish(i)
+		}()
+	}
+	wg.Wait()
+	topic.Close()
+
+	// Assert
+	if topic.Len() != 0 {
+		t.Errorf("expected no subscribers, got %d", topic.Len())
+	}
+}
@@ -0,0 +1,269 @@
+// Package cache provides a generic, concurrency-safe LRU cache with
+// optional per-entry expiry and deduplicated loading.
+package cache
+
+import (
+	"container/list"
+	"context"
+	"sync"
+	"time"
+
+	"github.com/zodimo/go-zbase-std/clock"
+	"github.com/zodimo/go-zbase-std/internal/flight"
+	"github.com/zodimo/go-zbase-std/optional"
+)
+
+// EvictReason describes why an entry left the cache.
+type EvictReason int
+
+const (
+	// Capacity means the entry was the least recently used when the cache
+	// was full.
+	Capacity EvictReason = iota
+
+	// Expired means the entry outlived its time to live.
+	Expired
+
+	// Removed means the entry was removed by Delete or Purge.
+	Removed
+)
+
+// String returns the name of the reason.
+func (r EvictReason) String() string {
+	switch r {
+	case Capacity:
+		return "capacity"
+	case Expired:
+		return "expired"
+	case Removed:
+		return "removed"
+	default:
+		return "unknown"
+	}
+}
+
+// EvictFunc is called after an entry leaves the cache. It runs in the
+// goroutine that caused the eviction, outside the cache's lock.
+type EvictFunc[K comparable, V any] func(key K, value V, reason EvictReason)
+
+// Cache is a least-recently-used cache bounded by a number of entries.
+// Entries may also expire after a time to live; expired entries are
+// removed when they are next looked up or when they reach the end of the
+// LRU order. It is safe for concurrent use.
+type Cache[K comparable, V any] struct {
+	mu      sync.Mutex
+	entries map[K]*list.Element // Values are *entry[K, V].
+	order   *list.List          // Most recently used at the front.
+	loads   flight.Group[K, V]  // Shares the loads in flight.
+
+	maxEntries int
+	ttl        time.Duration
+	onEvict    EvictFunc[K, V]
+	clock      clock.Clock
+}
+
+// entry is a cached key-value pair.
+type entry[K comparable, V any] struct {
+	key       K
+	value     V
+	expiresAt time.Time // Zero if the entry does not expire.
+}
+
+// eviction records an evicted entry whose callback is still to be run.
+type eviction[K comparable, V any] struct {
+	key    K
+	value  V
+	reason EvictReason
+}
+
+// New creates an empty cache.
+//
+// Parameters:
+//   - maxEntries: The maximum number of entries. Values below 1 leave the
+//     cache unbounded.
+//   - opts: Options configuring expiry and eviction callbacks.
+//
+// Returns:
+//   - *Cache[K, V]: The new cache.
+//
+// Example:
+//
+//	users := cache.New(1000, cache.WithTTL[int, User](time.Minute))
+//	user, err := users.GetOrLoad(ctx, id, func(ctx context.Context) (User, error) {
+//		return db.LoadUser(ctx, id)
+//	})
+func New[K comparable, V any](maxEntries int, opts ...Option[K, V]) *Cache[K, V] {
+	c := &Cache[K, V]{
+		entries:    make(map[K]*list.Element),
+		order:      list.New(),
+		maxEntries: maxEntries,
+		clock:      clock.System(),
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// Get returns the value cached under key and marks it as recently used, or
+// returns None if the key is absent or its entry has expired.
+func (c *Cache[K, V]) Get(key K) optional.Option[V] {
+	value, ok := c.get(key)
+	if !ok {
+		return optional.None[V]()
+	}
+	return optional.Some(value)
+}
+
+// get is Get returning a (value, ok) pair.
+func (c *Cache[K, V]) get(key K) (V, bool) {
+	var evicted []eviction[K, V]
+	defer func() { c.notify(evicted) }()
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	elem, ok := c.entries[key]
+	if !ok {
+		var zero V
+		return zero, false
+	}
+	e := elem.Value.(*entry[K, V])
+	if c.expired(e) {
+		evicted = append(evicted, c.remove(elem, Expired))
+		var zero V
+		return zero, false
+	}
+	c.order.MoveToFront(elem)
+	return e.value, true
+}
+
+// Set caches value under key with the cache's default time to live,
+// evicting the least recently used entry if the cache is full.
+func (c *Cache[K, V]) Set(key K, value V) {
+	c.SetWithTTL(key, value, c.ttl)
+}
+
+// SetWithTTL caches value under key, expiring it after ttl. A ttl of zero
+// or less keeps the entry until it is evicted or removed.
+func (c *Cache[K, V]) SetWithTTL(key K, value V, ttl time.Duration) {
+	var evicted []eviction[K, V]
+	defer func() { c.notify(evicted) }()
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	evicted = c.set(key, value, ttl)
+}
+
+// set stores an entry and returns the entries evicted to make room. The
+// caller must hold c.mu.
+func (c *Cache[K, V]) set(key K, value V, ttl time.Duration) []eviction[K, V] {
+	var expiresAt time.Time
+	if ttl > 0 {
+		expiresAt = c.clock.Now().Add(ttl)
+	}
+	if elem, ok := c.entries[key]; ok {
+		e := elem.Value.(*entry[K, V])
+		e.value, e.expiresAt = value, expiresAt
+		c.order.MoveToFront(elem)
+		return nil
+	}
+	c.entries[key] = c.order.PushFront(&entry[K, V]{key: key, value: value, expiresAt: expiresAt})
+	var evicted []eviction[K, V]
+	for c.maxEntries > 0 && c.order.Len() > c.maxEntries {
+		oldest := c.order.Back()
+		reason := Capacity
+		if c.expired(oldest.Value.(*entry[K, V])) {
+			reason = Expired
+		}
+		evicted = append(evicted, c.remove(oldest, reason))
+	}
+	return evicted
+}
+
+// Delete removes the entry cached under key and reports whether it was
+// present.
+func (c *Cache[K, V]) Delete(key K) bool {
+	var evicted []eviction[K, V]
+	defer func() { c.notify(evicted) }()
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	elem, ok := c.entries[key]
+	if !ok {
+		return false
+	}
+	evicted = append(evicted, c.remove(elem, Removed))
+	return true
+}
+
+// Purge removes every entry.
+func (c *Cache[K, V]) Purge() {
+	var evicted []eviction[K, V]
+	defer func() { c.notify(evicted) }()
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for elem := c.order.Back(); elem != nil; elem = c.order.Back() {
+		evicted = append(evicted, c.remove(elem, Removed))
+	}
+}
+
+// Len returns the number of entries, including expired entries that have
+// not been removed yet.
+func (c *Cache[K, V]) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.order.Len()
+}
+
+// GetOrLoad returns the value cached under key, calling loader to produce
+// and cache it if the key is absent. Concurrent calls for the same key
+// share a single call to loader: callers that arrive while a load is in
+// flight wait for its outcome or for their own ctx to be done, whichever
+// comes first. Errors are returned to the callers sharing the load and are
+// not cached.
+//
+// Parameters:
+//   - ctx: The context bounding the wait, passed to loader.
+//   - key: The key to look up.
+//   - loader: Produces the value for key when it is not cached.
+//
+// Returns:
+//   - V: The cached or loaded value, or the zero value of V on failure.
+//   - error: The loader's error, or ctx's error if ctx is done first.
+func (c *Cache[K, V]) GetOrLoad(ctx context.Context, key K, loader func(context.Context) (V, error)) (V, error) {
+	if value, ok := c.get(key); ok {
+		return value, nil
+	}
+	return c.loads.Do(ctx, key, "cache: loader", func(ctx context.Context) (V, error) {
+		if value, ok := c.get(key); ok {
+			return value, nil // Cached by a load that finished meanwhile.
+		}
+		value, err := loader(ctx)
+		if err == nil {
+			c.Set(key, value)
+		}
+		return value, err
+	})
+}
+
+// expired reports whether e has outlived its time to live. The caller must
+// hold c.mu.
+func (c *Cache[K, V]) expired(e *entry[K, V]) bool {
+	return !e.expiresAt.IsZero() && !c.clock.Now().Before(e.expiresAt)
+}
+
+// remove unlinks elem and returns its eviction record. The caller must hold
+// c.mu.
+func (c *Cache[K, V]) remove(elem *list.Element, reason EvictReason) eviction[K, V] {
+	e := c.order.Remove(elem).(*entry[K, V])
+	delete(c.entries, e.key)
+	return eviction[K, V]{key: e.key, value: e.value, reason: reason}
+}
+
+// notify runs the eviction callback for each evicted entry. It must be
+// called without holding c.mu.
+func (c *Cache[K, V]) notify(evicted []eviction[K, V]) {
+	if c.onEvict == nil {
+		return
+	}
+	for _, ev := range evicted {
+		c.onEvict(ev.key, ev.value, ev.reason)
+	}
+}
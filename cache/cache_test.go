@@ -0,0 +1,225 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/zodimo/go-zbase-std/clock"
+)
+
+func TestCache_GetAndSet(t *testing.T) {
+	// Arrange
+	c := New[string, int](2)
+	c.Set("a", 1)
+
+	// Act
+	present := c.Get("a")
+	absent := c.Get("b")
+
+	// Assert
+	if v, ok := present.Value(); !ok || v != 1 {
+		t.Errorf("expected Some(1), got %v, %v", v, ok)
+	}
+	if _, ok := absent.Value(); ok {
+		t.Error("expected None for an absent key")
+	}
+}
+
+func TestCache_EvictsLeastRecentlyUsed(t *testing.T) {
+	// Arrange
+	var evicted []string
+	var reasons []EvictReason
+	c := New(2, WithOnEvict(func(key string, _ int, reason EvictReason) {
+		evicted = append(evicted, key)
+		reasons = append(reasons, reason)
+	}))
+	c.Set("a", 1)
+	c.Set("b", 2)
+	c.Get("a") // b is now the least recently used
+
+	// Act
+	c.Set("c", 3)
+
+	// Assert
+	if len(evicted) != 1 || evicted[0] != "b" || reasons[0] != Capacity {
+		t.Errorf("expected b evicted for capacity, got %v %v", evicted, reasons)
+	}
+	if c.Len() != 2 {
+		t.Errorf("expected 2 entries, got %d", c.Len())
+	}
+}
+
+func TestCache_TTL(t *testing.T) {
+	// Arrange
+	fake := clock.NewFakeClock(time.Unix(0, 0))
+	var reason EvictReason = -1
+	c := New(10,
+		WithTTL[string, int](time.Minute),
+		WithClock[string, int](fake),
+		WithOnEvict(func(_ string, _ int, r EvictReason) { reason = r }),
+	)
+	c.Set("a", 1)
+	c.SetWithTTL("forever", 2, 0)
+
+	// Act
+	fake.Advance(time.Minute)
+	expired := c.Get("a")
+	kept := c.Get("forever")
+
+	// Assert
+	if _, ok := expired.Value(); ok {
+		t.Error("expected the entry to have expired")
+	}
+	if reason != Expired {
+		t.Errorf("expected eviction reason %v, got %v", Expired, reason)
+	}
+	if _, ok := kept.Value(); !ok {
+		t.Error("expected an entry without a TTL to be kept")
+	}
+}
+
+func TestCache_DeleteAndPurge(t *testing.T) {
+	// Arrange
+	removed := 0
+	c := New(0, WithOnEvict(func(string, int, EvictReason) { removed++ }))
+	c.Set("a", 1)
+	c.Set("b", 2)
+	c.Set("c", 3)
+
+	// Act
+	deleted := c.Delete("a")
+	missing := c.Delete("a")
+	c.Purge()
+
+	// Assert
+	if !deleted || missing {
+		t.Errorf("expected deletes true, false, got %v, %v", deleted, missing)
+	}
+	if removed != 3 || c.Len() != 0 {
+		t.Errorf("expected 3 removals and an empty cache, got %d and %d", removed, c.Len())
+	}
+}
+
+func TestCache_GetOrLoadDeduplicates(t *testing.T) {
+	// Arrange
+	c := New[string, int](10)
+	var calls atomic.Int32
+	release := make(chan struct{})
+	loader := func(context.Context) (int, error) {
+		calls.Add(1)
+		<-release
+		return 42, nil
+	}
+	var wg sync.WaitGroup
+	results := make([]int, 5)
+
+	// Act
+	for i := range results {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i], _ = c.GetOrLoad(context.Background(), "k", loader)
+		}()
+	}
+	time.Sleep(10 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	// Assert
+	if calls.Load() != 1 {
+		t.Errorf("expected 1 loader call, got %d", calls.Load())
+	}
+	for _, v := range results {
+		if v != 42 {
+			t.Errorf("expected 42, got %d", v)
+		}
+	}
+	cached := c.Get("k")
+	if v, _ := cached.Value(); v != 42 {
+		t.Errorf("expected the loaded value to be cached, got %d", v)
+	}
+}
+
+func TestCache_GetOrLoadErrorNotCached(t *testing.T) {
+	// Arrange
+	c := New[string, int](10)
+	failure := errors.New("boom")
+	calls := 0
+	loader := func(context.Context) (int, error) {
+		calls++
+		if calls == 1 {
+			return 0, failure
+		}
+		return 7, nil
+	}
+
+	// Act
+	_, firstErr := c.GetOrLoad(context.Background(), "k", loader)
+	value, secondErr := c.GetOrLoad(context.Background(), "k", loader)
+
+	// Assert
+	if !errors.Is(firstErr, failure) {
+		t.Errorf("expected %v, got %v", failure, firstErr)
+	}
+	if secondErr != nil || value != 7 {
+		t.Errorf("expected 7 after retrying, got %d, %v", value, secondErr)
+	}
+}
+
+func TestCache_GetOrLoadWaiterRetriesAbandonedLoad(t *testing.T) {
+	// Arrange
+	c := New[string, int](10)
+	ctx, cancel := context.WithCancel(context.Background())
+	started := make(chan struct{})
+	go func() {
+		_, _ = c.GetOrLoad(ctx, "k", func(ctx context.Context) (int, error) {
+			close(started)
+			<-ctx.Done()
+			return 0, ctx.Err()
+		})
+	}()
+	<-started
+	result := make(chan int)
+	go func() {
+		v, _ := c.GetOrLoad(context.Background(), "k", func(context.Context) (int, error) {
+			return 9, nil
+		})
+		result <- v
+	}()
+
+	// Act
+	time.Sleep(10 * time.Millisecond)
+	cancel()
+
+	// Assert
+	select {
+	case v := <-result:
+		if v != 9 {
+			t.Errorf("expected the waiter to load 9 itself, got %d", v)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("expected the waiter to retry the abandoned load")
+	}
+}
+
+func TestCache_GetOrLoadContextDone(t *testing.T) {
+	// Arrange
+	c := New[string, int](10)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	// Act
+	_, err := c.GetOrLoad(ctx, "k", func(ctx context.Context) (int, error) {
+		<-ctx.Done()
+		return 0, ctx.Err()
+	})
+
+	// Assert
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected context.DeadlineExceeded, got %v", err)
+	}
+}
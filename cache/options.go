@@ -0,0 +1,34 @@
+package cache
+
+import (
+	"time"
+
+	"github.com/zodimo/go-zbase-std/clock"
+)
+
+// Option configures a Cache created by New.
+type Option[K comparable, V any] func(*Cache[K, V])
+
+// WithTTL expires entries stored by Set and GetOrLoad d after they are
+// stored. Without it, entries only leave the cache when evicted or removed.
+func WithTTL[K comparable, V any](d time.Duration) Option[K, V] {
+	return func(c *Cache[K, V]) {
+		c.ttl = d
+	}
+}
+
+// WithOnEvict calls fn whenever an entry leaves the cache, including on
+// Delete and Purge. Replacing the value of a cached key does not call fn.
+func WithOnEvict[K comparable, V any](fn EvictFunc[K, V]) Option[K, V] {
+	return func(c *Cache[K, V]) {
+		c.onEvict = fn
+	}
+}
+
+// WithClock makes the cache measure time to live with the given clock
+// instead of the system clock.
+func WithClock[K comparable, V any](c clock.Clock) Option[K, V] {
+	return func(cache *Cache[K, V]) {
+		cache.clock = c
+	}
+}
@@ -0,0 +1,82 @@
+package chans
+
+import (
+	"context"
+	"time"
+)
+
+// Batch groups the values received from in into slices. A batch is emitted
+// as soon as it holds maxSize values, or once maxWait has passed since its
+// first value was received, whichever comes first. When in is closed, the
+// pending partial batch is emitted and the output is closed. When ctx is
+// done, the output is closed and the pending batch is dropped. Values below
+// 1 for maxSize are treated as 1; a maxWait of zero or less disables the
+// time threshold.
+//
+// Parameters:
+//   - ctx: Stops the batching when done.
+//   - in: The channel to batch.
+//   - maxSize: The size at which a batch is emitted.
+//   - maxWait: How long the first value of a batch may wait.
+//
+// Returns:
+//   - <-chan []T: The channel of batches. Batches are never empty.
+//
+// Example:
+//
+//	for rows := range chans.Batch(ctx, inserts, 500, 100*time.Millisecond) {
+//		db.BulkInsert(ctx, rows)
+//	}
+func Batch[T any](ctx context.Context, in <-chan T, maxSize int, maxWait time.Duration) <-chan []T {
+	maxSize = max(maxSize, 1)
+	out := make(chan []T)
+	go func() {
+		defer close(out)
+		var batch []T
+		var timer *time.Timer
+		var deadline <-chan time.Time // Nil while the batch is empty.
+		emit := func() bool {
+			if timer != nil {
+				timer.Stop()
+			}
+			deadline = nil
+			select {
+			case out <- batch:
+				batch = nil
+				return true
+			case <-ctx.Done():
+				return false
+			}
+		}
+		for {
+			select {
+			case value, ok := <-in:
+				if !ok {
+					if len(batch) > 0 {
+						emit()
+					}
+					return
+				}
+				batch = append(batch, value)
+				if len(batch) == 1 && maxWait > 0 {
+					if timer == nil {
+						timer = time.NewTimer(maxWait)
+					} else {
+						timer.Reset(maxWait)
+					}
+					deadline = timer.C
+				}
+				if len(batch) >= maxSize && !emit() {
+					return
+				}
+			case <-deadline:
+				if !emit() {
+					return
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return out
+}
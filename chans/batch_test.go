@@ -0,0 +1,69 @@
+package chans
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestBatch_BySize(t *testing.T) {
+	// Arrange
+	in := source(1, 2, 3, 4, 5)
+
+	// Act
+	batches := drain(Batch(context.Background(), in, 2, time.Hour))
+
+	// Assert
+	if len(batches) != 3 || len(batches[0]) != 2 || len(batches[1]) != 2 || len(batches[2]) != 1 {
+		t.Errorf("expected batches of 2, 2 and 1, got %v", batches)
+	}
+	if batches[2][0] != 5 {
+		t.Errorf("expected the final partial batch to hold 5, got %v", batches[2])
+	}
+}
+
+func TestBatch_ByTime(t *testing.T) {
+	// Arrange
+	in := make(chan int)
+	out := Batch(context.Background(), in, 100, 20*time.Millisecond)
+
+	// Act
+	in <- 1
+	in <- 2
+	var batch []int
+	select {
+	case batch = <-out:
+	case <-time.After(time.Second):
+		t.Fatal("expected a batch once maxWait passed")
+	}
+	close(in)
+
+	// Assert
+	if len(batch) != 2 {
+		t.Errorf("expected a batch of 2, got %v", batch)
+	}
+	if rest := drain(out); len(rest) != 0 {
+		t.Errorf("expected no further batches, got %v", rest)
+	}
+}
+
+func TestBatch_Cancelled(t *testing.T) {
+	// Arrange
+	ctx, cancel := context.WithCancel(context.Background())
+	in := make(chan int)
+	out := Batch(ctx, in, 10, time.Hour)
+	in <- 1
+
+	// Act
+	cancel()
+
+	// Assert
+	select {
+	case batch, ok := <-out:
+		if ok {
+			t.Errorf("expected the pending batch to be dropped, got %v", batch)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("expected the output to be closed after cancellation")
+	}
+}
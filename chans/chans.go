@@ -0,0 +1,156 @@
+// Package chans provides channel combinators that terminate cleanly: every
+// output channel is closed once its input is exhausted or the context is
+// done, so the goroutines they start never leak.
+package chans
+
+import (
+	"context"
+	"sync"
+)
+
+// Merge forwards the values received from every channel in chs to a single
+// output channel, in the order they arrive. The output is closed once all
+// inputs are closed or ctx is done.
+//
+// Parameters:
+//   - ctx: Stops the forwarding when done.
+//   - chs: The channels to merge.
+//
+// Returns:
+//   - <-chan T: The merged channel.
+//
+// Example:
+//
+//	for event := range chans.Merge(ctx, clicks, keys) {
+//		handle(event)
+//	}
+func Merge[T any](ctx context.Context, chs ...<-chan T) <-chan T {
+	out := make(chan T)
+	var wg sync.WaitGroup
+	wg.Add(len(chs))
+	for _, ch := range chs {
+		go func() {
+			defer wg.Done()
+			forward(ctx, ch, out)
+		}()
+	}
+	go func() {
+		wg.Wait()
+		close(out)
+	}()
+	return out
+}
+
+// FanOut distributes the values received from in across n output channels:
+// each value is delivered to exactly one output, whichever is ready to
+// receive first. Every output is closed once in is closed or ctx is done.
+// Values below 1 for n are treated as 1.
+//
+// Parameters:
+//   - ctx: Stops the distribution when done.
+//   - in: The channel to distribute.
+//   - n: The number of output channels.
+//
+// Returns:
+//   - []<-chan T: The output channels.
+//
+// Example:
+//
+//	for _, jobs := range chans.FanOut(ctx, queue, 4) {
+//		go worker(jobs)
+//	}
+func FanOut[T any](ctx context.Context, in <-chan T, n int) []<-chan T {
+	outs := make([]<-chan T, max(n, 1))
+	for i := range outs {
+		out := make(chan T)
+		outs[i] = out
+		go func() {
+			defer close(out)
+			forward(ctx, in, out)
+		}()
+	}
+	return outs
+}
+
+// Tee copies every value received from in to each of n output channels.
+// A value is delivered to all outputs before the next one is received, so
+// the slowest consumer sets the pace. Every output is closed once in is
+// closed or ctx is done. Values below 1 for n are treated as 1.
+//
+// Parameters:
+//   - ctx: Stops the copying when done.
+//   - in: The channel to copy.
+//   - n: The number of output channels.
+//
+// Returns:
+//   - []<-chan T: The output channels.
+//
+// Example:
+//
+//	copies := chans.Tee(ctx, events, 2)
+//	go audit(copies[0])
+//	process(copies[1])
+func Tee[T any](ctx context.Context, in <-chan T, n int) []<-chan T {
+	outs := make([]chan T, max(n, 1))
+	results := make([]<-chan T, len(outs))
+	for i := range outs {
+		outs[i] = make(chan T)
+		results[i] = outs[i]
+	}
+	go func() {
+		defer func() {
+			for _, out := range outs {
+				close(out)
+			}
+		}()
+		for {
+			value, ok := receive(ctx, in)
+			if !ok {
+				return
+			}
+			// Send to every output concurrently, so consumers may receive
+			// their copies in any order.
+			var wg sync.WaitGroup
+			wg.Add(len(outs))
+			for _, out := range outs {
+				go func() {
+					defer wg.Done()
+					select {
+					case out <- value:
+					case <-ctx.Done():
+					}
+				}()
+			}
+			wg.Wait()
+		}
+	}()
+	return results
+}
+
+// forward sends the values received from in to out until in is closed or
+// ctx is done.
+func forward[T any](ctx context.Context, in <-chan T, out chan<- T) {
+	for {
+		value, ok := receive(ctx, in)
+		if !ok {
+			return
+		}
+		select {
+		case out <- value:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// receive returns the next value from in, or false if in is closed or ctx
+// is done.
+func receive[T any](ctx context.Context, in <-chan T) (T, bool) {
+	select {
+	case value, ok := <-in:
+		return value, ok
+	case <-ctx.Done():
+		var zero T
+		return zero, false
+	}
+}
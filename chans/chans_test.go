@@ -0,0 +1,144 @@
+package chans
+
+import (
+	"context"
+	"runtime"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+)
+
+// source returns a channel that yields values and is then closed.
+func source[T any](values ...T) <-chan T {
+	ch := make(chan T)
+	go func() {
+		defer close(ch)
+		for _, v := range values {
+			ch <- v
+		}
+	}()
+	return ch
+}
+
+// drain receives every value from ch until it is closed.
+func drain[T any](ch <-chan T) []T {
+	var out []T
+	for v := range ch {
+		out = append(out, v)
+	}
+	return out
+}
+
+func TestMerge(t *testing.T) {
+	// Arrange
+	a, b := source(1, 2, 3), source(4, 5)
+
+	// Act
+	got := drain(Merge(context.Background(), a, b))
+
+	// Assert
+	slices.Sort(got)
+	if !slices.Equal(got, []int{1, 2, 3, 4, 5}) {
+		t.Errorf("expected [1 2 3 4 5], got %v", got)
+	}
+}
+
+func TestMerge_NoInputs(t *testing.T) {
+	// Act
+	got := drain(Merge[int](context.Background()))
+
+	// Assert
+	if len(got) != 0 {
+		t.Errorf("expected no values, got %v", got)
+	}
+}
+
+func TestFanOut(t *testing.T) {
+	// Arrange
+	in := source(1, 2, 3, 4, 5, 6)
+
+	// Act
+	outs := FanOut(context.Background(), in, 3)
+	var mu sync.Mutex
+	var got []int
+	var wg sync.WaitGroup
+	for _, out := range outs {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for v := range out {
+				mu.Lock()
+				got = append(got, v)
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+
+	// Assert
+	if len(outs) != 3 {
+		t.Errorf("expected 3 outputs, got %d", len(outs))
+	}
+	slices.Sort(got)
+	if !slices.Equal(got, []int{1, 2, 3, 4, 5, 6}) {
+		t.Errorf("expected every value exactly once, got %v", got)
+	}
+}
+
+func TestTee(t *testing.T) {
+	// Arrange
+	in := source("a", "b", "c")
+
+	// Act
+	outs := Tee(context.Background(), in, 2)
+	results := make([][]string, len(outs))
+	var wg sync.WaitGroup
+	for i, out := range outs {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i] = drain(out)
+		}()
+	}
+	wg.Wait()
+
+	// Assert
+	for i, got := range results {
+		if !slices.Equal(got, []string{"a", "b", "c"}) {
+			t.Errorf("expected output %d to receive [a b c], got %v", i, got)
+		}
+	}
+}
+
+func TestCancellationClosesOutputs(t *testing.T) {
+	// Arrange: inputs that never close
+	ctx, cancel := context.WithCancel(context.Background())
+	blocked := make(chan int)
+	before := runtime.NumGoroutine()
+	merged := Merge(ctx, blocked, blocked)
+	fanned := FanOut(ctx, blocked, 2)
+	teed := Tee(ctx, blocked, 2)
+
+	// Act
+	cancel()
+
+	// Assert
+	for _, ch := range append(append([]<-chan int{merged}, fanned...), teed...) {
+		select {
+		case _, ok := <-ch:
+			if ok {
+				t.Error("expected no values after cancellation")
+			}
+		case <-time.After(time.Second):
+			t.Fatal("expected the output to be closed after cancellation")
+		}
+	}
+	deadline := time.Now().Add(time.Second)
+	for runtime.NumGoroutine() > before && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	if n := runtime.NumGoroutine(); n > before {
+		t.Errorf("expected goroutines to exit, %d remain above the baseline", n-before)
+	}
+}
@@ -0,0 +1,79 @@
+package chans
+
+import (
+	"context"
+
+	"github.com/zodimo/go-zbase-std/errcode"
+	"github.com/zodimo/go-zbase-std/optional"
+)
+
+// ClosedError is returned by Send and Recv when the channel is closed.
+var ClosedError = errcode.New(errcode.Unavailable, "chans: channel closed")
+
+// Send sends value to ch, giving up once ctx is done. Unlike a bare send
+// statement, sending to a closed channel returns ClosedError instead of
+// panicking.
+//
+// Parameters:
+//   - ctx: Bounds how long Send blocks.
+//   - ch: The channel to send to.
+//   - value: The value to send.
+//
+// Returns:
+//   - error: ctx.Err() if ctx is done first; ClosedError if ch is closed;
+//     nil once the value has been sent.
+//
+// Example:
+//
+//	if err := chans.Send(ctx, jobs, job); err != nil {
+//		return err
+//	}
+func Send[T any](ctx context.Context, ch chan<- T, value T) (err error) {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	defer func() {
+		// A send on a closed channel is the only way the select can panic.
+		if recover() != nil {
+			err = ClosedError
+		}
+	}()
+	select {
+	case ch <- value:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Recv receives the next value from ch, giving up once ctx is done.
+//
+// Parameters:
+//   - ctx: Bounds how long Recv blocks.
+//   - ch: The channel to receive from.
+//
+// Returns:
+//   - optional.Option[T]: The received value, or None on error.
+//   - error: ctx.Err() if ctx is done first; ClosedError if ch is closed
+//     and drained; nil once a value has been received.
+//
+// Example:
+//
+//	next, err := chans.Recv(ctx, results)
+//	if errors.Is(err, chans.ClosedError) {
+//		// No more results
+//	}
+func Recv[T any](ctx context.Context, ch <-chan T) (optional.Option[T], error) {
+	if err := ctx.Err(); err != nil {
+		return optional.None[T](), err
+	}
+	select {
+	case value, ok := <-ch:
+		if !ok {
+			return optional.None[T](), ClosedError
+		}
+		return optional.Some(value), nil
+	case <-ctx.Done():
+		return optional.None[T](), ctx.Err()
+	}
+}
@@ -0,0 +1,87 @@
+package chans
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestSend(t *testing.T) {
+	// Arrange
+	ch := make(chan int, 1)
+
+	// Act
+	err := Send(context.Background(), ch, 7)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if v := <-ch; v != 7 {
+		t.Errorf("expected 7, got %d", v)
+	}
+}
+
+func TestSend_ContextDone(t *testing.T) {
+	// Arrange
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	// Act
+	err := Send(ctx, make(chan int), 1)
+
+	// Assert
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected context.DeadlineExceeded, got %v", err)
+	}
+}
+
+func TestSend_ClosedChannel(t *testing.T) {
+	// Arrange
+	ch := make(chan int)
+	close(ch)
+
+	// Act
+	err := Send(context.Background(), ch, 1)
+
+	// Assert
+	if !errors.Is(err, ClosedError) {
+		t.Errorf("expected ClosedError, got %v", err)
+	}
+}
+
+func TestRecv(t *testing.T) {
+	// Arrange
+	ch := make(chan string, 1)
+	ch <- "hello"
+	close(ch)
+
+	// Act
+	first, firstErr := Recv(context.Background(), ch)
+	second, secondErr := Recv(context.Background(), ch)
+
+	// Assert
+	if v, ok := first.Value(); !ok || v != "hello" || firstErr != nil {
+		t.Errorf("expected Some(hello), got %v, %v, %v", v, ok, firstErr)
+	}
+	if _, ok := second.Value(); ok || !errors.Is(secondErr, ClosedError) {
+		t.Errorf("expected None and ClosedError from a drained channel, got %v, %v", ok, secondErr)
+	}
+}
+
+func TestRecv_ContextDone(t *testing.T) {
+	// Arrange
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	ch := make(chan int, 1)
+	ch <- 1
+
+	// Act
+	value, err := Recv(ctx, ch)
+
+	// Assert
+	if _, ok := value.Value(); ok || !errors.Is(err, context.Canceled) {
+		t.Errorf("expected None and context.Canceled, got %v, %v", ok, err)
+	}
+}
@@ -0,0 +1,132 @@
+package chans
+
+import (
+	"context"
+	"time"
+)
+
+// Debounce forwards a value from in only once in has been quiet for the
+// given duration, dropping the values it superseded. Bursts of values, such
+// as the events a file watcher reports for a single save, are thereby
+// reduced to their last value. When in is closed, a pending value is
+// forwarded before the output is closed. When ctx is done, the output is
+// closed and a pending value is dropped.
+//
+// Parameters:
+//   - ctx: Stops the debouncing when done.
+//   - in: The channel to debounce.
+//   - quiet: How long in must be quiet before the last value is forwarded.
+//
+// Returns:
+//   - <-chan T: The debounced channel.
+//
+// Example:
+//
+//	for range chans.Debounce(ctx, watcher.Events, 200*time.Millisecond) {
+//		reloadConfig()
+//	}
+func Debounce[T any](ctx context.Context, in <-chan T, quiet time.Duration) <-chan T {
+	out := make(chan T)
+	go func() {
+		defer close(out)
+		timer := time.NewTimer(quiet)
+		timer.Stop()
+		var pending T
+		var waiting bool
+		for {
+			select {
+			case value, ok := <-in:
+				if !ok {
+					if waiting {
+						send(ctx, out, pending)
+					}
+					return
+				}
+				pending, waiting = value, true
+				timer.Reset(quiet)
+			case <-timer.C:
+				waiting = false
+				if !send(ctx, out, pending) {
+					return
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return out
+}
+
+// Throttle forwards at most one value from in per interval. The first
+// value is forwarded immediately; values arriving during the following
+// interval replace each other, and the latest is forwarded when the
+// interval ends, so the most recent value is never lost. When in is closed,
+// a pending value is forwarded before the output is closed. When ctx is
+// done, the output is closed and a pending value is dropped.
+//
+// Parameters:
+//   - ctx: Stops the throttling when done.
+//   - in: The channel to throttle.
+//   - interval: The minimum time between forwarded values.
+//
+// Returns:
+//   - <-chan T: The throttled channel.
+//
+// Example:
+//
+//	for progress := range chans.Throttle(ctx, updates, time.Second) {
+//		render(progress)
+//	}
+func Throttle[T any](ctx context.Context, in <-chan T, interval time.Duration) <-chan T {
+	out := make(chan T)
+	go func() {
+		defer close(out)
+		timer := time.NewTimer(interval)
+		timer.Stop()
+		var pending T
+		var waiting, cooling bool // cooling is set while an interval runs.
+		for {
+			select {
+			case value, ok := <-in:
+				if !ok {
+					if waiting {
+						send(ctx, out, pending)
+					}
+					return
+				}
+				if cooling {
+					pending, waiting = value, true
+					continue
+				}
+				if !send(ctx, out, value) {
+					return
+				}
+				cooling = true
+				timer.Reset(interval)
+			case <-timer.C:
+				if !waiting {
+					cooling = false
+					continue
+				}
+				waiting = false
+				if !send(ctx, out, pending) {
+					return
+				}
+				timer.Reset(interval)
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return out
+}
+
+// send sends value to out, reporting false if ctx is done first.
+func send[T any](ctx context.Context, out chan<- T, value T) bool {
+	select {
+	case out <- value:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
@@ -0,0 +1,96 @@
+package chans
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestDebounce_ForwardsLastValueOfBurst(t *testing.T) {
+	// Arrange
+	in := make(chan int)
+	out := Debounce(context.Background(), in, 30*time.Millisecond)
+
+	// Act
+	for i := 1; i <= 5; i++ {
+		in <- i
+	}
+	var first int
+	select {
+	case first = <-out:
+	case <-time.After(time.Second):
+		t.Fatal("expected a value once the input was quiet")
+	}
+	in <- 6
+	close(in)
+	rest := drain(out)
+
+	// Assert
+	if first != 5 {
+		t.Errorf("expected the last value of the burst, 5, got %d", first)
+	}
+	if len(rest) != 1 || rest[0] != 6 {
+		t.Errorf("expected the pending value 6 to be flushed on close, got %v", rest)
+	}
+}
+
+func TestDebounce_Cancelled(t *testing.T) {
+	// Arrange
+	ctx, cancel := context.WithCancel(context.Background())
+	in := make(chan int)
+	out := Debounce(ctx, in, time.Hour)
+	in <- 1
+
+	// Act
+	cancel()
+
+	// Assert
+	if got := drain(out); len(got) != 0 {
+		t.Errorf("expected the pending value to be dropped, got %v", got)
+	}
+}
+
+func TestThrottle_LimitsRateAndKeepsLatest(t *testing.T) {
+	// Arrange
+	in := make(chan int)
+	out := Throttle(context.Background(), in, 50*time.Millisecond)
+	received := make(chan []int)
+	go func() { received <- drain(out) }()
+
+	// Act
+	start := time.Now()
+	for i := 1; i <= 5; i++ {
+		in <- i
+	}
+	time.Sleep(80 * time.Millisecond)
+	close(in)
+	got := <-received
+
+	// Assert
+	if len(got) != 2 || got[0] != 1 || got[1] != 5 {
+		t.Errorf("expected the first and latest values [1 5], got %v", got)
+	}
+	if elapsed := time.Since(start); elapsed < 50*time.Millisecond {
+		t.Errorf("expected the second value after the interval, got it after %v", elapsed)
+	}
+}
+
+func TestThrottle_PassesSpacedValues(t *testing.T) {
+	// Arrange
+	in := make(chan int)
+	out := Throttle(context.Background(), in, 10*time.Millisecond)
+	received := make(chan []int)
+	go func() { received <- drain(out) }()
+
+	// Act
+	for i := 1; i <= 3; i++ {
+		in <- i
+		time.Sleep(30 * time.Millisecond)
+	}
+	close(in)
+
+	// Assert
+	if got := <-received; len(got) != 3 {
+		t.Errorf("expected all 3 spaced values, got %v", got)
+	}
+}
@@ -0,0 +1,110 @@
+// Package cleanup collects teardown functions so that code acquiring
+// several resources can release them all, in reverse order, whether it
+// succeeds or fails part way.
+package cleanup
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/zodimo/go-zbase-std/multierr"
+)
+
+// Stack holds teardown functions and runs them last in, first out. It is
+// safe for concurrent use. The zero value is an empty stack ready to use.
+//
+// Example:
+//
+//	func NewServer(ctx context.Context) (*Server, error) {
+//		var s cleanup.Stack
+//		defer s.Close(ctx) // releases everything if we return early
+//		db, err := openDB(ctx)
+//		if err != nil {
+//			return nil, err
+//		}
+//		s.DeferErr(db.Close)
+//		cache, err := dialCache(ctx)
+//		if err != nil {
+//			return nil, err
+//		}
+//		s.DeferErr(cache.Close)
+//		return &Server{db: db, cache: cache, cleanup: s.Transfer()}, nil
+//	}
+type Stack struct {
+	mu  sync.Mutex
+	fns []func(ctx context.Context) error
+}
+
+// Defer adds fn to the stack.
+func (s *Stack) Defer(fn func()) {
+	s.DeferCtx(func(context.Context) error {
+		fn()
+		return nil
+	})
+}
+
+// DeferErr adds fn to the stack; its error is reported by Close.
+func (s *Stack) DeferErr(fn func() error) {
+	s.DeferCtx(func(context.Context) error { return fn() })
+}
+
+// DeferCtx adds fn to the stack; it receives the context passed to Close
+// and its error is reported by Close.
+func (s *Stack) DeferCtx(fn func(ctx context.Context) error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.fns = append(s.fns, fn)
+}
+
+// Len returns the number of functions on the stack.
+func (s *Stack) Len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.fns)
+}
+
+// Transfer moves the functions on s to a new Stack and empties s, handing
+// responsibility for the teardown to the new owner. A deferred Close of s
+// then does nothing.
+func (s *Stack) Transfer() *Stack {
+	return &Stack{fns: s.take()}
+}
+
+// Close runs every function on the stack, most recently added first, and
+// empties it. Every function runs even if earlier ones fail or panic.
+//
+// Parameters:
+//   - ctx: Passed to functions added with DeferCtx. It does not stop the
+//     teardown; functions should respect it themselves.
+//
+// Returns:
+//   - error: nil if every function succeeded; otherwise their errors,
+//     including panics, combined by multierr in the order they ran.
+func (s *Stack) Close(ctx context.Context) error {
+	fns := s.take()
+	var errs multierr.Error
+	for i := len(fns) - 1; i >= 0; i-- {
+		errs.Append(run(ctx, fns[i]))
+	}
+	return errs.ErrorOrNil()
+}
+
+// take empties the stack and returns its functions.
+func (s *Stack) take() []func(ctx context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	fns := s.fns
+	s.fns = nil
+	return fns
+}
+
+// run calls fn, converting a panic into an error.
+func run(ctx context.Context, fn func(ctx context.Context) error) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("cleanup: function panicked: %v", r)
+		}
+	}()
+	return fn(ctx)
+}
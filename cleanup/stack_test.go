@@ -0,0 +1,111 @@
+package cleanup
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/zodimo/go-zbase-std/multierr"
+)
+
+func TestStack_CloseRunsLIFO(t *testing.T) {
+	// Arrange
+	var s Stack
+	var order []string
+	s.Defer(func() { order = append(order, "first") })
+	s.DeferErr(func() error { order = append(order, "second"); return nil })
+	s.DeferCtx(func(context.Context) error { order = append(order, "third"); return nil })
+
+	// Act
+	err := s.Close(context.Background())
+
+	// Assert
+	if err != nil {
+		t.Errorf("expected no error, got %v", err)
+	}
+	if want := []string{"third", "second", "first"}; !slices.Equal(order, want) {
+		t.Errorf("expected %v, got %v", want, order)
+	}
+	if s.Len() != 0 {
+		t.Errorf("expected the stack to be emptied, got %d", s.Len())
+	}
+}
+
+func TestStack_CloseCollectsErrors(t *testing.T) {
+	// Arrange
+	var s Stack
+	first, second := errors.New("first"), errors.New("second")
+	ran := 0
+	s.DeferErr(func() error { ran++; return first })
+	s.Defer(func() { ran++; panic("boom") })
+	s.DeferErr(func() error { ran++; return second })
+
+	// Act
+	err := s.Close(context.Background())
+
+	// Assert
+	var merr *multierr.Error
+	if !errors.As(err, &merr) || merr.Len() != 3 || !errors.Is(merr.Errors()[0], second) {
+		t.Fatalf("expected 3 errors starting with the last added, got %v", err)
+	}
+	if !errors.Is(err, first) || !strings.Contains(err.Error(), "panicked: boom") {
+		t.Errorf("expected every error, got %v", err)
+	}
+	if ran != 3 {
+		t.Errorf("expected every function to run, got %d", ran)
+	}
+}
+
+func TestStack_CloseIsIdempotent(t *testing.T) {
+	// Arrange
+	var s Stack
+	ran := 0
+	s.Defer(func() { ran++ })
+
+	// Act
+	_ = s.Close(context.Background())
+	_ = s.Close(context.Background())
+
+	// Assert
+	if ran != 1 {
+		t.Errorf("expected the function to run once, got %d", ran)
+	}
+}
+
+func TestStack_Transfer(t *testing.T) {
+	// Arrange
+	var s Stack
+	closed := false
+	s.Defer(func() { closed = true })
+
+	// Act
+	owned := s.Transfer()
+	_ = s.Close(context.Background())
+
+	// Assert
+	if closed {
+		t.Fatal("expected the original stack to do nothing after a transfer")
+	}
+	_ = owned.Close(context.Background())
+	if !closed {
+		t.Error("expected the new owner to run the function")
+	}
+}
+
+func TestStack_DeferCtxReceivesContext(t *testing.T) {
+	// Arrange
+	type key struct{}
+	var s Stack
+	var got any
+	s.DeferCtx(func(ctx context.Context) error { got = ctx.Value(key{}); return nil })
+
+	// Act
+	_ = s.Close(context.WithValue(context.Background(), key{}, "v"))
+
+	// Assert
+	if got != "v" {
+		t.Errorf("expected the Close context, got %v", got)
+	}
+}
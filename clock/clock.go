@@ -0,0 +1,148 @@
+// Package clock abstracts the passage of time so that time-dependent code,
+// such as timeouts, TTLs and leases, can be tested deterministically with a
+// FakeClock.
+package clock
+
+import (
+	"context"
+	"time"
+)
+
+// Clock is a source of time and timers.
+type Clock interface {
+	// Now returns the current time.
+	Now() time.Time
+
+	// After waits for the duration to elapse and then sends the current
+	// time on the returned channel.
+	After(d time.Duration) <-chan time.Time
+
+	// AfterFunc waits for the duration to elapse and then calls f in its
+	// own goroutine. The returned Timer can be used to cancel the call; its
+	// C method returns nil.
+	AfterFunc(d time.Duration, f func()) Timer
+
+	// NewTimer creates a Timer that sends the current time on its channel
+	// after at least duration d.
+	NewTimer(d time.Duration) Timer
+
+	// NewTicker creates a Ticker that sends the current time on its
+	// channel every d. It panics if d is not positive.
+	NewTicker(d time.Duration) Ticker
+}
+
+// Timer is a single pending event, created by NewTimer or AfterFunc.
+type Timer interface {
+	// C returns the channel on which the time is delivered, or nil for a
+	// timer created by AfterFunc.
+	C() <-chan time.Time
+
+	// Stop prevents the timer from firing. It returns false if the timer
+	// has already fired or been stopped.
+	Stop() bool
+
+	// Reset changes the timer to fire after duration d. It returns true if
+	// the timer had been active.
+	Reset(d time.Duration) bool
+}
+
+// Ticker delivers the time at intervals.
+type Ticker interface {
+	// C returns the channel on which the ticks are delivered.
+	C() <-chan time.Time
+
+	// Stop turns off the ticker. No more ticks are sent after Stop returns.
+	Stop()
+
+	// Reset stops the ticker and resets its period to d. The next tick
+	// arrives after d elapses. It panics if d is not positive.
+	Reset(d time.Duration)
+}
+
+// System returns the Clock backed by the time package.
+func System() Clock {
+	return systemClock{}
+}
+
+// Sleep pauses until d has elapsed on c or ctx is done, whichever comes
+// first. It is a function rather than a Clock method so that it works with
+// any Clock built from NewTimer, and adding it to the interface would break
+// existing implementations.
+//
+// Parameters:
+//   - ctx: Ends the sleep early when done.
+//   - c: The clock measuring d.
+//   - d: How long to sleep.
+//
+// Returns:
+//   - error: ctx.Err() if ctx is done first; nil otherwise.
+//
+// Example:
+//
+//	if err := clock.Sleep(ctx, c, backoff); err != nil {
+//		return err
+//	}
+func Sleep(ctx context.Context, c Clock, d time.Duration) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if d <= 0 {
+		return nil
+	}
+	t := c.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-t.C():
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// systemClock implements Clock using the time package.
+type systemClock struct{}
+
+// Now returns time.Now().
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
+
+// After returns time.After(d).
+func (systemClock) After(d time.Duration) <-chan time.Time {
+	return time.After(d)
+}
+
+// AfterFunc returns time.AfterFunc(d, f).
+func (systemClock) AfterFunc(d time.Duration, f func()) Timer {
+	return systemTimer{time.AfterFunc(d, f)}
+}
+
+// NewTimer returns time.NewTimer(d).
+func (systemClock) NewTimer(d time.Duration) Timer {
+	return systemTimer{time.NewTimer(d)}
+}
+
+// NewTicker returns time.NewTicker(d).
+func (systemClock) NewTicker(d time.Duration) Ticker {
+	return systemTicker{time.NewTicker(d)}
+}
+
+// systemTimer adapts *time.Timer to Timer.
+type systemTimer struct {
+	*time.Timer
+}
+
+// C returns the channel of the timer.
+func (t systemTimer) C() <-chan time.Time {
+	return t.Timer.C
+}
+
+// systemTicker adapts *time.Ticker to Ticker.
+type systemTicker struct {
+	*time.Ticker
+}
+
+// C returns the channel of the ticker.
+func (t systemTicker) C() <-chan time.Time {
+	return t.Ticker.C
+}
@@ -0,0 +1,80 @@
+package clock
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestSystem_Now(t *testing.T) {
+	// Arrange
+	before := time.Now()
+
+	// Act
+	now := System().Now()
+
+	// Assert
+	if now.Before(before) {
+		t.Errorf("expected the system clock to return the current time, got %v", now)
+	}
+}
+
+func TestSystem_Timer(t *testing.T) {
+	// Arrange
+	timer := System().NewTimer(time.Millisecond)
+
+	// Act
+	select {
+	case <-timer.C():
+	case <-time.After(time.Second):
+		t.Fatal("expected the system timer to fire")
+	}
+	stopped := timer.Stop()
+
+	// Assert
+	if stopped {
+		t.Error("expected Stop to report a timer that has already fired")
+	}
+}
+
+func TestSleep(t *testing.T) {
+	// Arrange
+	c := NewFakeClock(time.Unix(0, 0))
+	done := make(chan error)
+
+	// Act
+	go func() { done <- Sleep(context.Background(), c, time.Minute) }()
+	for c.Pending() == 0 {
+		time.Sleep(time.Millisecond)
+	}
+	c.Advance(time.Minute)
+
+	// Assert
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Errorf("expected no error, got %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("expected Sleep to return once the fake time advanced")
+	}
+}
+
+func TestSleep_ContextDone(t *testing.T) {
+	// Arrange
+	c := NewFakeClock(time.Unix(0, 0))
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	// Act
+	err := Sleep(ctx, c, time.Hour)
+
+	// Assert
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context.Canceled, got %v", err)
+	}
+	if c.Pending() != 0 {
+		t.Errorf("expected no pending timers, got %d", c.Pending())
+	}
+}
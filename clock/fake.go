@@ -0,0 +1,207 @@
+package clock
+
+import (
+	"slices"
+	"sync"
+	"time"
+)
+
+// FakeClock is a Clock whose time only moves when Advance or Set is called.
+// Timers, tickers, After channels and AfterFunc calls fire once the fake
+// time reaches their deadline. Channels are buffered like those of the time
+// package: a tick that finds the channel full is dropped. It is safe for
+// concurrent use.
+type FakeClock struct {
+	mu      sync.Mutex
+	now     time.Time
+	waiters []*fakeWaiter
+}
+
+// fakeWaiter is a pending timer, ticker, After or AfterFunc call.
+type fakeWaiter struct {
+	clock    *FakeClock
+	deadline time.Time
+	period   time.Duration  // Set for tickers.
+	ch       chan time.Time // Set unless fn is.
+	fn       func()         // Set for AfterFunc.
+}
+
+// NewFakeClock creates a FakeClock set to the given time.
+//
+// Example:
+//
+//	c := clock.NewFakeClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+//	timer := c.NewTimer(time.Minute)
+//	c.Advance(time.Minute)
+//	<-timer.C() // Fires without waiting
+func NewFakeClock(now time.Time) *FakeClock {
+	return &FakeClock{now: now}
+}
+
+// Now returns the fake current time.
+func (c *FakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+// After returns a channel that receives the fake time once it has advanced
+// by at least d.
+func (c *FakeClock) After(d time.Duration) <-chan time.Time {
+	return c.NewTimer(d).C()
+}
+
+// AfterFunc schedules f to run in its own goroutine once the fake time has
+// advanced by at least d.
+func (c *FakeClock) AfterFunc(d time.Duration, f func()) Timer {
+	return c.schedule(&fakeWaiter{clock: c, fn: f}, d)
+}
+
+// NewTimer creates a Timer that fires once the fake time has advanced by
+// at least d.
+func (c *FakeClock) NewTimer(d time.Duration) Timer {
+	return c.schedule(&fakeWaiter{clock: c, ch: make(chan time.Time, 1)}, d)
+}
+
+// NewTicker creates a Ticker that fires every time the fake time advances
+// past a multiple of d.
+func (c *FakeClock) NewTicker(d time.Duration) Ticker {
+	if d <= 0 {
+		panic("clock: non-positive interval for NewTicker")
+	}
+	return fakeTicker{c.schedule(&fakeWaiter{clock: c, period: d, ch: make(chan time.Time, 1)}, d)}
+}
+
+// schedule adds w with a deadline d from now, firing it at once if d is not
+// positive.
+func (c *FakeClock) schedule(w *fakeWaiter, d time.Duration) *fakeWaiter {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	w.deadline = c.now.Add(d)
+	c.waiters = append(c.waiters, w)
+	c.fireLocked()
+	return w
+}
+
+// Advance moves the fake time forward by d, firing everything whose
+// deadline has been reached.
+func (c *FakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+	c.fireLocked()
+}
+
+// Set moves the fake time to t, firing everything whose deadline has been
+// reached.
+func (c *FakeClock) Set(t time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = t
+	c.fireLocked()
+}
+
+// Pending returns the number of timers, tickers, After and AfterFunc calls
+// that are still waiting to fire.
+func (c *FakeClock) Pending() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.waiters)
+}
+
+// fireLocked fires due waiters in deadline order, rescheduling tickers.
+// A ticker fires at most once per call however many periods have elapsed.
+// c.mu must be held.
+func (c *FakeClock) fireLocked() {
+	slices.SortStableFunc(c.waiters, func(a, b *fakeWaiter) int {
+		return a.deadline.Compare(b.deadline)
+	})
+	for len(c.waiters) > 0 && !c.waiters[0].deadline.After(c.now) {
+		w := c.waiters[0]
+		c.waiters = c.waiters[1:]
+		switch {
+		case w.fn != nil:
+			go w.fn()
+		default:
+			select {
+			case w.ch <- c.now:
+			default: // Drop the tick, as time.Ticker does.
+			}
+		}
+		if w.period > 0 {
+			// Coalesce missed ticks like time.Ticker: one send, then the
+			// first period boundary after now.
+			missed := c.now.Sub(w.deadline) / w.period
+			w.deadline = w.deadline.Add((missed + 1) * w.period)
+			i, _ := slices.BinarySearchFunc(c.waiters, w.deadline, func(p *fakeWaiter, t time.Time) int {
+				return p.deadline.Compare(t)
+			})
+			c.waiters = slices.Insert(c.waiters, i, w)
+		}
+	}
+}
+
+// removeLocked removes w from the pending waiters and reports whether it
+// was pending. c.mu must be held.
+func (c *FakeClock) removeLocked(w *fakeWaiter) bool {
+	i := slices.Index(c.waiters, w)
+	if i < 0 {
+		return false
+	}
+	c.waiters = slices.Delete(c.waiters, i, i+1)
+	return true
+}
+
+// C returns the channel of a timer or ticker, or nil for AfterFunc.
+func (w *fakeWaiter) C() <-chan time.Time {
+	return w.ch
+}
+
+// Stop removes the pending timer or ticker. It returns false if it has
+// already fired or been stopped.
+func (w *fakeWaiter) Stop() bool {
+	w.clock.mu.Lock()
+	defer w.clock.mu.Unlock()
+	return w.clock.removeLocked(w)
+}
+
+// Reset reschedules the timer or ticker to fire d after the current fake
+// time, reporting whether it was pending. For a ticker, d also becomes the
+// new period and must be positive.
+func (w *fakeWaiter) Reset(d time.Duration) bool {
+	c := w.clock
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	pending := c.removeLocked(w)
+	if w.period > 0 {
+		if d <= 0 {
+			panic("clock: non-positive interval for Ticker.Reset")
+		}
+		w.period = d
+	}
+	w.deadline = c.now.Add(d)
+	c.waiters = append(c.waiters, w)
+	c.fireLocked()
+	return pending
+}
+
+// fakeTicker adapts a fakeWaiter to Ticker, whose Stop and Reset return no
+// result.
+type fakeTicker struct {
+	w *fakeWaiter
+}
+
+// C returns the channel of the ticker.
+func (t fakeTicker) C() <-chan time.Time {
+	return t.w.C()
+}
+
+// Stop turns off the ticker.
+func (t fakeTicker) Stop() {
+	t.w.Stop()
+}
+
+// Reset stops the ticker and resets its period to d.
+func (t fakeTicker) Reset(d time.Duration) {
+	t.w.Reset(d)
+}
@@ -0,0 +1,189 @@
+package clock
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// Compile-time check that the fake satisfies the interface.
+var _ Clock = (*FakeClock)(nil)
+
+func TestFakeClock_After(t *testing.T) {
+	// Arrange
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	c := NewFakeClock(start)
+	ch := c.After(time.Minute)
+
+	// Act
+	c.Advance(30 * time.Second)
+
+	// Assert
+	select {
+	case <-ch:
+		t.Fatal("expected After not to fire before its deadline")
+	default:
+	}
+	c.Advance(30 * time.Second)
+	select {
+	case got := <-ch:
+		if !got.Equal(start.Add(time.Minute)) {
+			t.Errorf("expected fired time %v, got %v", start.Add(time.Minute), got)
+		}
+	default:
+		t.Fatal("expected After to fire at its deadline")
+	}
+}
+
+func TestFakeClock_TimerStopAndReset(t *testing.T) {
+	// Arrange
+	c := NewFakeClock(time.Unix(0, 0))
+	timer := c.NewTimer(time.Minute)
+
+	// Act
+	stopped := timer.Stop()
+	stoppedAgain := timer.Stop()
+	wasPending := timer.Reset(time.Second)
+	c.Advance(time.Second)
+
+	// Assert
+	if !stopped || stoppedAgain || wasPending {
+		t.Errorf("expected Stop true, false and Reset false, got %v, %v, %v", stopped, stoppedAgain, wasPending)
+	}
+	select {
+	case <-timer.C():
+	default:
+		t.Fatal("expected the reset timer to fire")
+	}
+}
+
+func TestFakeClock_Ticker(t *testing.T) {
+	// Arrange
+	c := NewFakeClock(time.Unix(0, 0))
+	ticker := c.NewTicker(time.Second)
+	ticks := 0
+
+	// Act
+	for range 3 {
+		c.Advance(time.Second)
+		select {
+		case <-ticker.C():
+			ticks++
+		default:
+		}
+	}
+	ticker.Stop()
+	c.Advance(time.Second)
+
+	// Assert
+	if ticks != 3 {
+		t.Errorf("expected 3 ticks, got %d", ticks)
+	}
+	select {
+	case <-ticker.C():
+		t.Error("expected no tick after Stop")
+	default:
+	}
+}
+
+func TestFakeClock_TickerDropsMissedTicks(t *testing.T) {
+	// Arrange
+	c := NewFakeClock(time.Unix(0, 0))
+	ticker := c.NewTicker(time.Second)
+
+	// Act
+	c.Advance(5 * time.Second)
+
+	// Assert
+	<-ticker.C()
+	select {
+	case <-ticker.C():
+		t.Error("expected ticks beyond the buffered one to be dropped")
+	default:
+	}
+	if c.Pending() != 1 {
+		t.Errorf("expected the ticker to stay scheduled, got %d pending", c.Pending())
+	}
+}
+
+func TestFakeClock_TickerCoalescesLargeAdvance(t *testing.T) {
+	// Arrange
+	c := NewFakeClock(time.Unix(0, 0))
+	ticker := c.NewTicker(time.Nanosecond)
+	done := make(chan struct{})
+
+	// Act
+	go func() {
+		c.Advance(time.Hour)
+		close(done)
+	}()
+
+	// Assert
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("expected Advance to coalesce missed ticks instead of firing each one")
+	}
+	<-ticker.C()
+	if len(ticker.C()) != 0 {
+		t.Error("expected a single coalesced tick")
+	}
+	c.Advance(time.Nanosecond)
+	select {
+	case <-ticker.C():
+	default:
+		t.Error("expected a tick at the first period after the advance")
+	}
+}
+
+func TestFakeClock_TickerRealignsAfterMissedTicks(t *testing.T) {
+	// Arrange
+	c := NewFakeClock(time.Unix(0, 0))
+	ticker := c.NewTicker(time.Second)
+	c.Advance(2500 * time.Millisecond)
+	<-ticker.C()
+
+	// Act
+	c.Advance(499 * time.Millisecond)
+	early := len(ticker.C())
+	c.Advance(time.Millisecond)
+
+	// Assert
+	if early != 0 {
+		t.Error("expected no tick before 3s")
+	}
+	select {
+	case got := <-ticker.C():
+		if !got.Equal(time.Unix(3, 0)) {
+			t.Errorf("expected a tick at 3s, got %v", got)
+		}
+	default:
+		t.Error("expected a tick at 3s")
+	}
+}
+
+func TestFakeClock_AfterFunc(t *testing.T) {
+	// Arrange
+	c := NewFakeClock(time.Unix(0, 0))
+	var fired atomic.Bool
+	done := make(chan struct{})
+	c.AfterFunc(time.Minute, func() {
+		fired.Store(true)
+		close(done)
+	})
+	cancelled := c.AfterFunc(time.Minute, func() { t.Error("expected a stopped call not to run") })
+
+	// Act
+	cancelled.Stop()
+	c.Set(time.Unix(60, 0))
+
+	// Assert
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("expected AfterFunc to run once the deadline was reached")
+	}
+	if !fired.Load() || c.Pending() != 0 {
+		t.Errorf("expected the call to have run with nothing pending, got %v and %d", fired.Load(), c.Pending())
+	}
+}
@@ -0,0 +1,147 @@
+// Package cmpx builds comparators by combining simpler ones, so orderings
+// such as "by priority, then newest first" can be written declaratively and
+// shared between sorting, the priority queue and Option ordering.
+package cmpx
+
+import (
+	"cmp"
+
+	"github.com/zodimo/go-zbase-std/optional"
+)
+
+// Ordering is the normalized result of a comparison.
+type Ordering int
+
+const (
+	// Less means the first value sorts before the second.
+	Less Ordering = -1
+
+	// Equal means the values sort together.
+	Equal Ordering = 0
+
+	// Greater means the first value sorts after the second.
+	Greater Ordering = 1
+)
+
+// String returns the name of the ordering.
+func (o Ordering) String() string {
+	switch o {
+	case Less:
+		return "less"
+	case Equal:
+		return "equal"
+	case Greater:
+		return "greater"
+	default:
+		return "unknown"
+	}
+}
+
+// Comparator compares two values, returning a negative number when a
+// sorts before b, zero when they sort together and a positive number when
+// a sorts after b. It has the shape expected by slices.SortFunc.
+type Comparator[T any] func(a, b T) int
+
+// Natural returns the comparator of the natural order of T, cmp.Compare.
+func Natural[T cmp.Ordered]() Comparator[T] {
+	return cmp.Compare[T]
+}
+
+// Comparing returns a comparator ordering values by the key extract
+// returns for them.
+//
+// Example:
+//
+//	byPriority := cmpx.Comparing(func(j Job) int { return j.Priority })
+//	newestFirst := cmpx.Comparing(func(j Job) int64 { return j.Created.UnixNano() }).Reversed()
+//	slices.SortFunc(jobs, byPriority.ThenComparing(newestFirst))
+func Comparing[T any, K cmp.Ordered](extract func(T) K) Comparator[T] {
+	return ComparingWith(extract, Natural[K]())
+}
+
+// ComparingWith returns a comparator ordering values by the key extract
+// returns for them, compared with c.
+func ComparingWith[T, K any](extract func(T) K, c Comparator[K]) Comparator[T] {
+	return func(a, b T) int {
+		return c(extract(a), extract(b))
+	}
+}
+
+// NoneFirst returns a comparator of Options that sorts None before any
+// Some and compares Somes with c. With Natural it agrees with
+// optional.Compare.
+func NoneFirst[T any](c Comparator[T]) Comparator[optional.Option[T]] {
+	return nullable(c, Less)
+}
+
+// NoneLast returns a comparator of Options that sorts None after any Some
+// and compares Somes with c.
+func NoneLast[T any](c Comparator[T]) Comparator[optional.Option[T]] {
+	return nullable(c, Greater)
+}
+
+// nullable compares Options, placing None where noneOrder says relative to
+// Some.
+func nullable[T any](c Comparator[T], noneOrder Ordering) Comparator[optional.Option[T]] {
+	return func(a, b optional.Option[T]) int {
+		av, aok := a.Value()
+		bv, bok := b.Value()
+		switch {
+		case !aok && !bok:
+			return 0
+		case !aok:
+			return int(noneOrder)
+		case !bok:
+			return -int(noneOrder)
+		default:
+			return c(av, bv)
+		}
+	}
+}
+
+// Reversed returns the comparator of the reverse order.
+func (c Comparator[T]) Reversed() Comparator[T] {
+	return func(a, b T) int {
+		return c(b, a)
+	}
+}
+
+// ThenComparing returns a comparator that orders by c, breaking ties with
+// next.
+func (c Comparator[T]) ThenComparing(next Comparator[T]) Comparator[T] {
+	return func(a, b T) int {
+		if r := c(a, b); r != 0 {
+			return r
+		}
+		return next(a, b)
+	}
+}
+
+// ThenComparingBy returns a comparator that orders by c, breaking ties by
+// the key extract returns. It is the key-extractor form of ThenComparing,
+// which cannot be a method because methods take no type parameters.
+//
+// Example:
+//
+//	byPriorityThenName := cmpx.ThenComparingBy(byPriority, func(j Job) string { return j.Name })
+func ThenComparingBy[T any, K cmp.Ordered](c Comparator[T], extract func(T) K) Comparator[T] {
+	return c.ThenComparing(Comparing(extract))
+}
+
+// Compare compares a and b, normalizing the result to an Ordering.
+func (c Comparator[T]) Compare(a, b T) Ordering {
+	switch r := c(a, b); {
+	case r < 0:
+		return Less
+	case r > 0:
+		return Greater
+	default:
+		return Equal
+	}
+}
+
+// Less reports whether a sorts before b. Its method value suits APIs
+// taking a less function, such as collections.NewPriorityQueue.
+func (c Comparator[T]) Less(a, b T) bool {
+	return c(a, b) < 0
+}
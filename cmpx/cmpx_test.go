@@ -0,0 +1,111 @@
+package cmpx
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/zodimo/go-zbase-std/collections"
+	"github.com/zodimo/go-zbase-std/optional"
+)
+
+type job struct {
+	name     string
+	priority int
+	created  int
+}
+
+var (
+	byPriority = Comparing(func(j job) int { return j.priority })
+	newest     = Comparing(func(j job) int { return j.created }).Reversed()
+)
+
+func names(jobs []job) []string {
+	out := make([]string, len(jobs))
+	for i, j := range jobs {
+		out[i] = j.name
+	}
+	return out
+}
+
+func TestComparator_ThenComparing(t *testing.T) {
+	// Arrange
+	jobs := []job{{"a", 2, 1}, {"b", 1, 1}, {"c", 2, 3}, {"d", 1, 2}}
+
+	// Act
+	slices.SortFunc(jobs, byPriority.ThenComparing(newest))
+
+	// Assert
+	if got := names(jobs); !slices.Equal(got, []string{"d", "b", "c", "a"}) {
+		t.Errorf("expected [d b c a], got %v", got)
+	}
+}
+
+func TestThenComparingBy(t *testing.T) {
+	// Arrange
+	jobs := []job{{"c", 2, 1}, {"b", 1, 1}, {"a", 2, 3}, {"d", 1, 2}}
+
+	// Act
+	slices.SortFunc(jobs, ThenComparingBy(byPriority, func(j job) string { return j.name }))
+
+	// Assert
+	if got := names(jobs); !slices.Equal(got, []string{"b", "d", "a", "c"}) {
+		t.Errorf("expected [b d a c], got %v", got)
+	}
+}
+
+func TestComparator_Compare(t *testing.T) {
+	// Arrange
+	c := Natural[int]()
+
+	// Act
+	less, equal, greater := c.Compare(1, 2), c.Compare(2, 2), c.Compare(3, 2)
+
+	// Assert
+	if less != Less || equal != Equal || greater != Greater {
+		t.Errorf("expected less, equal and greater, got %v, %v and %v", less, equal, greater)
+	}
+	if c.Reversed().Compare(1, 2) != Greater {
+		t.Error("expected the reversed comparator to invert the order")
+	}
+}
+
+func TestNoneFirstAndLast(t *testing.T) {
+	// Arrange
+	values := []optional.Option[int]{optional.Some(2), optional.None[int](), optional.Some(1)}
+	first, last := slices.Clone(values), slices.Clone(values)
+
+	// Act
+	slices.SortFunc(first, NoneFirst(Natural[int]()))
+	slices.SortFunc(last, NoneLast(Natural[int]()))
+
+	// Assert
+	if want := []optional.Option[int]{optional.None[int](), optional.Some(1), optional.Some(2)}; !slices.Equal(first, want) {
+		t.Errorf("expected %v, got %v", want, first)
+	}
+	if want := []optional.Option[int]{optional.Some(1), optional.Some(2), optional.None[int]()}; !slices.Equal(last, want) {
+		t.Errorf("expected %v, got %v", want, last)
+	}
+	for _, a := range values {
+		for _, b := range values {
+			if NoneFirst(Natural[int]())(a, b) != optional.Compare(a, b) {
+				t.Errorf("expected NoneFirst to agree with optional.Compare for %v and %v", a, b)
+			}
+		}
+	}
+}
+
+func TestComparator_LessDrivesPriorityQueue(t *testing.T) {
+	// Arrange
+	q := collections.NewPriorityQueue(byPriority.Reversed().Less)
+	q.Push(job{"low", 1, 0})
+	q.Push(job{"high", 9, 0})
+
+	// Act
+	top, err := q.Pop(context.Background())
+
+	// Assert
+	if err != nil || top.name != "high" {
+		t.Errorf("expected the highest priority job, got %v and %v", top, err)
+	}
+}
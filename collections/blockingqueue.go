@@ -0,0 +1,172 @@
+package collections
+
+import (
+	"context"
+	"sync"
+
+	"github.com/zodimo/go-zbase-std/optional"
+)
+
+// BlockingQueue is a bounded, concurrency-safe FIFO queue for handing values
+// from producers to consumers. Put blocks while the queue is full and Take
+// blocks while it is empty; Offer, Poll and Peek never block, which gives
+// callers the length-aware rejection and inspection a channel cannot.
+type BlockingQueue[T any] struct {
+	mu       sync.Mutex
+	items    Queue[T]
+	capacity int
+	notEmpty chan struct{} // Closed and replaced whenever a value is added.
+	notFull  chan struct{} // Closed and replaced whenever a value is removed.
+	takers   int           // Number of goroutines blocked in Take.
+	putters  int           // Number of goroutines blocked in Put.
+}
+
+// NewBlockingQueue creates an empty queue that holds at most capacity values.
+// It panics if capacity is not positive.
+//
+// Parameters:
+//   - capacity: The maximum number of values the queue holds.
+//
+// Returns:
+//   - *BlockingQueue[T]: The new queue.
+//
+// Example:
+//
+//	jobs := collections.NewBlockingQueue[Job](64)
+//	go func() { _ = jobs.Put(ctx, Job{ID: 1}) }()
+//	next, err := jobs.Take(ctx)
+func NewBlockingQueue[T any](capacity int) *BlockingQueue[T] {
+	if capacity <= 0 {
+		panic("collections: BlockingQueue capacity must be positive")
+	}
+	return &BlockingQueue[T]{
+		capacity: capacity,
+		notEmpty: make(chan struct{}),
+		notFull:  make(chan struct{}),
+	}
+}
+
+// Put adds value to the back of the queue, waiting for room if it is full.
+//
+// Parameters:
+//   - ctx: Bounds the wait.
+//   - value: The value to add.
+//
+// Returns:
+//   - error: ctx.Err() if ctx is done before room is available.
+func (q *BlockingQueue[T]) Put(ctx context.Context, value T) error {
+	q.mu.Lock()
+	for q.items.Len() >= q.capacity {
+		if err := ctx.Err(); err != nil {
+			q.mu.Unlock()
+			return err
+		}
+		notFull := q.notFull
+		q.putters++
+		q.mu.Unlock()
+		select {
+		case <-notFull:
+		case <-ctx.Done():
+		}
+		q.mu.Lock()
+		q.putters--
+	}
+	defer q.mu.Unlock()
+	q.push(value)
+	return nil
+}
+
+// Offer adds value to the back of the queue without waiting.
+//
+// Returns:
+//   - bool: false, leaving the queue unchanged, if it is full.
+func (q *BlockingQueue[T]) Offer(value T) bool {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.items.Len() >= q.capacity {
+		return false
+	}
+	q.push(value)
+	return true
+}
+
+// Take removes and returns the front value, waiting for one to be added if
+// the queue is empty.
+//
+// Parameters:
+//   - ctx: Bounds the wait.
+//
+// Returns:
+//   - T: The front value.
+//   - error: ctx.Err() if ctx is done before a value is available.
+func (q *BlockingQueue[T]) Take(ctx context.Context) (T, error) {
+	q.mu.Lock()
+	for q.items.Len() == 0 {
+		if err := ctx.Err(); err != nil {
+			q.mu.Unlock()
+			var zero T
+			return zero, err
+		}
+		notEmpty := q.notEmpty
+		q.takers++
+		q.mu.Unlock()
+		select {
+		case <-notEmpty:
+		case <-ctx.Done():
+		}
+		q.mu.Lock()
+		q.takers--
+	}
+	defer q.mu.Unlock()
+	value := q.pop()
+	v, _ := value.Value()
+	return v, nil
+}
+
+// Poll removes and returns the front value without waiting, or None if the
+// queue is empty.
+func (q *BlockingQueue[T]) Poll() optional.Option[T] {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.pop()
+}
+
+// Peek returns the front value without removing it, or None if the queue is
+// empty.
+func (q *BlockingQueue[T]) Peek() optional.Option[T] {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.items.Peek()
+}
+
+// Len returns the number of values in the queue.
+func (q *BlockingQueue[T]) Len() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.items.Len()
+}
+
+// Cap returns the maximum number of values the queue holds.
+func (q *BlockingQueue[T]) Cap() int {
+	return q.capacity
+}
+
+// push adds value and wakes goroutines blocked in Take. q.mu must be held.
+func (q *BlockingQueue[T]) push(value T) {
+	q.items.Push(value)
+	if q.takers > 0 {
+		close(q.notEmpty)
+		q.notEmpty = make(chan struct{})
+	}
+}
+
+// pop removes the front value, if any, and wakes goroutines blocked in Put.
+// q.mu must be held.
+func (q *BlockingQueue[T]) pop() optional.Option[T] {
+	value := q.items.Pop()
+	if _, ok := value.Value(); ok && q.putters > 0 {
+		close(q.notFull)
+		q.notFull = make(chan struct{})
+	}
+	return value
+}
@@ -0,0 +1,179 @@
+package collections
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestBlockingQueue_FIFO(t *testing.T) {
+	// Arrange
+	q := NewBlockingQueue[int](3)
+	for _, v := range []int{1, 2, 3} {
+		if err := q.Put(context.Background(), v); err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+	}
+
+	// Act
+	peeked := q.Peek()
+	var taken []int
+	for q.Len() > 0 {
+		v, _ := q.Take(context.Background())
+		taken = append(taken, v)
+	}
+
+	// Assert
+	if v, ok := peeked.Value(); !ok || v != 1 {
+		t.Errorf("expected Peek Some(1), got %v, %v", v, ok)
+	}
+	expected := []int{1, 2, 3}
+	for i := range expected {
+		if taken[i] != expected[i] {
+			t.Fatalf("expected %v, got %v", expected, taken)
+		}
+	}
+}
+
+func TestBlockingQueue_OfferAndPoll(t *testing.T) {
+	// Arrange
+	q := NewBlockingQueue[string](1)
+
+	// Act
+	first := q.Offer("a")
+	second := q.Offer("b")
+	polled := q.Poll()
+	empty := q.Poll()
+
+	// Assert
+	if !first || second {
+		t.Errorf("expected Offer true then false, got %v then %v", first, second)
+	}
+	if v, ok := polled.Value(); !ok || v != "a" {
+		t.Errorf("expected Poll Some(a), got %v, %v", v, ok)
+	}
+	if _, ok := empty.Value(); ok {
+		t.Error("expected None from Poll on an empty queue")
+	}
+	if q.Cap() != 1 {
+		t.Errorf("expected Cap 1, got %d", q.Cap())
+	}
+}
+
+func TestBlockingQueue_TakeWaitsForPut(t *testing.T) {
+	// Arrange
+	q := NewBlockingQueue[int](1)
+	result := make(chan int)
+	go func() {
+		v, _ := q.Take(context.Background())
+		result <- v
+	}()
+
+	// Act
+	time.Sleep(10 * time.Millisecond)
+	q.Offer(42)
+
+	// Assert
+	select {
+	case v := <-result:
+		if v != 42 {
+			t.Errorf("expected 42, got %d", v)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("expected Take to return after Offer")
+	}
+}
+
+func TestBlockingQueue_PutWaitsForRoom(t *testing.T) {
+	// Arrange
+	q := NewBlockingQueue[int](1)
+	q.Offer(1)
+	done := make(chan error)
+	go func() {
+		done <- q.Put(context.Background(), 2)
+	}()
+
+	// Act
+	time.Sleep(10 * time.Millisecond)
+	polled := q.Poll()
+
+	// Assert
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Errorf("expected no error, got %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("expected Put to return after Poll")
+	}
+	if v, ok := polled.Value(); !ok || v != 1 {
+		t.Errorf("expected Poll Some(1), got %v, %v", v, ok)
+	}
+	peeked := q.Peek()
+	if v, ok := peeked.Value(); !ok || v != 2 {
+		t.Errorf("expected Peek Some(2), got %v, %v", v, ok)
+	}
+}
+
+func TestBlockingQueue_Cancelled(t *testing.T) {
+	// Arrange
+	q := NewBlockingQueue[int](1)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	// Act
+	_, takeErr := q.Take(ctx)
+	q.Offer(1)
+	putErr := q.Put(ctx, 2)
+
+	// Assert
+	if !errors.Is(takeErr, context.DeadlineExceeded) {
+		t.Errorf("expected context.DeadlineExceeded from Take, got %v", takeErr)
+	}
+	if !errors.Is(putErr, context.DeadlineExceeded) {
+		t.Errorf("expected context.DeadlineExceeded from Put, got %v", putErr)
+	}
+	if q.Len() != 1 {
+		t.Errorf("expected Len 1, got %d", q.Len())
+	}
+}
+
+func TestBlockingQueue_ProducersConsumers(t *testing.T) {
+	// Arrange
+	q := NewBlockingQueue[int](4)
+	const producers, perProducer = 4, 100
+	var wg sync.WaitGroup
+	for p := 0; p < producers; p++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < perProducer; i++ {
+				_ = q.Put(context.Background(), 1)
+			}
+		}()
+	}
+
+	// Act
+	sum := 0
+	for i := 0; i < producers*perProducer; i++ {
+		v, _ := q.Take(context.Background())
+		sum += v
+	}
+	wg.Wait()
+
+	// Assert
+	if sum != producers*perProducer {
+		t.Errorf("expected %d, got %d", producers*perProducer, sum)
+	}
+}
+
+func TestNewBlockingQueue_PanicsOnZeroCapacity(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("expected a panic for a zero capacity")
+		}
+	}()
+	NewBlockingQueue[int](0)
+}
@@ -0,0 +1,201 @@
+package collections
+
+import (
+	"encoding/binary"
+	"fmt"
+	"hash/fnv"
+	"math"
+	"math/bits"
+	"sync/atomic"
+
+	"github.com/zodimo/go-zbase-std/errcode"
+)
+
+// IncompatibleBloomError is returned by Bloom.Merge when the two filters
+// were not created with the same size and hash count.
+var IncompatibleBloomError = errcode.New(errcode.Invalid, "collections: incompatible Bloom filters")
+
+// InvalidBloomEncodingError is returned by Bloom.UnmarshalBinary when the
+// data was not produced by Bloom.MarshalBinary.
+var InvalidBloomEncodingError = errcode.New(errcode.Invalid, "collections: invalid Bloom filter encoding")
+
+// bloomVersion prefixes the binary encoding so the format can evolve.
+const bloomVersion = 1
+
+// bloomHeaderSize is the length of the encoded version, hash count and bit
+// count that precede the bit words.
+const bloomHeaderSize = 1 + 4 + 8
+
+// Bloom is a probabilistic set of string keys. MaybeContains never reports
+// false for a key that was added, but may report true for a key that was
+// not, at roughly the false-positive rate the filter was sized for. Keys
+// cannot be removed.
+//
+// A Bloom is safe for concurrent use; Add and MaybeContains do not lock.
+// Create one with NewBloom or UnmarshalBinary; the zero value holds no bits
+// and must not be used until UnmarshalBinary succeeds.
+type Bloom struct {
+	words  []atomic.Uint64
+	bits   uint64 // Number of usable bits in words.
+	hashes uint32 // Number of bits set per key.
+}
+
+// NewBloom creates an empty filter sized to hold expected keys while keeping
+// the false-positive rate near fpRate. It panics if expected is not positive
+// or fpRate is not strictly between 0 and 1.
+//
+// Parameters:
+//   - expected: The number of keys the filter is sized for. Adding more
+//     raises the false-positive rate.
+//   - fpRate: The target probability that MaybeContains reports true for a
+//     key that was never added.
+//
+// Returns:
+//   - *Bloom: The new filter.
+//
+// Example:
+//
+//	seen := collections.NewBloom(100_000, 0.01)
+//	seen.Add("user:42")
+//	if !seen.MaybeContains(key) {
+//		return optional.None[User]() // Definitely never registered.
+//	}
+func NewBloom(expected int, fpRate float64) *Bloom {
+	if expected <= 0 {
+		panic("collections: Bloom expected count must be positive")
+	}
+	if !(fpRate > 0 && fpRate < 1) {
+		panic("collections: Bloom false-positive rate must be between 0 and 1")
+	}
+	// The standard optimal sizing: m = -n ln p / (ln 2)^2 and k = m/n ln 2.
+	n := float64(expected)
+	m := math.Ceil(-n * math.Log(fpRate) / (math.Ln2 * math.Ln2))
+	k := math.Max(1, math.Round(m/n*math.Ln2))
+	return newBloom(uint64(m), uint32(k))
+}
+
+// newBloom creates an empty filter of size bits that sets hashes bits per
+// key.
+func newBloom(size uint64, hashes uint32) *Bloom {
+	return &Bloom{
+		words:  make([]atomic.Uint64, (size+63)/64),
+		bits:   size,
+		hashes: hashes,
+	}
+}
+
+// Add records key in the filter.
+func (b *Bloom) Add(key string) {
+	h1, h2 := bloomHash(key)
+	for i := uint64(0); i < uint64(b.hashes); i++ {
+		bit := (h1 + i*h2) % b.bits
+		b.words[bit/64].Or(1 << (bit % 64))
+	}
+}
+
+// MaybeContains reports whether key may have been added. A false result is
+// certain; a true result is wrong with roughly the filter's false-positive
+// rate.
+func (b *Bloom) MaybeContains(key string) bool {
+	h1, h2 := bloomHash(key)
+	for i := uint64(0); i < uint64(b.hashes); i++ {
+		bit := (h1 + i*h2) % b.bits
+		if b.words[bit/64].Load()&(1<<(bit%64)) == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// Merge adds every key recorded in other to b, so that b then answers as if
+// both sets of keys had been added to it.
+//
+// Parameters:
+//   - other: A filter created with the same expected count and
+//     false-positive rate as b. It is not modified.
+//
+// Returns:
+//   - error: IncompatibleBloomError if the filters differ in size or hash
+//     count.
+func (b *Bloom) Merge(other *Bloom) error {
+	if b.bits != other.bits || b.hashes != other.hashes {
+		return fmt.Errorf("%w: %d bits and %d hashes, got %d bits and %d hashes",
+			IncompatibleBloomError, b.bits, b.hashes, other.bits, other.hashes)
+	}
+	for i := range other.words {
+		b.words[i].Or(other.words[i].Load())
+	}
+	return nil
+}
+
+// EstimatedCount approximates the number of distinct keys added, from the
+// fraction of bits that are set.
+func (b *Bloom) EstimatedCount() int {
+	set := 0
+	for i := range b.words {
+		set += bits.OnesCount64(b.words[i].Load())
+	}
+	if set == 0 {
+		return 0
+	}
+	m, k := float64(b.bits), float64(b.hashes)
+	if set >= int(b.bits) {
+		return math.MaxInt
+	}
+	return int(math.Round(-m / k * math.Log(1-float64(set)/m)))
+}
+
+// MarshalBinary encodes the filter, including its size and hash count, so
+// UnmarshalBinary can restore it in another process.
+func (b *Bloom) MarshalBinary() ([]byte, error) {
+	data := make([]byte, bloomHeaderSize, bloomHeaderSize+8*len(b.words))
+	data[0] = bloomVersion
+	binary.BigEndian.PutUint32(data[1:], b.hashes)
+	binary.BigEndian.PutUint64(data[5:], b.bits)
+	for i := range b.words {
+		data = binary.BigEndian.AppendUint64(data, b.words[i].Load())
+	}
+	return data, nil
+}
+
+// UnmarshalBinary replaces the filter with one decoded from data, as
+// produced by MarshalBinary. It must not be called concurrently with other
+// methods.
+//
+// Returns:
+//   - error: InvalidBloomEncodingError if data is malformed.
+func (b *Bloom) UnmarshalBinary(data []byte) error {
+	if len(data) < bloomHeaderSize || data[0] != bloomVersion {
+		return fmt.Errorf("%w: missing or unknown header", InvalidBloomEncodingError)
+	}
+	hashes := binary.BigEndian.Uint32(data[1:])
+	size := binary.BigEndian.Uint64(data[5:])
+	if hashes == 0 || size == 0 {
+		return fmt.Errorf("%w: %d bits and %d hashes", InvalidBloomEncodingError, size, hashes)
+	}
+	body := data[bloomHeaderSize:]
+	// (size-1)/64+1 rounds up to whole words without overflowing.
+	if words := (size-1)/64 + 1; uint64(len(body))%8 != 0 || uint64(len(body))/8 != words {
+		return fmt.Errorf("%w: %d bits need %d words, got %d bytes",
+			InvalidBloomEncodingError, size, words, len(body))
+	}
+	decoded := newBloom(size, hashes)
+	for i := range decoded.words {
+		decoded.words[i].Store(binary.BigEndian.Uint64(body[8*i:]))
+	}
+	b.words, b.bits, b.hashes = decoded.words, decoded.bits, decoded.hashes
+	return nil
+}
+
+// bloomHash derives the two hashes that Add and MaybeContains combine into
+// a bit index per hash function. FNV-1a is used rather than a seeded hash
+// so encoded filters remain valid across processes.
+func bloomHash(key string) (uint64, uint64) {
+	h := fnv.New64a()
+	h.Write([]byte(key))
+	sum := h.Sum64()
+	// Mixing the sum again gives an independent-enough second hash; forcing
+	// it odd keeps successive indexes from collapsing onto the same bit.
+	h2 := sum*0x9e3779b97f4a7c15 ^ sum>>29
+	return sum, h2 | 1
+}
@@ -0,0 +1,171 @@
+package collections
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+)
+
+func TestBloom_NoFalseNegatives(t *testing.T) {
+	// Arrange
+	b := NewBloom(1000, 0.01)
+	for i := 0; i < 1000; i++ {
+		b.Add("key-" + strconv.Itoa(i))
+	}
+
+	// Act & Assert
+	for i := 0; i < 1000; i++ {
+		if !b.MaybeContains("key-" + strconv.Itoa(i)) {
+			t.Fatalf("expected key-%d to be reported as maybe present", i)
+		}
+	}
+}
+
+func TestBloom_FalsePositiveRate(t *testing.T) {
+	// Arrange
+	b := NewBloom(10000, 0.01)
+	for i := 0; i < 10000; i++ {
+		b.Add("present-" + strconv.Itoa(i))
+	}
+
+	// Act
+	falsePositives := 0
+	const probes = 10000
+	for i := 0; i < probes; i++ {
+		if b.MaybeContains("absent-" + strconv.Itoa(i)) {
+			falsePositives++
+		}
+	}
+
+	// Assert: allow generous slack over the 1% target
+	if rate := float64(falsePositives) / probes; rate > 0.02 {
+		t.Errorf("expected a false-positive rate near 0.01, got %v", rate)
+	}
+}
+
+func TestBloom_EstimatedCount(t *testing.T) {
+	// Arrange
+	b := NewBloom(1000, 0.01)
+	empty := b.EstimatedCount()
+	for i := 0; i < 500; i++ {
+		b.Add(strconv.Itoa(i))
+	}
+
+	// Act
+	estimate := b.EstimatedCount()
+
+	// Assert
+	if empty != 0 {
+		t.Errorf("expected 0 for an empty filter, got %d", empty)
+	}
+	if estimate < 450 || estimate > 550 {
+		t.Errorf("expected an estimate near 500, got %d", estimate)
+	}
+}
+
+func TestBloom_Merge(t *testing.T) {
+	// Arrange
+	a := NewBloom(100, 0.01)
+	b := NewBloom(100, 0.01)
+	a.Add("alpha")
+	b.Add("beta")
+
+	// Act
+	err := a.Merge(b)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if !a.MaybeContains("alpha") || !a.MaybeContains("beta") {
+		t.Error("expected the merged filter to contain both keys")
+	}
+	if b.MaybeContains("alpha") {
+		t.Error("expected Merge to leave the other filter unchanged")
+	}
+}
+
+func TestBloom_MergeIncompatible(t *testing.T) {
+	// Arrange
+	a := NewBloom(100, 0.01)
+	b := NewBloom(1000, 0.01)
+
+	// Act
+	err := a.Merge(b)
+
+	// Assert
+	if !errors.Is(err, IncompatibleBloomError) {
+		t.Errorf("expected IncompatibleBloomError, got %v", err)
+	}
+}
+
+func TestBloom_BinaryRoundTrip(t *testing.T) {
+	// Arrange
+	original := NewBloom(100, 0.01)
+	original.Add("alpha")
+	original.Add("beta")
+
+	// Act
+	data, err := original.MarshalBinary()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	var restored Bloom
+	err = restored.UnmarshalBinary(data)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if !restored.MaybeContains("alpha") || !restored.MaybeContains("beta") {
+		t.Error("expected the restored filter to contain the added keys")
+	}
+	if err := restored.Merge(original); err != nil {
+		t.Errorf("expected the restored filter to be compatible, got %v", err)
+	}
+}
+
+func TestBloom_UnmarshalInvalid(t *testing.T) {
+	valid, _ := NewBloom(100, 0.01).MarshalBinary()
+	tests := map[string][]byte{
+		"empty":     nil,
+		"version":   append([]byte{9}, valid[1:]...),
+		"truncated": valid[:len(valid)-1],
+		"oversized": append(append([]byte{}, valid...), 0, 0, 0, 0, 0, 0, 0, 0),
+		"zero size": {1, 0, 0, 0, 1, 0, 0, 0, 0, 0, 0, 0, 0},
+		"huge size": {1, 0, 0, 0, 1, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff},
+	}
+	for name, data := range tests {
+		// Arrange
+		var b Bloom
+
+		// Act
+		err := b.UnmarshalBinary(data)
+
+		// Assert
+		if !errors.Is(err, InvalidBloomEncodingError) {
+			t.Errorf("%s: expected InvalidBloomEncodingError, got %v", name, err)
+		}
+	}
+}
+
+func TestNewBloom_PanicsOnInvalidArguments(t *testing.T) {
+	tests := []struct {
+		expected int
+		fpRate   float64
+	}{
+		{0, 0.01},
+		{100, 0},
+		{100, 1},
+	}
+	for _, tt := range tests {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("expected a panic for expected=%d fpRate=%v", tt.expected, tt.fpRate)
+				}
+			}()
+			NewBloom(tt.expected, tt.fpRate)
+		}()
+	}
+}
@@ -0,0 +1,122 @@
+package collections
+
+import (
+	"github.com/zodimo/go-zbase-std/optional"
+)
+
+// minDequeCapacity is the capacity allocated by the first push onto an
+// empty Deque.
+const minDequeCapacity = 8
+
+// Deque is a double-ended queue backed by a growable circular buffer. The
+// zero value is an empty deque ready to use. It is not safe for concurrent
+// use.
+type Deque[T any] struct {
+	buf  []T
+	head int // Index of the front value in buf.
+	n    int // Number of values.
+}
+
+// NewDeque creates a deque holding items, with the first item at the front.
+//
+// Example:
+//
+//	d := collections.NewDeque(2, 3)
+//	d.PushFront(1)
+//	back := d.PopBack() // Some(3)
+func NewDeque[T any](items ...T) *Deque[T] {
+	d := &Deque[T]{}
+	for _, item := range items {
+		d.PushBack(item)
+	}
+	return d
+}
+
+// PushFront adds value to the front of the deque.
+func (d *Deque[T]) PushFront(value T) {
+	d.grow()
+	d.head = d.index(-1)
+	d.buf[d.head] = value
+	d.n++
+}
+
+// PushBack adds value to the back of the deque.
+func (d *Deque[T]) PushBack(value T) {
+	d.grow()
+	d.buf[d.index(d.n)] = value
+	d.n++
+}
+
+// PopFront removes and returns the front value, or None if the deque is
+// empty.
+func (d *Deque[T]) PopFront() optional.Option[T] {
+	if d.n == 0 {
+		return optional.None[T]()
+	}
+	value := d.take(d.head)
+	d.head = d.index(1)
+	d.n--
+	return optional.Some(value)
+}
+
+// PopBack removes and returns the back value, or None if the deque is
+// empty.
+func (d *Deque[T]) PopBack() optional.Option[T] {
+	if d.n == 0 {
+		return optional.None[T]()
+	}
+	value := d.take(d.index(d.n - 1))
+	d.n--
+	return optional.Some(value)
+}
+
+// Front returns the front value without removing it, or None if the deque
+// is empty.
+func (d *Deque[T]) Front() optional.Option[T] {
+	if d.n == 0 {
+		return optional.None[T]()
+	}
+	return optional.Some(d.buf[d.head])
+}
+
+// Back returns the back value without removing it, or None if the deque is
+// empty.
+func (d *Deque[T]) Back() optional.Option[T] {
+	if d.n == 0 {
+		return optional.None[T]()
+	}
+	return optional.Some(d.buf[d.index(d.n-1)])
+}
+
+// Len returns the number of values in the deque.
+func (d *Deque[T]) Len() int {
+	return d.n
+}
+
+// index returns the position in buf of the value offset places from the
+// front. Offsets may be negative.
+func (d *Deque[T]) index(offset int) int {
+	return ((d.head+offset)%len(d.buf) + len(d.buf)) % len(d.buf)
+}
+
+// take returns the value at position i and clears the slot so the garbage
+// collector can reclaim it.
+func (d *Deque[T]) take(i int) T {
+	value := d.buf[i]
+	var zero T
+	d.buf[i] = zero
+	return value
+}
+
+// grow doubles the buffer when it is full, unwrapping the values so the
+// front is at index zero.
+func (d *Deque[T]) grow() {
+	if d.n < len(d.buf) {
+		return
+	}
+	buf := make([]T, max(2*len(d.buf), minDequeCapacity))
+	n := copy(buf, d.buf[d.head:])
+	copy(buf[n:], d.buf[:d.head])
+	d.buf = buf
+	d.head = 0
+}
@@ -0,0 +1,87 @@
+package collections
+
+import (
+	"testing"
+
+	"github.com/zodimo/go-zbase-std/optional"
+)
+
+// drainFront pops every value from the front of d.
+func drainFront[T any](d *Deque[T]) []T {
+	var out []T
+	for {
+		front := d.PopFront()
+		value, ok := front.Value()
+		if !ok {
+			return out
+		}
+		out = append(out, value)
+	}
+}
+
+func TestDeque_BothEnds(t *testing.T) {
+	// Arrange
+	d := NewDeque(2, 3)
+
+	// Act
+	d.PushFront(1)
+	d.PushBack(4)
+	front, back := d.Front(), d.Back()
+	poppedBack := d.PopBack()
+
+	// Assert
+	if v, _ := front.Value(); v != 1 {
+		t.Errorf("expected front 1, got %v", v)
+	}
+	if v, _ := back.Value(); v != 4 {
+		t.Errorf("expected back 4, got %v", v)
+	}
+	if v, _ := poppedBack.Value(); v != 4 {
+		t.Errorf("expected PopBack 4, got %v", v)
+	}
+	if got := drainFront(d); len(got) != 3 || got[0] != 1 || got[1] != 2 || got[2] != 3 {
+		t.Errorf("expected [1 2 3], got %v", got)
+	}
+}
+
+func TestDeque_Empty(t *testing.T) {
+	// Arrange
+	var d Deque[int]
+
+	// Act
+	results := []optional.Option[int]{d.PopFront(), d.PopBack(), d.Front(), d.Back()}
+
+	// Assert
+	for i, result := range results {
+		if _, ok := result.Value(); ok {
+			t.Errorf("expected None from call %d on an empty deque", i)
+		}
+	}
+}
+
+func TestDeque_GrowsAcrossWrap(t *testing.T) {
+	// Arrange
+	var d Deque[int]
+	for i := range 6 {
+		d.PushBack(i)
+	}
+	d.PopFront()
+	d.PopFront()
+
+	// Act: wrap around the end of the buffer, then force it to grow
+	for i := 6; i < 20; i++ {
+		d.PushBack(i)
+	}
+	d.PushFront(1)
+
+	// Assert
+	got := drainFront(&d)
+	if len(got) != 19 || got[0] != 1 {
+		t.Fatalf("expected 19 values starting with 1, got %v", got)
+	}
+	for i, v := range got[1:] {
+		if v != i+2 {
+			t.Fatalf("expected %d at position %d, got %v", i+2, i+1, got)
+		}
+	}
+}
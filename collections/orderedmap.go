@@ -0,0 +1,256 @@
+// Package collections provides generic container types that complement the
+// standard library, returning optional.Option where a value may be absent.
+package collections
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"iter"
+	"reflect"
+	"strconv"
+
+	"github.com/zodimo/go-zbase-std/optional"
+)
+
+// OrderedMap is a map that remembers the order in which keys were first
+// inserted. Updating an existing key keeps its position. The zero value is
+// an empty map ready to use. It is not safe for concurrent use.
+type OrderedMap[K comparable, V any] struct {
+	entries map[K]*orderedEntry[K, V]
+	head    *orderedEntry[K, V] // The oldest entry.
+	tail    *orderedEntry[K, V] // The newest entry.
+}
+
+// orderedEntry is an element of the insertion-order list of an OrderedMap.
+type orderedEntry[K comparable, V any] struct {
+	key        K
+	value      V
+	prev, next *orderedEntry[K, V]
+}
+
+// NewOrderedMap creates an empty OrderedMap.
+//
+// Example:
+//
+//	m := collections.NewOrderedMap[string, int]()
+//	m.Set("b", 2)
+//	m.Set("a", 1)
+//	out, _ := json.Marshal(m) // {"b":2,"a":1}
+func NewOrderedMap[K comparable, V any]() *OrderedMap[K, V] {
+	return &OrderedMap[K, V]{}
+}
+
+// Set stores value under key. A new key is appended to the order; an
+// existing key keeps its position.
+func (m *OrderedMap[K, V]) Set(key K, value V) {
+	if e, ok := m.entries[key]; ok {
+		e.value = value
+		return
+	}
+	if m.entries == nil {
+		m.entries = make(map[K]*orderedEntry[K, V])
+	}
+	e := &orderedEntry[K, V]{key: key, value: value, prev: m.tail}
+	if m.tail != nil {
+		m.tail.next = e
+	} else {
+		m.head = e
+	}
+	m.tail = e
+	m.entries[key] = e
+}
+
+// Get returns the value stored under key, or None if the key is absent.
+//
+// Example:
+//
+//	port := m.Get("port")
+//	if value, ok := port.Value(); ok {
+//		// use value
+//	}
+func (m *OrderedMap[K, V]) Get(key K) optional.Option[V] {
+	if e, ok := m.entries[key]; ok {
+		return optional.Some(e.value)
+	}
+	return optional.None[V]()
+}
+
+// Has reports whether key is present.
+func (m *OrderedMap[K, V]) Has(key K) bool {
+	_, ok := m.entries[key]
+	return ok
+}
+
+// Delete removes key and reports whether it was present.
+func (m *OrderedMap[K, V]) Delete(key K) bool {
+	e, ok := m.entries[key]
+	if !ok {
+		return false
+	}
+	delete(m.entries, key)
+	if e.prev != nil {
+		e.prev.next = e.next
+	} else {
+		m.head = e.next
+	}
+	if e.next != nil {
+		e.next.prev = e.prev
+	} else {
+		m.tail = e.prev
+	}
+	return true
+}
+
+// Len returns the number of entries.
+func (m *OrderedMap[K, V]) Len() int {
+	return len(m.entries)
+}
+
+// All returns an iterator over the entries in insertion order. Entries
+// deleted during iteration are not visited.
+func (m *OrderedMap[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for e := m.head; e != nil; e = e.next {
+			if !yield(e.key, e.value) {
+				return
+			}
+		}
+	}
+}
+
+// Keys returns an iterator over the keys in insertion order.
+func (m *OrderedMap[K, V]) Keys() iter.Seq[K] {
+	return func(yield func(K) bool) {
+		for k := range m.All() {
+			if !yield(k) {
+				return
+			}
+		}
+	}
+}
+
+// Values returns an iterator over the values in insertion order.
+func (m *OrderedMap[K, V]) Values() iter.Seq[V] {
+	return func(yield func(V) bool) {
+		for _, v := range m.All() {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// MarshalJSON encodes the map as a JSON object whose members appear in
+// insertion order. Keys are encoded like encoding/json encodes map keys:
+// strings as is, encoding.TextMarshaler implementations through
+// MarshalText, and integers in decimal. It has a value receiver so that an
+// OrderedMap embedded by value in another struct is encoded too.
+func (m OrderedMap[K, V]) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for e := m.head; e != nil; e = e.next {
+		if e != m.head {
+			buf.WriteByte(',')
+		}
+		key, err := encodeKey(e.key)
+		if err != nil {
+			return nil, err
+		}
+		keyJSON, err := json.Marshal(key)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(keyJSON)
+		buf.WriteByte(':')
+		valueJSON, err := json.Marshal(e.value)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(valueJSON)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// UnmarshalJSON decodes a JSON object into the map, appending its members
+// in document order. Keys are decoded as by MarshalJSON. Like
+// encoding/json does for maps, a JSON null leaves the map unchanged.
+func (m *OrderedMap[K, V]) UnmarshalJSON(data []byte) error {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	tok, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	if tok == nil {
+		return nil
+	}
+	if delim, ok := tok.(json.Delim); !ok || delim != '{' {
+		return fmt.Errorf("collections: OrderedMap: expected a JSON object, got %v", tok)
+	}
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return err
+		}
+		key, err := decodeKey[K](tok.(string))
+		if err != nil {
+			return err
+		}
+		var value V
+		if err := dec.Decode(&value); err != nil {
+			return err
+		}
+		m.Set(key, value)
+	}
+	_, err = dec.Token()
+	return err
+}
+
+// encodeKey converts a map key into a JSON object member name.
+func encodeKey[K comparable](key K) (string, error) {
+	if tm, ok := any(key).(encoding.TextMarshaler); ok {
+		text, err := tm.MarshalText()
+		return string(text), err
+	}
+	v := reflect.ValueOf(key)
+	switch v.Kind() {
+	case reflect.String:
+		return v.String(), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(v.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return strconv.FormatUint(v.Uint(), 10), nil
+	default:
+		return "", fmt.Errorf("collections: OrderedMap: unsupported key type %T", key)
+	}
+}
+
+// decodeKey converts a JSON object member name into a map key.
+func decodeKey[K comparable](name string) (K, error) {
+	var key K
+	if tu, ok := any(&key).(encoding.TextUnmarshaler); ok {
+		return key, tu.UnmarshalText([]byte(name))
+	}
+	v := reflect.ValueOf(&key).Elem()
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(name)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(name, 10, v.Type().Bits())
+		if err != nil {
+			return key, err
+		}
+		v.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		n, err := strconv.ParseUint(name, 10, v.Type().Bits())
+		if err != nil {
+			return key, err
+		}
+		v.SetUint(n)
+	default:
+		return key, fmt.Errorf("collections: OrderedMap: unsupported key type %T", key)
+	}
+	return key, nil
+}
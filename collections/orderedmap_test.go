@@ -0,0 +1,175 @@
+package collections
+
+import (
+	"encoding/json"
+	"slices"
+	"testing"
+)
+
+func TestOrderedMap_InsertionOrder(t *testing.T) {
+	// Arrange
+	m := NewOrderedMap[string, int]()
+
+	// Act
+	m.Set("c", 3)
+	m.Set("a", 1)
+	m.Set("b", 2)
+	m.Set("c", 30) // Updating keeps the position
+
+	// Assert
+	if keys := slices.Collect(m.Keys()); !slices.Equal(keys, []string{"c", "a", "b"}) {
+		t.Errorf("expected keys [c a b], got %v", keys)
+	}
+	if values := slices.Collect(m.Values()); !slices.Equal(values, []int{30, 1, 2}) {
+		t.Errorf("expected values [30 1 2], got %v", values)
+	}
+}
+
+func TestOrderedMap_Get(t *testing.T) {
+	// Arrange
+	var m OrderedMap[string, int]
+	m.Set("a", 1)
+
+	// Act
+	present := m.Get("a")
+	absent := m.Get("z")
+
+	// Assert
+	if value, ok := present.Value(); !ok || value != 1 {
+		t.Errorf("expected Some(1), got %v, %v", value, ok)
+	}
+	if _, ok := absent.Value(); ok {
+		t.Error("expected None for an absent key")
+	}
+}
+
+func TestOrderedMap_Delete(t *testing.T) {
+	// Arrange
+	m := NewOrderedMap[int, string]()
+	for i, s := range []string{"a", "b", "c", "d"} {
+		m.Set(i, s)
+	}
+
+	// Act
+	deletedMiddle := m.Delete(1)
+	deletedHead := m.Delete(0)
+	deletedTail := m.Delete(3)
+	deletedAbsent := m.Delete(9)
+	m.Set(4, "e")
+
+	// Assert
+	if !deletedMiddle || !deletedHead || !deletedTail || deletedAbsent {
+		t.Errorf("expected deletes true, true, true, false, got %v, %v, %v, %v", deletedMiddle, deletedHead, deletedTail, deletedAbsent)
+	}
+	if keys := slices.Collect(m.Keys()); !slices.Equal(keys, []int{2, 4}) {
+		t.Errorf("expected keys [2 4], got %v", keys)
+	}
+	if m.Len() != 2 || m.Has(1) {
+		t.Errorf("expected 2 entries without key 1, got %d", m.Len())
+	}
+}
+
+func TestOrderedMap_MarshalJSON(t *testing.T) {
+	// Arrange
+	m := NewOrderedMap[string, any]()
+	m.Set("zeta", 1)
+	m.Set("alpha", []int{2})
+	m.Set("mid", "x")
+
+	// Act
+	out, err := json.Marshal(m)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if expected := `{"zeta":1,"alpha":[2],"mid":"x"}`; string(out) != expected {
+		t.Errorf("expected %s, got %s", expected, out)
+	}
+}
+
+func TestOrderedMap_MarshalJSONIntKeys(t *testing.T) {
+	// Arrange
+	m := NewOrderedMap[int, bool]()
+	m.Set(10, true)
+	m.Set(2, false)
+
+	// Act
+	out, err := json.Marshal(m)
+
+	// Assert
+	if err != nil || string(out) != `{"10":true,"2":false}` {
+		t.Errorf("expected {\"10\":true,\"2\":false}, got %s, %v", out, err)
+	}
+}
+
+func TestOrderedMap_UnmarshalJSON(t *testing.T) {
+	// Arrange
+	var m OrderedMap[string, int]
+
+	// Act
+	err := json.Unmarshal([]byte(`{"b": 2, "a": 1, "c": 3}`), &m)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if keys := slices.Collect(m.Keys()); !slices.Equal(keys, []string{"b", "a", "c"}) {
+		t.Errorf("expected document order [b a c], got %v", keys)
+	}
+	out, _ := json.Marshal(&m)
+	if string(out) != `{"b":2,"a":1,"c":3}` {
+		t.Errorf("expected a round trip to keep the order, got %s", out)
+	}
+}
+
+func TestOrderedMap_MarshalJSONEmbeddedByValue(t *testing.T) {
+	// Arrange
+	type config struct {
+		Labels OrderedMap[string, string]
+	}
+	var c config
+	c.Labels.Set("b", "2")
+	c.Labels.Set("a", "1")
+
+	// Act
+	out, err := json.Marshal(c)
+
+	// Assert
+	if err != nil || string(out) != `{"Labels":{"b":"2","a":"1"}}` {
+		t.Errorf("expected {\"Labels\":{\"b\":\"2\",\"a\":\"1\"}}, got %s, %v", out, err)
+	}
+}
+
+func TestOrderedMap_UnmarshalJSONNull(t *testing.T) {
+	// Arrange
+	var m OrderedMap[string, int]
+	var c struct {
+		Labels OrderedMap[string, int]
+	}
+
+	// Act
+	err := json.Unmarshal([]byte(`null`), &m)
+	fieldErr := json.Unmarshal([]byte(`{"Labels": null}`), &c)
+
+	// Assert
+	if err != nil || fieldErr != nil {
+		t.Fatalf("expected no errors, got %v and %v", err, fieldErr)
+	}
+	if m.Len() != 0 || c.Labels.Len() != 0 {
+		t.Errorf("expected empty maps, got Len %d and %d", m.Len(), c.Labels.Len())
+	}
+}
+
+func TestOrderedMap_UnmarshalJSONNotObject(t *testing.T) {
+	// Arrange
+	var m OrderedMap[string, int]
+
+	// Act
+	err := json.Unmarshal([]byte(`[1, 2]`), &m)
+
+	// Assert
+	if err == nil {
+		t.Error("expected an error for a JSON array")
+	}
+}
@@ -0,0 +1,132 @@
+package collections
+
+import (
+	"container/heap"
+	"context"
+	"sync"
+
+	"github.com/zodimo/go-zbase-std/optional"
+)
+
+// PriorityQueue is a concurrency-safe heap that yields its values in
+// priority order, as decided by a comparator. Pop blocks until a value is
+// available, which makes the queue usable as a work source for schedulers.
+type PriorityQueue[T any] struct {
+	mu      sync.Mutex
+	heap    priorityHeap[T]
+	pushed  chan struct{} // Closed and replaced whenever a value is pushed.
+	waiting int           // Number of goroutines blocked in Pop.
+}
+
+// priorityHeap adapts a slice and comparator to heap.Interface.
+type priorityHeap[T any] struct {
+	items []T
+	less  func(a, b T) bool
+}
+
+func (h *priorityHeap[T]) Len() int           { return len(h.items) }
+func (h *priorityHeap[T]) Less(i, j int) bool { return h.less(h.items[i], h.items[j]) }
+func (h *priorityHeap[T]) Swap(i, j int)      { h.items[i], h.items[j] = h.items[j], h.items[i] }
+func (h *priorityHeap[T]) Push(x any)         { h.items = append(h.items, x.(T)) }
+
+func (h *priorityHeap[T]) Pop() any {
+	n := len(h.items) - 1
+	item := h.items[n]
+	var zero T
+	h.items[n] = zero // Release the reference for the garbage collector.
+	h.items = h.items[:n]
+	return item
+}
+
+// NewPriorityQueue creates an empty priority queue.
+//
+// Parameters:
+//   - less: Reports whether a must be popped before b.
+//
+// Returns:
+//   - *PriorityQueue[T]: The new priority queue.
+//
+// Example:
+//
+//	jobs := collections.NewPriorityQueue(func(a, b Job) bool {
+//		return a.Priority > b.Priority
+//	})
+//	jobs.Push(Job{Priority: 5})
+//	next, err := jobs.Pop(ctx)
+func NewPriorityQueue[T any](less func(a, b T) bool) *PriorityQueue[T] {
+	return &PriorityQueue[T]{
+		heap:   priorityHeap[T]{less: less},
+		pushed: make(chan struct{}),
+	}
+}
+
+// Push adds value to the queue and wakes goroutines blocked in Pop.
+func (pq *PriorityQueue[T]) Push(value T) {
+	pq.mu.Lock()
+	defer pq.mu.Unlock()
+	heap.Push(&pq.heap, value)
+	if pq.waiting > 0 {
+		close(pq.pushed)
+		pq.pushed = make(chan struct{})
+	}
+}
+
+// Pop removes and returns the highest-priority value, waiting for one to be
+// pushed if the queue is empty.
+//
+// Parameters:
+//   - ctx: Bounds the wait.
+//
+// Returns:
+//   - T: The highest-priority value.
+//   - error: ctx.Err() if ctx is done before a value is available.
+func (pq *PriorityQueue[T]) Pop(ctx context.Context) (T, error) {
+	pq.mu.Lock()
+	for pq.heap.Len() == 0 {
+		if err := ctx.Err(); err != nil {
+			pq.mu.Unlock()
+			var zero T
+			return zero, err
+		}
+		pushed := pq.pushed
+		pq.waiting++
+		pq.mu.Unlock()
+		select {
+		case <-pushed:
+		case <-ctx.Done():
+		}
+		pq.mu.Lock()
+		pq.waiting--
+	}
+	defer pq.mu.Unlock()
+	return heap.Pop(&pq.heap).(T), nil
+}
+
+// TryPop removes and returns the highest-priority value without waiting,
+// or None if the queue is empty.
+func (pq *PriorityQueue[T]) TryPop() optional.Option[T] {
+	pq.mu.Lock()
+	defer pq.mu.Unlock()
+	if pq.heap.Len() == 0 {
+		return optional.None[T]()
+	}
+	return optional.Some(heap.Pop(&pq.heap).(T))
+}
+
+// Peek returns the highest-priority value without removing it, or None if
+// the queue is empty.
+func (pq *PriorityQueue[T]) Peek() optional.Option[T] {
+	pq.mu.Lock()
+	defer pq.mu.Unlock()
+	if pq.heap.Len() == 0 {
+		return optional.None[T]()
+	}
+	return optional.Some(pq.heap.items[0])
+}
+
+// Len returns the number of values in the queue.
+func (pq *PriorityQueue[T]) Len() int {
+	pq.mu.Lock()
+	defer pq.mu.Unlock()
+	return pq.heap.Len()
+}
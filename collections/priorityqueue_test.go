@@ -0,0 +1,91 @@
+package collections
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestPriorityQueue_Order(t *testing.T) {
+	// Arrange
+	pq := NewPriorityQueue(func(a, b int) bool { return a > b })
+	for _, v := range []int{3, 9, 1, 7, 5} {
+		pq.Push(v)
+	}
+
+	// Act
+	peeked := pq.Peek()
+	var popped []int
+	for pq.Len() > 0 {
+		v, _ := pq.Pop(context.Background())
+		popped = append(popped, v)
+	}
+
+	// Assert
+	if v, ok := peeked.Value(); !ok || v != 9 {
+		t.Errorf("expected Peek Some(9), got %v, %v", v, ok)
+	}
+	expected := []int{9, 7, 5, 3, 1}
+	for i := range expected {
+		if popped[i] != expected[i] {
+			t.Fatalf("expected %v, got %v", expected, popped)
+		}
+	}
+}
+
+func TestPriorityQueue_TryPopEmpty(t *testing.T) {
+	// Arrange
+	pq := NewPriorityQueue(func(a, b string) bool { return a < b })
+
+	// Act
+	popped := pq.TryPop()
+	peeked := pq.Peek()
+
+	// Assert
+	if _, ok := popped.Value(); ok {
+		t.Error("expected None from TryPop on an empty queue")
+	}
+	if _, ok := peeked.Value(); ok {
+		t.Error("expected None from Peek on an empty queue")
+	}
+}
+
+func TestPriorityQueue_PopWaitsForPush(t *testing.T) {
+	// Arrange
+	pq := NewPriorityQueue(func(a, b int) bool { return a < b })
+	result := make(chan int)
+	go func() {
+		v, _ := pq.Pop(context.Background())
+		result <- v
+	}()
+
+	// Act
+	time.Sleep(10 * time.Millisecond)
+	pq.Push(42)
+
+	// Assert
+	select {
+	case v := <-result:
+		if v != 42 {
+			t.Errorf("expected 42, got %d", v)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("expected Pop to return after Push")
+	}
+}
+
+func TestPriorityQueue_PopCancelled(t *testing.T) {
+	// Arrange
+	pq := NewPriorityQueue(func(a, b int) bool { return a < b })
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	// Act
+	_, err := pq.Pop(ctx)
+
+	// Assert
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected context.DeadlineExceeded, got %v", err)
+	}
+}
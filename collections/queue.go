@@ -0,0 +1,110 @@
+package collections
+
+import (
+	"sync"
+
+	"github.com/zodimo/go-zbase-std/optional"
+)
+
+// Queue is a first-in, first-out collection. The zero value is an empty
+// queue ready to use. It is not safe for concurrent use; see SyncQueue.
+type Queue[T any] struct {
+	items []T
+	head  int // Index of the front value in items.
+}
+
+// NewQueue creates a queue holding items, with the first item at the front.
+//
+// Example:
+//
+//	q := collections.NewQueue("a", "b")
+//	q.Push("c")
+//	front := q.Pop() // Some("a")
+func NewQueue[T any](items ...T) *Queue[T] {
+	return &Queue[T]{items: append([]T(nil), items...)}
+}
+
+// Push adds value to the back of the queue.
+func (q *Queue[T]) Push(value T) {
+	// Reclaim the popped prefix once it makes up half of the backing array,
+	// so a long-lived queue does not grow without bound.
+	if q.head > 0 && q.head >= len(q.items)/2 && len(q.items) == cap(q.items) {
+		n := copy(q.items, q.items[q.head:])
+		clear(q.items[n:])
+		q.items = q.items[:n]
+		q.head = 0
+	}
+	q.items = append(q.items, value)
+}
+
+// Pop removes and returns the front value, or None if the queue is empty.
+func (q *Queue[T]) Pop() optional.Option[T] {
+	if q.head == len(q.items) {
+		return optional.None[T]()
+	}
+	value := q.items[q.head]
+	var zero T
+	q.items[q.head] = zero // Release the reference for the garbage collector.
+	q.head++
+	if q.head == len(q.items) {
+		q.items = q.items[:0]
+		q.head = 0
+	}
+	return optional.Some(value)
+}
+
+// Peek returns the front value without removing it, or None if the queue is
+// empty.
+func (q *Queue[T]) Peek() optional.Option[T] {
+	if q.head == len(q.items) {
+		return optional.None[T]()
+	}
+	return optional.Some(q.items[q.head])
+}
+
+// Len returns the number of values in the queue.
+func (q *Queue[T]) Len() int {
+	return len(q.items) - q.head
+}
+
+// SyncQueue is a Queue guarded by a mutex, safe for concurrent use. The zero
+// value is an empty queue ready to use.
+type SyncQueue[T any] struct {
+	mu    sync.Mutex
+	queue Queue[T]
+}
+
+// NewSyncQueue creates a concurrency-safe queue holding items, with the
+// first item at the front.
+func NewSyncQueue[T any](items ...T) *SyncQueue[T] {
+	return &SyncQueue[T]{queue: Queue[T]{items: append([]T(nil), items...)}}
+}
+
+// Push adds value to the back of the queue.
+func (q *SyncQueue[T]) Push(value T) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.queue.Push(value)
+}
+
+// Pop removes and returns the front value, or None if the queue is empty.
+func (q *SyncQueue[T]) Pop() optional.Option[T] {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.queue.Pop()
+}
+
+// Peek returns the front value without removing it, or None if the queue is
+// empty.
+func (q *SyncQueue[T]) Peek() optional.Option[T] {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.queue.Peek()
+}
+
+// Len returns the number of values in the queue.
+func (q *SyncQueue[T]) Len() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.queue.Len()
+}
@@ -0,0 +1,89 @@
+package collections
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestQueue_FIFO(t *testing.T) {
+	// Arrange
+	q := NewQueue("a", "b")
+	q.Push("c")
+
+	// Act
+	peeked := q.Peek()
+	var popped []string
+	for range 3 {
+		front := q.Pop()
+		if v, ok := front.Value(); ok {
+			popped = append(popped, v)
+		}
+	}
+	empty := q.Pop()
+
+	// Assert
+	if v, ok := peeked.Value(); !ok || v != "a" {
+		t.Errorf("expected Peek Some(a), got %v, %v", v, ok)
+	}
+	if len(popped) != 3 || popped[0] != "a" || popped[1] != "b" || popped[2] != "c" {
+		t.Errorf("expected [a b c], got %v", popped)
+	}
+	if _, ok := empty.Value(); ok {
+		t.Error("expected None from an empty queue")
+	}
+}
+
+func TestQueue_InterleavedKeepsOrder(t *testing.T) {
+	// Arrange
+	var q Queue[int]
+	next := 0
+
+	// Act
+	for i := range 1000 {
+		q.Push(i)
+		if i%3 == 0 {
+			front := q.Pop()
+			if v, _ := front.Value(); v != next {
+				t.Fatalf("expected %d, got %d", next, v)
+			}
+			next++
+		}
+	}
+
+	// Assert
+	if q.Len() != 1000-next {
+		t.Errorf("expected length %d, got %d", 1000-next, q.Len())
+	}
+	for q.Len() > 0 {
+		front := q.Pop()
+		if v, _ := front.Value(); v != next {
+			t.Fatalf("expected %d, got %d", next, v)
+		}
+		next++
+	}
+}
+
+func TestSyncQueue_Concurrent(t *testing.T) {
+	// Arrange
+	q := NewSyncQueue[int]()
+	var wg sync.WaitGroup
+
+	// Act
+	for i := range 100 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			q.Push(i)
+		}()
+	}
+	wg.Wait()
+
+	// Assert
+	if q.Len() != 100 {
+		t.Errorf("expected length 100, got %d", q.Len())
+	}
+	front := q.Peek()
+	if _, ok := front.Value(); !ok {
+		t.Error("expected Some from a non-empty queue")
+	}
+}
@@ -0,0 +1,99 @@
+package collections
+
+import (
+	"github.com/zodimo/go-zbase-std/optional"
+)
+
+// RingMode selects what a full RingBuffer does with a new value.
+type RingMode int
+
+const (
+	// RingReject makes Push on a full buffer discard the new value.
+	RingReject RingMode = iota
+	// RingOverwrite makes Push on a full buffer drop the oldest value to make
+	// room for the new one.
+	RingOverwrite
+)
+
+// RingBuffer is a first-in, first-out buffer with a fixed capacity, for
+// producer/consumer buffering without channel semantics: pushes never block
+// and pops return None when the buffer is empty. It is not safe for
+// concurrent use.
+type RingBuffer[T any] struct {
+	deque    Deque[T]
+	mode     RingMode
+	dropped  uint64
+	capacity int
+}
+
+// NewRingBuffer creates an empty ring buffer.
+//
+// Parameters:
+//   - capacity: The maximum number of values held. It must be positive.
+//   - mode: What Push does when the buffer is full.
+//
+// Returns:
+//   - *RingBuffer[T]: The new ring buffer.
+//
+// Example:
+//
+//	recent := collections.NewRingBuffer[string](100, collections.RingOverwrite)
+//	recent.Push(line) // Keeps the last 100 lines
+func NewRingBuffer[T any](capacity int, mode RingMode) *RingBuffer[T] {
+	if capacity <= 0 {
+		panic("collections: RingBuffer capacity must be positive")
+	}
+	return &RingBuffer[T]{
+		deque:    Deque[T]{buf: make([]T, capacity)},
+		mode:     mode,
+		capacity: capacity,
+	}
+}
+
+// Push appends value and reports whether it was stored. When the buffer is
+// full, a RingReject buffer discards value and returns false, while a
+// RingOverwrite buffer drops its oldest value and returns true. Either way
+// the discarded value is counted by Dropped.
+func (r *RingBuffer[T]) Push(value T) bool {
+	if r.deque.Len() == r.capacity {
+		r.dropped++
+		if r.mode == RingReject {
+			return false
+		}
+		r.deque.PopFront()
+	}
+	r.deque.PushBack(value)
+	return true
+}
+
+// Pop removes and returns the oldest value, or None if the buffer is empty.
+func (r *RingBuffer[T]) Pop() optional.Option[T] {
+	return r.deque.PopFront()
+}
+
+// Peek returns the oldest value without removing it, or None if the buffer
+// is empty.
+func (r *RingBuffer[T]) Peek() optional.Option[T] {
+	return r.deque.Front()
+}
+
+// Len returns the number of values in the buffer.
+func (r *RingBuffer[T]) Len() int {
+	return r.deque.Len()
+}
+
+// Cap returns the capacity of the buffer.
+func (r *RingBuffer[T]) Cap() int {
+	return r.capacity
+}
+
+// Full reports whether the buffer holds Cap values.
+func (r *RingBuffer[T]) Full() bool {
+	return r.deque.Len() == r.capacity
+}
+
+// Dropped returns the number of values discarded because the buffer was
+// full.
+func (r *RingBuffer[T]) Dropped() uint64 {
+	return r.dropped
+}
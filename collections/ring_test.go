@@ -0,0 +1,63 @@
+package collections
+
+import (
+	"testing"
+)
+
+func TestRingBuffer_Reject(t *testing.T) {
+	// Arrange
+	r := NewRingBuffer[int](2, RingReject)
+
+	// Act
+	first, second, third := r.Push(1), r.Push(2), r.Push(3)
+
+	// Assert
+	if !first || !second || third {
+		t.Errorf("expected pushes true, true, false, got %v, %v, %v", first, second, third)
+	}
+	if !r.Full() || r.Dropped() != 1 {
+		t.Errorf("expected full buffer with 1 drop, got full=%v dropped=%d", r.Full(), r.Dropped())
+	}
+	oldest := r.Pop()
+	if v, _ := oldest.Value(); v != 1 {
+		t.Errorf("expected oldest value 1, got %v", v)
+	}
+}
+
+func TestRingBuffer_Overwrite(t *testing.T) {
+	// Arrange
+	r := NewRingBuffer[int](3, RingOverwrite)
+
+	// Act
+	for i := 1; i <= 5; i++ {
+		r.Push(i)
+	}
+
+	// Assert
+	if r.Len() != 3 || r.Dropped() != 2 {
+		t.Errorf("expected 3 values and 2 drops, got %d and %d", r.Len(), r.Dropped())
+	}
+	for _, expected := range []int{3, 4, 5} {
+		value := r.Pop()
+		if v, ok := value.Value(); !ok || v != expected {
+			t.Errorf("expected %d, got %v, %v", expected, v, ok)
+		}
+	}
+	empty := r.Peek()
+	if _, ok := empty.Value(); ok {
+		t.Error("expected None from an empty buffer")
+	}
+}
+
+func TestRingBuffer_InvalidCapacity(t *testing.T) {
+	// Arrange
+	defer func() {
+		// Assert
+		if recover() == nil {
+			t.Error("expected a panic for a zero capacity")
+		}
+	}()
+
+	// Act
+	NewRingBuffer[int](0, RingReject)
+}
@@ -0,0 +1,134 @@
+// Package set provides a generic set of comparable values, replacing
+// hand-rolled map[T]struct{} sets.
+package set
+
+import (
+	"iter"
+	"maps"
+)
+
+// Set is an unordered collection of distinct values. The zero value is an
+// empty set ready to use. A Set is not safe for concurrent use; see
+// SyncSet.
+type Set[T comparable] struct {
+	items map[T]struct{}
+}
+
+// New creates a Set holding the given items.
+//
+// Example:
+//
+//	admins := set.New("alice", "bob")
+func New[T comparable](items ...T) *Set[T] {
+	s := &Set[T]{items: make(map[T]struct{}, len(items))}
+	s.Add(items...)
+	return s
+}
+
+// Collect creates a Set holding the values produced by seq.
+//
+// Example:
+//
+//	keys := set.Collect(maps.Keys(m))
+func Collect[T comparable](seq iter.Seq[T]) *Set[T] {
+	s := New[T]()
+	for item := range seq {
+		s.items[item] = struct{}{}
+	}
+	return s
+}
+
+// Add adds items to the set.
+func (s *Set[T]) Add(items ...T) {
+	if s.items == nil {
+		s.items = make(map[T]struct{}, len(items))
+	}
+	for _, item := range items {
+		s.items[item] = struct{}{}
+	}
+}
+
+// Remove removes items from the set; absent items are ignored.
+func (s *Set[T]) Remove(items ...T) {
+	for _, item := range items {
+		delete(s.items, item)
+	}
+}
+
+// Contains reports whether item is in the set.
+func (s *Set[T]) Contains(item T) bool {
+	_, ok := s.items[item]
+	return ok
+}
+
+// Len returns the number of items in the set.
+func (s *Set[T]) Len() int {
+	return len(s.items)
+}
+
+// All returns an iterator over the items of the set, in no particular
+// order.
+//
+// Example:
+//
+//	sorted := slices.Sorted(admins.All())
+func (s *Set[T]) All() iter.Seq[T] {
+	return maps.Keys(s.items)
+}
+
+// Clone returns a copy of the set.
+func (s *Set[T]) Clone() *Set[T] {
+	return &Set[T]{items: maps.Clone(s.items)}
+}
+
+// Equal reports whether both sets hold the same items.
+func (s *Set[T]) Equal(other *Set[T]) bool {
+	if s.Len() != other.Len() {
+		return false
+	}
+	for item := range s.items {
+		if !other.Contains(item) {
+			return false
+		}
+	}
+	return true
+}
+
+// Union returns a new set holding the items of either set.
+func (s *Set[T]) Union(other *Set[T]) *Set[T] {
+	union := s.Clone()
+	if union.items == nil {
+		union.items = make(map[T]struct{}, other.Len())
+	}
+	for item := range other.items {
+		union.items[item] = struct{}{}
+	}
+	return union
+}
+
+// Intersect returns a new set holding the items present in both sets.
+func (s *Set[T]) Intersect(other *Set[T]) *Set[T] {
+	small, large := s, other
+	if small.Len() > large.Len() {
+		small, large = large, small
+	}
+	intersection := New[T]()
+	for item := range small.items {
+		if large.Contains(item) {
+			intersection.items[item] = struct{}{}
+		}
+	}
+	return intersection
+}
+
+// Difference returns a new set holding the items of s that are not in
+// other.
+func (s *Set[T]) Difference(other *Set[T]) *Set[T] {
+	difference := New[T]()
+	for item := range s.items {
+		if !other.Contains(item) {
+			difference.items[item] = struct{}{}
+		}
+	}
+	return difference
+}
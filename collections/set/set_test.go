@@ -0,0 +1,98 @@
+package set
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestSet_AddRemoveContains(t *testing.T) {
+	// Arrange
+	s := New(1, 2)
+
+	// Act
+	s.Add(3, 2)
+	s.Remove(1, 42)
+
+	// Assert
+	if s.Contains(1) || !s.Contains(2) || !s.Contains(3) {
+		t.Errorf("expected {2 3}, got %v", slices.Sorted(s.All()))
+	}
+	if s.Len() != 2 {
+		t.Errorf("expected length 2, got %d", s.Len())
+	}
+}
+
+func TestSet_ZeroValue(t *testing.T) {
+	// Arrange
+	var s Set[string]
+
+	// Act
+	s.Add("a")
+
+	// Assert
+	if !s.Contains("a") || s.Len() != 1 {
+		t.Error("expected the zero Set to be usable")
+	}
+}
+
+func TestSet_Collect(t *testing.T) {
+	// Act
+	s := Collect(slices.Values([]string{"b", "a", "b"}))
+
+	// Assert
+	if got := slices.Sorted(s.All()); !slices.Equal(got, []string{"a", "b"}) {
+		t.Errorf("expected [a b], got %v", got)
+	}
+}
+
+func TestSet_Operations(t *testing.T) {
+	// Arrange
+	a := New(1, 2, 3)
+	b := New(2, 3, 4)
+
+	// Act
+	union := a.Union(b)
+	intersection := a.Intersect(b)
+	difference := a.Difference(b)
+
+	// Assert
+	if !union.Equal(New(1, 2, 3, 4)) {
+		t.Errorf("expected union [1 2 3 4], got %v", slices.Sorted(union.All()))
+	}
+	if !intersection.Equal(New(2, 3)) {
+		t.Errorf("expected intersection [2 3], got %v", slices.Sorted(intersection.All()))
+	}
+	if !difference.Equal(New(1)) {
+		t.Errorf("expected difference [1], got %v", slices.Sorted(difference.All()))
+	}
+	if !a.Equal(New(1, 2, 3)) {
+		t.Error("expected operations not to modify their operands")
+	}
+}
+
+func TestSet_UnionOfZeroValue(t *testing.T) {
+	// Arrange
+	var empty Set[int]
+
+	// Act
+	union := empty.Union(New(1))
+
+	// Assert
+	if !union.Equal(New(1)) {
+		t.Errorf("expected [1], got %v", slices.Sorted(union.All()))
+	}
+}
+
+func TestSet_CloneIsIndependent(t *testing.T) {
+	// Arrange
+	s := New("x")
+
+	// Act
+	clone := s.Clone()
+	clone.Add("y")
+
+	// Assert
+	if s.Contains("y") {
+		t.Error("expected the clone to be independent of the original")
+	}
+}
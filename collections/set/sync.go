@@ -0,0 +1,62 @@
+package set
+
+import (
+	"iter"
+	"sync"
+)
+
+// SyncSet is a Set that is safe for concurrent use. The zero value is an
+// empty set ready to use.
+type SyncSet[T comparable] struct {
+	mu  sync.RWMutex
+	set Set[T]
+}
+
+// NewSync creates a SyncSet holding the given items.
+func NewSync[T comparable](items ...T) *SyncSet[T] {
+	s := &SyncSet[T]{}
+	s.set.Add(items...)
+	return s
+}
+
+// Add adds items to the set.
+func (s *SyncSet[T]) Add(items ...T) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.set.Add(items...)
+}
+
+// Remove removes items from the set; absent items are ignored.
+func (s *SyncSet[T]) Remove(items ...T) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.set.Remove(items...)
+}
+
+// Contains reports whether item is in the set.
+func (s *SyncSet[T]) Contains(item T) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.set.Contains(item)
+}
+
+// Len returns the number of items in the set.
+func (s *SyncSet[T]) Len() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.set.Len()
+}
+
+// All returns an iterator over a snapshot of the items of the set, so the
+// set can be modified while iterating.
+func (s *SyncSet[T]) All() iter.Seq[T] {
+	return s.Snapshot().All()
+}
+
+// Snapshot returns a copy of the set as a plain Set, for example to combine
+// it with other sets.
+func (s *SyncSet[T]) Snapshot() *Set[T] {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.set.Clone()
+}
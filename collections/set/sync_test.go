@@ -0,0 +1,58 @@
+package set
+
+import (
+	"slices"
+	"sync"
+	"testing"
+)
+
+func TestSyncSet_ConcurrentAdd(t *testing.T) {
+	// Arrange
+	s := NewSync[int]()
+	var wg sync.WaitGroup
+
+	// Act
+	for i := range 100 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			s.Add(i % 10)
+			_ = s.Contains(i)
+		}()
+	}
+	wg.Wait()
+
+	// Assert
+	if s.Len() != 10 {
+		t.Errorf("expected 10 distinct items, got %d", s.Len())
+	}
+}
+
+func TestSyncSet_ModifyWhileIterating(t *testing.T) {
+	// Arrange
+	s := NewSync(1, 2, 3)
+
+	// Act
+	for item := range s.All() {
+		s.Remove(item)
+	}
+
+	// Assert
+	if s.Len() != 0 {
+		t.Errorf("expected every item to be removed, got %d left", s.Len())
+	}
+}
+
+func TestSyncSet_Snapshot(t *testing.T) {
+	// Arrange
+	s := NewSync("a", "b")
+
+	// Act
+	snapshot := s.Snapshot()
+	s.Add("c")
+
+	// Assert
+	if got := slices.Sorted(snapshot.All()); !slices.Equal(got, []string{"a", "b"}) {
+		t.Errorf("expected the snapshot [a b], got %v", got)
+	}
+}
@@ -0,0 +1,98 @@
+package collections
+
+import (
+	"sync"
+
+	"github.com/zodimo/go-zbase-std/optional"
+)
+
+// Stack is a last-in, first-out collection. The zero value is an empty stack
+// ready to use. It is not safe for concurrent use; see SyncStack.
+type Stack[T any] struct {
+	items []T
+}
+
+// NewStack creates a stack holding items, with the last item on top.
+//
+// Example:
+//
+//	s := collections.NewStack(1, 2)
+//	s.Push(3)
+//	top := s.Pop() // Some(3)
+func NewStack[T any](items ...T) *Stack[T] {
+	return &Stack[T]{items: append([]T(nil), items...)}
+}
+
+// Push places value on top of the stack.
+func (s *Stack[T]) Push(value T) {
+	s.items = append(s.items, value)
+}
+
+// Pop removes and returns the top value, or None if the stack is empty.
+func (s *Stack[T]) Pop() optional.Option[T] {
+	n := len(s.items)
+	if n == 0 {
+		return optional.None[T]()
+	}
+	value := s.items[n-1]
+	var zero T
+	s.items[n-1] = zero // Release the reference for the garbage collector.
+	s.items = s.items[:n-1]
+	return optional.Some(value)
+}
+
+// Peek returns the top value without removing it, or None if the stack is
+// empty.
+func (s *Stack[T]) Peek() optional.Option[T] {
+	if len(s.items) == 0 {
+		return optional.None[T]()
+	}
+	return optional.Some(s.items[len(s.items)-1])
+}
+
+// Len returns the number of values on the stack.
+func (s *Stack[T]) Len() int {
+	return len(s.items)
+}
+
+// SyncStack is a Stack guarded by a mutex, safe for concurrent use. The zero
+// value is an empty stack ready to use.
+type SyncStack[T any] struct {
+	mu    sync.Mutex
+	stack Stack[T]
+}
+
+// NewSyncStack creates a concurrency-safe stack holding items, with the last
+// item on top.
+func NewSyncStack[T any](items ...T) *SyncStack[T] {
+	return &SyncStack[T]{stack: Stack[T]{items: append([]T(nil), items...)}}
+}
+
+// Push places value on top of the stack.
+func (s *SyncStack[T]) Push(value T) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.stack.Push(value)
+}
+
+// Pop removes and returns the top value, or None if the stack is empty.
+func (s *SyncStack[T]) Pop() optional.Option[T] {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.stack.Pop()
+}
+
+// Peek returns the top value without removing it, or None if the stack is
+// empty.
+func (s *SyncStack[T]) Peek() optional.Option[T] {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.stack.Peek()
+}
+
+// Len returns the number of values on the stack.
+func (s *SyncStack[T]) Len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.stack.Len()
+}
@@ -0,0 +1,80 @@
+package collections
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestStack_LIFO(t *testing.T) {
+	// Arrange
+	s := NewStack(1, 2)
+	s.Push(3)
+
+	// Act
+	peeked := s.Peek()
+	var popped []int
+	for range 3 {
+		top := s.Pop()
+		if v, ok := top.Value(); ok {
+			popped = append(popped, v)
+		}
+	}
+	empty := s.Pop()
+
+	// Assert
+	if v, ok := peeked.Value(); !ok || v != 3 {
+		t.Errorf("expected Peek Some(3), got %v, %v", v, ok)
+	}
+	if len(popped) != 3 || popped[0] != 3 || popped[1] != 2 || popped[2] != 1 {
+		t.Errorf("expected [3 2 1], got %v", popped)
+	}
+	if _, ok := empty.Value(); ok {
+		t.Error("expected None from an empty stack")
+	}
+}
+
+func TestStack_ZeroValue(t *testing.T) {
+	// Arrange
+	var s Stack[string]
+
+	// Act
+	peeked := s.Peek()
+	s.Push("a")
+
+	// Assert
+	if _, ok := peeked.Value(); ok {
+		t.Error("expected None from Peek on an empty stack")
+	}
+	if s.Len() != 1 {
+		t.Errorf("expected length 1, got %d", s.Len())
+	}
+}
+
+func TestSyncStack_Concurrent(t *testing.T) {
+	// Arrange
+	s := NewSyncStack[int]()
+	var wg sync.WaitGroup
+
+	// Act
+	for i := range 100 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			s.Push(i)
+		}()
+	}
+	wg.Wait()
+	popped := 0
+	for {
+		top := s.Pop()
+		if _, ok := top.Value(); !ok {
+			break
+		}
+		popped++
+	}
+
+	// Assert
+	if popped != 100 {
+		t.Errorf("expected 100 values, got %d", popped)
+	}
+}
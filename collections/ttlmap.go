@@ -0,0 +1,237 @@
+package collections
+
+import (
+	"sync"
+	"time"
+
+	"github.com/zodimo/go-zbase-std/clock"
+	"github.com/zodimo/go-zbase-std/optional"
+)
+
+// DefaultJanitorInterval is how often a TTLMap removes expired entries in
+// the background unless WithJanitorInterval says otherwise.
+const DefaultJanitorInterval = time.Minute
+
+// TTLMap is a concurrency-safe map whose entries expire after a time to
+// live. Expired entries are never returned: Get removes them when it finds
+// them, and a background janitor removes the rest every janitor interval so
+// that keys which are never looked up again do not accumulate. Call Close to
+// stop the janitor once the map is no longer needed.
+type TTLMap[K comparable, V any] struct {
+	mu      sync.Mutex
+	entries map[K]ttlEntry[V]
+
+	ttl       time.Duration
+	interval  time.Duration
+	clock     clock.Clock
+	onExpire  func(key K, value V)
+	done      chan struct{} // Closed by Close to stop the janitor.
+	closeOnce sync.Once
+}
+
+// ttlEntry is a stored value and its expiry.
+type ttlEntry[V any] struct {
+	value     V
+	expiresAt time.Time // Zero if the entry does not expire.
+}
+
+// TTLMapOption configures a TTLMap created by NewTTLMap.
+type TTLMapOption[K comparable, V any] func(*TTLMap[K, V])
+
+// WithTTLClock makes the map read time, and schedule its janitor, from the
+// given clock instead of the system clock.
+func WithTTLClock[K comparable, V any](c clock.Clock) TTLMapOption[K, V] {
+	return func(m *TTLMap[K, V]) {
+		m.clock = c
+	}
+}
+
+// WithJanitorInterval sets how often expired entries are removed in the
+// background. Values below 1 disable the janitor, leaving expired entries in
+// place until they are looked up or DeleteExpired is called.
+func WithJanitorInterval[K comparable, V any](d time.Duration) TTLMapOption[K, V] {
+	return func(m *TTLMap[K, V]) {
+		m.interval = d
+	}
+}
+
+// WithOnExpire calls fn after an entry has been removed because it expired.
+// It runs in the goroutine that removed the entry, outside the map's lock;
+// entries removed by Delete or replaced by Set do not call fn.
+func WithOnExpire[K comparable, V any](fn func(key K, value V)) TTLMapOption[K, V] {
+	return func(m *TTLMap[K, V]) {
+		m.onExpire = fn
+	}
+}
+
+// NewTTLMap creates an empty map and starts its janitor.
+//
+// Parameters:
+//   - ttl: The time to live of entries stored by Set. Values below 1 keep
+//     such entries until they are deleted.
+//   - opts: Options configuring the clock, janitor and expiry callback.
+//
+// Returns:
+//   - *TTLMap[K, V]: The new map.
+//
+// Example:
+//
+//	sessions := collections.NewTTLMap(30*time.Minute,
+//		collections.WithOnExpire(func(id string, s Session) { s.Logout() }))
+//	defer sessions.Close()
+//	sessions.Set(id, session)
+//	current := sessions.Get(id)
+func NewTTLMap[K comparable, V any](ttl time.Duration, opts ...TTLMapOption[K, V]) *TTLMap[K, V] {
+	m := &TTLMap[K, V]{
+		entries:  make(map[K]ttlEntry[V]),
+		ttl:      ttl,
+		interval: DefaultJanitorInterval,
+		clock:    clock.System(),
+		done:     make(chan struct{}),
+	}
+	for _, opt := range opts {
+		opt(m)
+	}
+	if m.interval > 0 {
+		// Create the ticker before returning so that time advanced on a fake
+		// clock right after construction is already observed.
+		go m.janitor(m.clock.NewTicker(m.interval))
+	}
+	return m
+}
+
+// Get returns the value stored under key, or None if the key is absent or
+// its entry has expired.
+func (m *TTLMap[K, V]) Get(key K) optional.Option[V] {
+	m.mu.Lock()
+	e, ok := m.entries[key]
+	if !ok {
+		m.mu.Unlock()
+		return optional.None[V]()
+	}
+	if m.expired(e, m.clock.Now()) {
+		delete(m.entries, key)
+		m.mu.Unlock()
+		if m.onExpire != nil {
+			m.onExpire(key, e.value)
+		}
+		return optional.None[V]()
+	}
+	m.mu.Unlock()
+	return optional.Some(e.value)
+}
+
+// Set stores value under key with the map's default time to live, replacing
+// any existing entry.
+func (m *TTLMap[K, V]) Set(key K, value V) {
+	m.SetWithTTL(key, value, m.ttl)
+}
+
+// SetWithTTL stores value under key, expiring it after ttl and replacing any
+// existing entry. A ttl below 1 keeps the entry until it is deleted.
+func (m *TTLMap[K, V]) SetWithTTL(key K, value V, ttl time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	e := ttlEntry[V]{value: value}
+	if ttl > 0 {
+		e.expiresAt = m.clock.Now().Add(ttl)
+	}
+	m.entries[key] = e
+}
+
+// Delete removes the entry stored under key and reports whether a live
+// entry was present.
+func (m *TTLMap[K, V]) Delete(key K) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	e, ok := m.entries[key]
+	if !ok {
+		return false
+	}
+	delete(m.entries, key)
+	return !m.expired(e, m.clock.Now())
+}
+
+// DeleteExpired removes every expired entry, calling the expiry callback
+// for each, and returns how many were removed. The janitor calls it every
+// janitor interval.
+func (m *TTLMap[K, V]) DeleteExpired() int {
+	var expired map[K]V
+	defer func() { m.notify(expired) }()
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	now := m.clock.Now()
+	for key, e := range m.entries {
+		if m.expired(e, now) {
+			if expired == nil {
+				expired = make(map[K]V)
+			}
+			expired[key] = e.value
+			delete(m.entries, key)
+		}
+	}
+	return len(expired)
+}
+
+// Range calls fn for each live entry, in no particular order, until fn
+// returns false. It iterates over a snapshot, so fn may modify the map.
+func (m *TTLMap[K, V]) Range(fn func(key K, value V) bool) {
+	m.mu.Lock()
+	now := m.clock.Now()
+	live := make(map[K]V, len(m.entries))
+	for key, e := range m.entries {
+		if !m.expired(e, now) {
+			live[key] = e.value
+		}
+	}
+	m.mu.Unlock()
+	for key, value := range live {
+		if !fn(key, value) {
+			return
+		}
+	}
+}
+
+// Len returns the number of entries, including expired entries that have
+// not been removed yet.
+func (m *TTLMap[K, V]) Len() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return len(m.entries)
+}
+
+// Close stops the janitor. The map remains usable, but expired entries are
+// then only removed by Get and DeleteExpired. Calling Close more than once
+// has no further effect.
+func (m *TTLMap[K, V]) Close() {
+	m.closeOnce.Do(func() { close(m.done) })
+}
+
+// janitor removes expired entries on every tick until Close is called.
+func (m *TTLMap[K, V]) janitor(ticker clock.Ticker) {
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C():
+			m.DeleteExpired()
+		case <-m.done:
+			return
+		}
+	}
+}
+
+// expired reports whether e has outlived its time to live at now.
+func (m *TTLMap[K, V]) expired(e ttlEntry[V], now time.Time) bool {
+	return !e.expiresAt.IsZero() && !now.Before(e.expiresAt)
+}
+
+// notify runs the expiry callback for each expired entry. It must be called
+// without holding m.mu.
+func (m *TTLMap[K, V]) notify(expired map[K]V) {
+	if m.onExpire == nil {
+		return
+	}
+	for key, value := range expired {
+		m.onExpire(key, value)
+	}
+}
@@ -0,0 +1,176 @@
+package collections
+
+import (
+	"testing"
+	"time"
+
+	"github.com/zodimo/go-zbase-std/clock"
+)
+
+func TestTTLMap_GetExpires(t *testing.T) {
+	// Arrange
+	c := clock.NewFakeClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	var expired []string
+	m := NewTTLMap(time.Minute,
+		WithTTLClock[string, int](c),
+		WithJanitorInterval[string, int](0),
+		WithOnExpire(func(key string, value int) { expired = append(expired, key) }))
+	m.Set("a", 1)
+
+	// Act
+	before := m.Get("a")
+	c.Advance(time.Minute)
+	after := m.Get("a")
+
+	// Assert
+	if v, ok := before.Value(); !ok || v != 1 {
+		t.Errorf("expected Some(1) before expiry, got %v, %v", v, ok)
+	}
+	if _, ok := after.Value(); ok {
+		t.Error("expected None after expiry")
+	}
+	if len(expired) != 1 || expired[0] != "a" {
+		t.Errorf("expected expiry callback for [a], got %v", expired)
+	}
+	if m.Len() != 0 {
+		t.Errorf("expected Len 0, got %d", m.Len())
+	}
+}
+
+func TestTTLMap_SetWithTTL(t *testing.T) {
+	// Arrange
+	c := clock.NewFakeClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	m := NewTTLMap(time.Minute, WithTTLClock[string, int](c), WithJanitorInterval[string, int](0))
+	m.SetWithTTL("short", 1, time.Second)
+	m.SetWithTTL("forever", 2, 0)
+	m.Set("default", 3)
+
+	// Act
+	c.Advance(time.Second)
+	short, forever, def := m.Get("short"), m.Get("forever"), m.Get("default")
+	c.Advance(time.Hour)
+	later := m.Get("forever")
+
+	// Assert
+	if _, ok := short.Value(); ok {
+		t.Error("expected the short entry to have expired")
+	}
+	if _, ok := forever.Value(); !ok {
+		t.Error("expected the entry without a TTL to be present")
+	}
+	if _, ok := def.Value(); !ok {
+		t.Error("expected the default-TTL entry to be present")
+	}
+	if _, ok := later.Value(); !ok {
+		t.Error("expected the entry without a TTL to never expire")
+	}
+}
+
+func TestTTLMap_DeleteAndRange(t *testing.T) {
+	// Arrange
+	c := clock.NewFakeClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	m := NewTTLMap(time.Minute, WithTTLClock[string, int](c), WithJanitorInterval[string, int](0))
+	m.Set("a", 1)
+	m.Set("b", 2)
+	m.SetWithTTL("stale", 3, time.Second)
+	c.Advance(time.Second)
+
+	// Act
+	deleted := m.Delete("a")
+	deletedStale := m.Delete("stale")
+	deletedAbsent := m.Delete("missing")
+	seen := map[string]int{}
+	m.Range(func(key string, value int) bool {
+		seen[key] = value
+		return true
+	})
+
+	// Assert
+	if !deleted || deletedStale || deletedAbsent {
+		t.Errorf("expected deletes true, false, false, got %v, %v, %v", deleted, deletedStale, deletedAbsent)
+	}
+	if len(seen) != 1 || seen["b"] != 2 {
+		t.Errorf("expected Range to visit only b, got %v", seen)
+	}
+}
+
+func TestTTLMap_DeleteExpired(t *testing.T) {
+	// Arrange
+	c := clock.NewFakeClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	expired := map[string]int{}
+	m := NewTTLMap(time.Minute,
+		WithTTLClock[string, int](c),
+		WithJanitorInterval[string, int](0),
+		WithOnExpire(func(key string, value int) { expired[key] = value }))
+	m.Set("a", 1)
+	m.Set("b", 2)
+	m.SetWithTTL("c", 3, time.Hour)
+
+	// Act
+	c.Advance(time.Minute)
+	removed := m.DeleteExpired()
+
+	// Assert
+	if removed != 2 {
+		t.Errorf("expected 2 removed, got %d", removed)
+	}
+	if len(expired) != 2 || expired["a"] != 1 || expired["b"] != 2 {
+		t.Errorf("expected expiry callbacks for a and b, got %v", expired)
+	}
+	if m.Len() != 1 {
+		t.Errorf("expected Len 1, got %d", m.Len())
+	}
+}
+
+func TestTTLMap_Janitor(t *testing.T) {
+	// Arrange
+	c := clock.NewFakeClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	expired := make(chan string, 1)
+	m := NewTTLMap(time.Minute,
+		WithTTLClock[string, int](c),
+		WithJanitorInterval[string, int](time.Minute),
+		WithOnExpire(func(key string, value int) { expired <- key }))
+	defer m.Close()
+	m.Set("a", 1)
+
+	// Act
+	c.Advance(time.Minute)
+
+	// Assert
+	select {
+	case key := <-expired:
+		if key != "a" {
+			t.Errorf("expected a, got %s", key)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("expected the janitor to remove the expired entry")
+	}
+	if m.Len() != 0 {
+		t.Errorf("expected Len 0, got %d", m.Len())
+	}
+}
+
+func TestTTLMap_CloseStopsJanitor(t *testing.T) {
+	// Arrange
+	c := clock.NewFakeClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	m := NewTTLMap(time.Minute, WithTTLClock[string, int](c), WithJanitorInterval[string, int](time.Minute))
+	m.Set("a", 1)
+
+	// Act
+	m.Close()
+	m.Close()
+	deadline := time.Now().Add(time.Second)
+	for c.Pending() > 0 && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	c.Advance(time.Minute)
+	time.Sleep(10 * time.Millisecond)
+
+	// Assert
+	if c.Pending() != 0 {
+		t.Errorf("expected the janitor's ticker to be stopped, got %d pending", c.Pending())
+	}
+	if m.Len() != 1 {
+		t.Errorf("expected the expired entry to remain after Close, got Len %d", m.Len())
+	}
+}
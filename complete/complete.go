@@ -3,6 +3,8 @@ package complete
 //@see https://github.com/AngusGMorrison/typedd-gophers-talk
 import (
 	"fmt"
+
+	"github.com/zodimo/go-zbase-std/multierr"
 )
 
 // Complete types require that all of their Complete fields are complete.
@@ -32,3 +34,18 @@ func ValidateCompleteness(maybeComplete ...Complete) error {
 
 	return nil
 }
+
+// ValidateAllCompleteness is the aggregate form of [ValidateCompleteness]: it
+// checks every given [Complete] type and returns a [multierr.Error] holding
+// an [IncompleteTypeError] for each incomplete one, or nil if all are
+// complete.
+func ValidateAllCompleteness(maybeComplete ...Complete) error {
+	var errs multierr.Error
+	for _, mc := range maybeComplete {
+		if !mc.Complete() {
+			errs.Append(&IncompleteTypeError{Incomplete: mc})
+		}
+	}
+
+	return errs.ErrorOrNil()
+}
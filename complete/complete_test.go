@@ -3,6 +3,8 @@ package complete
 import (
 	"errors"
 	"testing"
+
+	"github.com/zodimo/go-zbase-std/multierr"
 )
 
 // Mock implementation of the Complete interface
@@ -62,3 +64,40 @@ func TestIncompleteTypeError_ErrorMethod(t *testing.T) {
 		t.Errorf("Error() = %q; want %q", got, expected)
 	}
 }
+
+func TestValidateAllCompleteness_AllComplete(t *testing.T) {
+	// Arrange
+	c1 := MockComplete{isComplete: true}
+	c2 := MockComplete{isComplete: true}
+
+	// Act
+	err := ValidateAllCompleteness(c1, c2)
+
+	// Assert
+	if err != nil {
+		t.Errorf("expected no error, but got: %v", err)
+	}
+}
+
+func TestValidateAllCompleteness_ReportsEveryIncomplete(t *testing.T) {
+	// Arrange
+	c1 := MockComplete{isComplete: false}
+	c2 := MockComplete{isComplete: true}
+	c3 := MockComplete{isComplete: false}
+
+	// Act
+	err := ValidateAllCompleteness(c1, c2, c3)
+
+	// Assert
+	var errs *multierr.Error
+	if !errors.As(err, &errs) {
+		t.Fatalf("expected error of type *multierr.Error, but got: %T", err)
+	}
+	if errs.Len() != 2 {
+		t.Errorf("expected 2 incomplete values, but got: %d", errs.Len())
+	}
+	var incompleteError *IncompleteTypeError
+	if !errors.As(err, &incompleteError) {
+		t.Errorf("expected a member of type *IncompleteTypeError, but got: %v", err)
+	}
+}
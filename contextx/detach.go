@@ -0,0 +1,48 @@
+package contextx
+
+import (
+	"context"
+	"time"
+)
+
+// Detach returns a context that carries the values of ctx but is never
+// cancelled and has no deadline, for background work spawned from a
+// request, such as audit logging or cache refreshes, that must outlive the
+// request without losing its trace or tenant values.
+//
+// Parameters:
+//   - ctx: The context whose values are kept.
+//
+// Returns:
+//   - context.Context: The detached context.
+//
+// Example:
+//
+//	go auditLog(contextx.Detach(r.Context()), event)
+func Detach(ctx context.Context) context.Context {
+	return context.WithoutCancel(ctx)
+}
+
+// DetachWithTimeout returns a context that carries the values of ctx but
+// not its cancellation or deadline, bounded by its own timeout instead, so
+// that detached work cannot run forever. The returned cancel function must
+// be called once the work is done.
+//
+// Parameters:
+//   - ctx: The context whose values are kept.
+//   - timeout: How long the detached context lives.
+//
+// Returns:
+//   - context.Context: The detached context.
+//   - context.CancelFunc: Cancels the detached context.
+//
+// Example:
+//
+//	go func() {
+//		ctx, cancel := contextx.DetachWithTimeout(r.Context(), 30*time.Second)
+//		defer cancel()
+//		refreshCache(ctx, key)
+//	}()
+func DetachWithTimeout(ctx context.Context, timeout time.Duration) (context.Context, context.CancelFunc) {
+	return context.WithTimeout(Detach(ctx), timeout)
+}
@@ -0,0 +1,50 @@
+package contextx
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestDetach_KeepsValuesDropsCancellation(t *testing.T) {
+	// Arrange
+	trace := NewKey[string]("trace")
+	parent, cancel := context.WithTimeout(trace.WithValue(context.Background(), "abc"), time.Hour)
+
+	// Act
+	detached := Detach(parent)
+	cancel()
+
+	// Assert
+	if detached.Err() != nil {
+		t.Errorf("expected the detached context to outlive its parent, got %v", detached.Err())
+	}
+	if _, ok := detached.Deadline(); ok {
+		t.Error("expected the detached context to have no deadline")
+	}
+	value := trace.From(detached)
+	if v, _ := value.Value(); v != "abc" {
+		t.Errorf("expected the parent's value abc, got %q", v)
+	}
+}
+
+func TestDetachWithTimeout(t *testing.T) {
+	// Arrange
+	parent, cancelParent := context.WithCancel(context.Background())
+	ctx, cancel := DetachWithTimeout(parent, 20*time.Millisecond)
+	defer cancel()
+
+	// Act
+	cancelParent()
+	parentErr := ctx.Err()
+	<-ctx.Done()
+
+	// Assert
+	if parentErr != nil {
+		t.Errorf("expected the parent's cancellation to be ignored, got %v", parentErr)
+	}
+	if !errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		t.Errorf("expected context.DeadlineExceeded, got %v", ctx.Err())
+	}
+}
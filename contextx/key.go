@@ -0,0 +1,54 @@
+// Package contextx provides typed context keys and helpers for combining
+// and detaching contexts.
+package contextx
+
+import (
+	"context"
+
+	"github.com/zodimo/go-zbase-std/optional"
+)
+
+// Key is a typed context key. Each Key created by NewKey is distinct from
+// every other key, including keys with the same name, so packages can
+// declare keys without defining unexported key types.
+type Key[T any] struct {
+	name string
+}
+
+// NewKey creates a context key for values of type T.
+//
+// Parameters:
+//   - name: Describes the key in String output; it does not need to be
+//     unique.
+//
+// Returns:
+//   - *Key[T]: The new key.
+//
+// Example:
+//
+//	var TenantKey = contextx.NewKey[string]("tenant")
+//
+//	ctx = TenantKey.WithValue(ctx, "acme")
+//	tenant := TenantKey.From(ctx)
+func NewKey[T any](name string) *Key[T] {
+	return &Key[T]{name: name}
+}
+
+// WithValue returns a copy of ctx in which k is associated with value.
+func (k *Key[T]) WithValue(ctx context.Context, value T) context.Context {
+	return context.WithValue(ctx, k, value)
+}
+
+// From returns the value associated with k in ctx, or None if there is
+// none.
+func (k *Key[T]) From(ctx context.Context) optional.Option[T] {
+	if value, ok := ctx.Value(k).(T); ok {
+		return optional.Some(value)
+	}
+	return optional.None[T]()
+}
+
+// String returns the name of the key.
+func (k *Key[T]) String() string {
+	return "contextx.Key(" + k.name + ")"
+}
@@ -0,0 +1,66 @@
+package contextx
+
+import (
+	"context"
+	"testing"
+)
+
+func TestKey_WithValueAndFrom(t *testing.T) {
+	// Arrange
+	key := NewKey[string]("tenant")
+	ctx := key.WithValue(context.Background(), "acme")
+
+	// Act
+	present := key.From(ctx)
+	absent := key.From(context.Background())
+
+	// Assert
+	if v, ok := present.Value(); !ok || v != "acme" {
+		t.Errorf("expected Some(acme), got %v, %v", v, ok)
+	}
+	if _, ok := absent.Value(); ok {
+		t.Error("expected None from a context without the key")
+	}
+}
+
+func TestKey_DistinctKeysWithSameName(t *testing.T) {
+	// Arrange
+	first := NewKey[int]("id")
+	second := NewKey[int]("id")
+	ctx := first.WithValue(context.Background(), 1)
+
+	// Act
+	fromSecond := second.From(ctx)
+
+	// Assert
+	if _, ok := fromSecond.Value(); ok {
+		t.Error("expected keys with the same name not to collide")
+	}
+}
+
+func TestKey_InterfaceTypeWithNil(t *testing.T) {
+	// Arrange
+	key := NewKey[error]("err")
+	ctx := key.WithValue(context.Background(), nil)
+
+	// Act
+	value := key.From(ctx)
+
+	// Assert
+	if _, ok := value.Value(); ok {
+		t.Error("expected None for a nil interface value")
+	}
+}
+
+func TestKey_String(t *testing.T) {
+	// Arrange
+	key := NewKey[bool]("debug")
+
+	// Act
+	name := key.String()
+
+	// Assert
+	if name != "contextx.Key(debug)" {
+		t.Errorf("expected contextx.Key(debug), got %q", name)
+	}
+}
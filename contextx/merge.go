@@ -0,0 +1,64 @@
+package contextx
+
+import (
+	"context"
+	"errors"
+)
+
+// mergedContext takes its cancellation from its embedded context and
+// falls back to secondary for values.
+type mergedContext struct {
+	context.Context
+	secondary context.Context
+}
+
+// Value returns the value for key from the primary parent, or from the
+// secondary parent if the primary has none.
+func (c mergedContext) Value(key any) any {
+	if value := c.Context.Value(key); value != nil {
+		return value
+	}
+	return c.secondary.Value(key)
+}
+
+// Merge returns a context that is done as soon as either a or b is done,
+// whose deadline is the earlier of theirs, and which carries the values of
+// both, preferring those of a. context.Cause on the merged context reports
+// the cause of whichever parent ended it. The returned cancel function
+// releases the resources of the merged context and must be called once it
+// is no longer needed.
+//
+// Parameters:
+//   - a: The primary parent, consulted first for values.
+//   - b: The secondary parent.
+//
+// Returns:
+//   - context.Context: The merged context.
+//   - context.CancelFunc: Cancels the merged context.
+//
+// Example:
+//
+//	// Stop handling the request if either the client goes away or the
+//	// server starts shutting down.
+//	ctx, cancel := contextx.Merge(r.Context(), shutdownCtx)
+//	defer cancel()
+func Merge(a, b context.Context) (context.Context, context.CancelFunc) {
+	ctx, cancelCause := context.WithCancelCause(a)
+	cancelDeadline := context.CancelFunc(func() {})
+	if deadline, ok := b.Deadline(); ok {
+		ctx, cancelDeadline = context.WithDeadline(ctx, deadline)
+	}
+	stop := context.AfterFunc(b, func() {
+		// A deadline of b is also a deadline of ctx, which then reports
+		// context.DeadlineExceeded itself.
+		if !errors.Is(b.Err(), context.DeadlineExceeded) {
+			cancelCause(context.Cause(b))
+		}
+	})
+	cancel := func() {
+		stop()
+		cancelDeadline()
+		cancelCause(context.Canceled)
+	}
+	return mergedContext{Context: ctx, secondary: b}, cancel
+}
@@ -0,0 +1,98 @@
+package contextx
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestMerge_DoneWhenEitherParentIsDone(t *testing.T) {
+	for _, cancelFirst := range []bool{true, false} {
+		// Arrange
+		a, cancelA := context.WithCancel(context.Background())
+		b, cancelB := context.WithCancelCause(context.Background())
+		ctx, cancel := Merge(a, b)
+
+		// Act
+		if cancelFirst {
+			cancelA()
+		} else {
+			cancelB(errors.New("shutting down"))
+		}
+
+		// Assert
+		select {
+		case <-ctx.Done():
+		case <-time.After(time.Second):
+			t.Fatalf("expected the merged context to be done (cancelFirst=%v)", cancelFirst)
+		}
+		if !errors.Is(ctx.Err(), context.Canceled) {
+			t.Errorf("expected context.Canceled, got %v", ctx.Err())
+		}
+		if !cancelFirst && context.Cause(ctx).Error() != "shutting down" {
+			t.Errorf("expected the cause of b, got %v", context.Cause(ctx))
+		}
+		cancel()
+		cancelA()
+		cancelB(nil)
+	}
+}
+
+func TestMerge_EarliestDeadline(t *testing.T) {
+	// Arrange
+	soon := time.Now().Add(20 * time.Millisecond)
+	a, cancelA := context.WithTimeout(context.Background(), time.Hour)
+	defer cancelA()
+	b, cancelB := context.WithDeadline(context.Background(), soon)
+	defer cancelB()
+
+	// Act
+	ctx, cancel := Merge(a, b)
+	defer cancel()
+	deadline, ok := ctx.Deadline()
+	<-ctx.Done()
+
+	// Assert
+	if !ok || !deadline.Equal(soon) {
+		t.Errorf("expected deadline %v, got %v, %v", soon, deadline, ok)
+	}
+	if !errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		t.Errorf("expected context.DeadlineExceeded, got %v", ctx.Err())
+	}
+}
+
+func TestMerge_ValuesPreferFirst(t *testing.T) {
+	// Arrange
+	shared := NewKey[string]("shared")
+	onlyB := NewKey[int]("onlyB")
+	a := shared.WithValue(context.Background(), "from a")
+	b := onlyB.WithValue(shared.WithValue(context.Background(), "from b"), 7)
+
+	// Act
+	ctx, cancel := Merge(a, b)
+	defer cancel()
+	sharedValue := shared.From(ctx)
+	bValue := onlyB.From(ctx)
+
+	// Assert
+	if v, _ := sharedValue.Value(); v != "from a" {
+		t.Errorf("expected the value from a, got %q", v)
+	}
+	if v, ok := bValue.Value(); !ok || v != 7 {
+		t.Errorf("expected the value from b, got %v, %v", v, ok)
+	}
+}
+
+func TestMerge_CancelReleases(t *testing.T) {
+	// Arrange
+	ctx, cancel := Merge(context.Background(), context.Background())
+
+	// Act
+	cancel()
+
+	// Assert
+	if !errors.Is(ctx.Err(), context.Canceled) {
+		t.Errorf("expected context.Canceled after cancel, got %v", ctx.Err())
+	}
+}
@@ -0,0 +1,156 @@
+// Package diff computes typed change sets between two versions of a slice
+// or map, for reconciliation loops that must create, delete and update
+// resources to move from an observed state to a desired one.
+package diff
+
+// Modified is an element present in both versions whose value changed.
+type Modified[T any] struct {
+	Old T
+	New T
+}
+
+// Changes describes how a slice changed. Elements are matched between the
+// old and new slices by key.
+type Changes[T any] struct {
+	// Added holds elements whose key appears only in the new slice, in new
+	// slice order.
+	Added []T
+
+	// Removed holds elements whose key appears only in the old slice, in
+	// old slice order.
+	Removed []T
+
+	// Modified holds elements whose key appears in both slices but whose
+	// values differ, in new slice order.
+	Modified []Modified[T]
+}
+
+// IsEmpty reports whether nothing changed.
+func (c Changes[T]) IsEmpty() bool {
+	return len(c.Added) == 0 && len(c.Removed) == 0 && len(c.Modified) == 0
+}
+
+// MapChanges describes how a map changed.
+type MapChanges[K comparable, V any] struct {
+	// Added holds entries whose key appears only in the new map.
+	Added map[K]V
+
+	// Removed holds entries whose key appears only in the old map.
+	Removed map[K]V
+
+	// Modified holds entries whose key appears in both maps but whose
+	// values differ.
+	Modified map[K]Modified[V]
+}
+
+// IsEmpty reports whether nothing changed.
+func (c MapChanges[K, V]) IsEmpty() bool {
+	return len(c.Added) == 0 && len(c.Removed) == 0 && len(c.Modified) == 0
+}
+
+// Slices compares two slices whose elements are identified by key and
+// compared with ==. Keys should be unique within each slice; if a key
+// repeats, only its last occurrence is considered.
+//
+// Parameters:
+//   - old: The previous version.
+//   - current: The current version.
+//   - key: Returns the identity of an element.
+//
+// Returns:
+//   - Changes[T]: The elements added, removed and modified.
+//
+// Example:
+//
+//	changes := diff.Slices(observed, desired, func(r Route) string { return r.Path })
+//	for _, r := range changes.Added {
+//		router.Add(r)
+//	}
+func Slices[T comparable, K comparable](old, current []T, key func(T) K) Changes[T] {
+	return SlicesFunc(old, current, key, func(a, b T) bool { return a == b })
+}
+
+// SlicesFunc is like Slices but compares elements with equal, for element
+// types that are not comparable or whose equality is looser than ==.
+func SlicesFunc[T any, K comparable](old, current []T, key func(T) K, equal func(a, b T) bool) Changes[T] {
+	before := lastByKey(old, key)
+	after := lastByKey(current, key)
+	var changes Changes[T]
+	for i, v := range current {
+		k := key(v)
+		if after[k] != i {
+			continue // A later occurrence of k is the one considered.
+		}
+		j, ok := before[k]
+		switch {
+		case !ok:
+			changes.Added = append(changes.Added, v)
+		case !equal(old[j], v):
+			changes.Modified = append(changes.Modified, Modified[T]{Old: old[j], New: v})
+		}
+	}
+	for i, v := range old {
+		k := key(v)
+		if before[k] != i {
+			continue
+		}
+		if _, ok := after[k]; !ok {
+			changes.Removed = append(changes.Removed, v)
+		}
+	}
+	return changes
+}
+
+// Maps compares two maps whose values are compared with ==.
+//
+// Parameters:
+//   - old: The previous version.
+//   - current: The current version.
+//
+// Returns:
+//   - MapChanges[K, V]: The entries added, removed and modified. Each map
+//     is non-nil, even when empty.
+//
+// Example:
+//
+//	changes := diff.Maps(currentLabels, desiredLabels)
+//	for k := range changes.Removed {
+//		node.RemoveLabel(k)
+//	}
+func Maps[K comparable, V comparable](old, current map[K]V) MapChanges[K, V] {
+	return MapsFunc(old, current, func(a, b V) bool { return a == b })
+}
+
+// MapsFunc is like Maps but compares values with equal, for value types
+// that are not comparable or whose equality is looser than ==.
+func MapsFunc[K comparable, V any](old, current map[K]V, equal func(a, b V) bool) MapChanges[K, V] {
+	changes := MapChanges[K, V]{
+		Added:    make(map[K]V),
+		Removed:  make(map[K]V),
+		Modified: make(map[K]Modified[V]),
+	}
+	for k, v := range current {
+		prev, ok := old[k]
+		switch {
+		case !ok:
+			changes.Added[k] = v
+		case !equal(prev, v):
+			changes.Modified[k] = Modified[V]{Old: prev, New: v}
+		}
+	}
+	for k, v := range old {
+		if _, ok := current[k]; !ok {
+			changes.Removed[k] = v
+		}
+	}
+	return changes
+}
+
+// lastByKey maps each key to the index of its last occurrence in s.
+func lastByKey[T any, K comparable](s []T, key func(T) K) map[K]int {
+	index := make(map[K]int, len(s))
+	for i, v := range s {
+		index[key(v)] = i
+	}
+	return index
+}
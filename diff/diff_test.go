@@ -0,0 +1,132 @@
+package diff
+
+import (
+	"slices"
+	"testing"
+)
+
+type route struct {
+	Path    string
+	Backend string
+}
+
+func routePath(r route) string { return r.Path }
+
+func TestSlices(t *testing.T) {
+	// Arrange
+	old := []route{{"/a", "v1"}, {"/b", "v1"}, {"/c", "v1"}}
+	current := []route{{"/d", "v1"}, {"/b", "v2"}, {"/a", "v1"}}
+
+	// Act
+	changes := Slices(old, current, routePath)
+
+	// Assert
+	if !slices.Equal(changes.Added, []route{{"/d", "v1"}}) {
+		t.Errorf("expected Added [/d], got %v", changes.Added)
+	}
+	if !slices.Equal(changes.Removed, []route{{"/c", "v1"}}) {
+		t.Errorf("expected Removed [/c], got %v", changes.Removed)
+	}
+	expected := []Modified[route]{{Old: route{"/b", "v1"}, New: route{"/b", "v2"}}}
+	if !slices.Equal(changes.Modified, expected) {
+		t.Errorf("expected Modified %v, got %v", expected, changes.Modified)
+	}
+	if changes.IsEmpty() {
+		t.Error("expected IsEmpty false")
+	}
+}
+
+func TestSlices_Unchanged(t *testing.T) {
+	// Arrange
+	routes := []route{{"/a", "v1"}, {"/b", "v1"}}
+
+	// Act
+	changes := Slices(routes, slices.Clone(routes), routePath)
+
+	// Assert
+	if !changes.IsEmpty() {
+		t.Errorf("expected no changes, got %+v", changes)
+	}
+}
+
+func TestSlices_RepeatedKeysUseLastOccurrence(t *testing.T) {
+	// Arrange
+	old := []route{{"/a", "v1"}, {"/a", "v2"}}
+	current := []route{{"/a", "v3"}, {"/a", "v2"}, {"/b", "v1"}, {"/b", "v2"}}
+
+	// Act
+	changes := Slices(old, current, routePath)
+
+	// Assert
+	if !slices.Equal(changes.Added, []route{{"/b", "v2"}}) {
+		t.Errorf("expected Added [/b v2], got %v", changes.Added)
+	}
+	if len(changes.Removed) != 0 || len(changes.Modified) != 0 {
+		t.Errorf("expected no removals or modifications, got %v and %v", changes.Removed, changes.Modified)
+	}
+}
+
+func TestSlicesFunc(t *testing.T) {
+	// Arrange
+	old := [][]string{{"a", "x"}, {"b", "y"}}
+	current := [][]string{{"a", "x"}, {"b", "z"}}
+	first := func(s []string) string { return s[0] }
+
+	// Act
+	changes := SlicesFunc(old, current, first, slices.Equal[[]string])
+
+	// Assert
+	if len(changes.Added) != 0 || len(changes.Removed) != 0 {
+		t.Errorf("expected no additions or removals, got %v and %v", changes.Added, changes.Removed)
+	}
+	if len(changes.Modified) != 1 || changes.Modified[0].New[1] != "z" {
+		t.Errorf("expected b to be modified, got %v", changes.Modified)
+	}
+}
+
+func TestMaps(t *testing.T) {
+	// Arrange
+	old := map[string]int{"a": 1, "b": 2, "c": 3}
+	current := map[string]int{"a": 1, "b": 20, "d": 4}
+
+	// Act
+	changes := Maps(old, current)
+
+	// Assert
+	if len(changes.Added) != 1 || changes.Added["d"] != 4 {
+		t.Errorf("expected Added {d: 4}, got %v", changes.Added)
+	}
+	if len(changes.Removed) != 1 || changes.Removed["c"] != 3 {
+		t.Errorf("expected Removed {c: 3}, got %v", changes.Removed)
+	}
+	if len(changes.Modified) != 1 || changes.Modified["b"] != (Modified[int]{Old: 2, New: 20}) {
+		t.Errorf("expected Modified {b: 2 -> 20}, got %v", changes.Modified)
+	}
+}
+
+func TestMaps_NilInputs(t *testing.T) {
+	// Act
+	changes := Maps[string, int](nil, nil)
+
+	// Assert
+	if !changes.IsEmpty() {
+		t.Errorf("expected no changes, got %+v", changes)
+	}
+	if changes.Added == nil || changes.Removed == nil || changes.Modified == nil {
+		t.Error("expected non-nil change maps")
+	}
+}
+
+func TestMapsFunc(t *testing.T) {
+	// Arrange
+	old := map[string][]int{"a": {1, 2}}
+	current := map[string][]int{"a": {1, 2, 3}}
+
+	// Act
+	changes := MapsFunc(old, current, slices.Equal[[]int])
+
+	// Assert
+	if len(changes.Modified) != 1 {
+		t.Errorf("expected a to be modified, got %v", changes.Modified)
+	}
+}
@@ -0,0 +1,144 @@
+// Package either provides a generic Either type holding exactly one of two
+// values, for outcomes with two meaningful branches where the other branch
+// is not an error.
+package either
+
+import (
+	"github.com/zodimo/go-zbase-std/optional"
+	"github.com/zodimo/go-zbase-std/result"
+)
+
+// Either represents a value of type L or a value of type R. By convention
+// Right holds the primary outcome and Left the alternative one.
+type Either[L, R any] struct {
+	left    L    // The left value, set when isRight is false.
+	right   R    // The right value, set when isRight is true.
+	isRight bool // Indicates which of the two values is held.
+}
+
+// Left initializes an Either holding a left value.
+//
+// Example:
+//
+//	cached := Left[Entry, Request](entry)
+func Left[L, R any](value L) Either[L, R] {
+	return Either[L, R]{left: value}
+}
+
+// Right initializes an Either holding a right value.
+//
+// Example:
+//
+//	fetch := Right[Entry](request)
+func Right[L, R any](value R) Either[L, R] {
+	return Either[L, R]{right: value, isRight: true}
+}
+
+// IsLeft reports whether the Either holds a left value.
+func (e Either[L, R]) IsLeft() bool {
+	return !e.isRight
+}
+
+// IsRight reports whether the Either holds a right value.
+func (e Either[L, R]) IsRight() bool {
+	return e.isRight
+}
+
+// Left returns the left value as an Option, which is None if the Either
+// holds a right value.
+func (e Either[L, R]) Left() optional.Option[L] {
+	if e.isRight {
+		return optional.None[L]()
+	}
+	return optional.Some(e.left)
+}
+
+// Right returns the right value as an Option, which is None if the Either
+// holds a left value.
+func (e Either[L, R]) Right() optional.Option[R] {
+	if !e.isRight {
+		return optional.None[R]()
+	}
+	return optional.Some(e.right)
+}
+
+// Fold reduces an Either to a single value by applying onLeft or onRight
+// to whichever value it holds.
+//
+// Parameters:
+//   - e: The Either to reduce.
+//   - onLeft: The function applied to a left value.
+//   - onRight: The function applied to a right value.
+//
+// Returns:
+//   - T: The result of the applied function.
+//
+// Example:
+//
+//	label := Fold(e, func(n int) string { return strconv.Itoa(n) }, strings.ToUpper)
+func Fold[L, R, T any](e Either[L, R], onLeft func(L) T, onRight func(R) T) T {
+	if e.isRight {
+		return onRight(e.right)
+	}
+	return onLeft(e.left)
+}
+
+// MapLeft applies fn to a left value. A right value is passed through.
+//
+// Parameters:
+//   - e: The Either to transform.
+//   - fn: The function applied to a left value.
+//
+// Returns:
+//   - Either[T, R]: The transformed Either.
+func MapLeft[L, R, T any](e Either[L, R], fn func(L) T) Either[T, R] {
+	if e.isRight {
+		return Right[T](e.right)
+	}
+	return Left[T, R](fn(e.left))
+}
+
+// MapRight applies fn to a right value. A left value is passed through.
+//
+// Parameters:
+//   - e: The Either to transform.
+//   - fn: The function applied to a right value.
+//
+// Returns:
+//   - Either[L, T]: The transformed Either.
+func MapRight[L, R, T any](e Either[L, R], fn func(R) T) Either[L, T] {
+	if !e.isRight {
+		return Left[L, T](e.left)
+	}
+	return Right[L](fn(e.right))
+}
+
+// ToResult converts an Either whose left branch is an error into a Result.
+//
+// Parameters:
+//   - e: The Either to convert.
+//
+// Returns:
+//   - result.Result[R]: Ok with the right value, or Err with the left one.
+func ToResult[R any](e Either[error, R]) result.Result[R] {
+	if e.isRight {
+		return result.Ok(e.right)
+	}
+	return result.Err[R](e.left)
+}
+
+// FromResult converts a Result into an Either holding its error on the
+// left or its value on the right.
+//
+// Parameters:
+//   - r: The Result to convert.
+//
+// Returns:
+//   - Either[error, R]: The converted Either.
+func FromResult[R any](r result.Result[R]) Either[error, R] {
+	value, err := r.Tuple()
+	if err != nil {
+		return Left[error, R](err)
+	}
+	return Right[error](value)
+}
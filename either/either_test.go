@@ -0,0 +1,122 @@
+package either
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+
+	"github.com/zodimo/go-zbase-std/result"
+)
+
+func TestLeft(t *testing.T) {
+	// Act
+	e := Left[int, string](7)
+
+	// Assert
+	if !e.IsLeft() || e.IsRight() {
+		t.Error("expected Left to hold a left value")
+	}
+	left := e.Left()
+	if value, some := left.Value(); !some || value != 7 {
+		t.Errorf("expected Left() to be Some(7), got %v, %v", value, some)
+	}
+	right := e.Right()
+	if _, some := right.Value(); some {
+		t.Error("expected Right() to be None for a left value")
+	}
+}
+
+func TestRight(t *testing.T) {
+	// Act
+	e := Right[int]("x")
+
+	// Assert
+	if !e.IsRight() || e.IsLeft() {
+		t.Error("expected Right to hold a right value")
+	}
+	right := e.Right()
+	if value, some := right.Value(); !some || value != "x" {
+		t.Errorf("expected Right() to be Some(x), got %v, %v", value, some)
+	}
+	left := e.Left()
+	if _, some := left.Value(); some {
+		t.Error("expected Left() to be None for a right value")
+	}
+}
+
+func TestFold(t *testing.T) {
+	// Arrange
+	onLeft := func(n int) string { return strconv.Itoa(n) }
+	onRight := func(s string) string { return s + "!" }
+
+	// Act
+	left := Fold(Left[int, string](3), onLeft, onRight)
+	right := Fold(Right[int]("hi"), onLeft, onRight)
+
+	// Assert
+	if left != "3" {
+		t.Errorf("expected 3, got %q", left)
+	}
+	if right != "hi!" {
+		t.Errorf("expected hi!, got %q", right)
+	}
+}
+
+func TestMapLeft(t *testing.T) {
+	// Arrange
+	double := func(n int) int { return n * 2 }
+
+	// Act
+	mapped := MapLeft(Left[int, string](4), double)
+	untouched := MapLeft(Right[int]("abc"), double)
+
+	// Assert
+	left := mapped.Left()
+	if value, some := left.Value(); !some || value != 8 {
+		t.Errorf("expected left 8, got %v, %v", value, some)
+	}
+	right := untouched.Right()
+	if value, some := right.Value(); !some || value != "abc" {
+		t.Errorf("expected right value to pass through, got %v, %v", value, some)
+	}
+}
+
+func TestMapRight(t *testing.T) {
+	// Arrange
+	length := func(s string) int { return len(s) }
+
+	// Act
+	mapped := MapRight(Right[int]("abc"), length)
+	untouched := MapRight(Left[int, string](4), length)
+
+	// Assert
+	right := mapped.Right()
+	if value, some := right.Value(); !some || value != 3 {
+		t.Errorf("expected right 3, got %v, %v", value, some)
+	}
+	left := untouched.Left()
+	if value, some := left.Value(); !some || value != 4 {
+		t.Errorf("expected left value to pass through, got %v, %v", value, some)
+	}
+}
+
+func TestResultConversions(t *testing.T) {
+	// Arrange
+	cause := errors.New("boom")
+
+	// Act
+	ok := ToResult(Right[error](5))
+	failed := ToResult(Left[error, int](cause))
+	roundTrip := FromResult(result.Err[int](cause))
+
+	// Assert
+	if value, err := ok.Value(); err != nil || value != 5 {
+		t.Errorf("expected Ok(5), got %v, %v", value, err)
+	}
+	if _, err := failed.Value(); !errors.Is(err, cause) {
+		t.Errorf("expected Err(%v), got %v", cause, err)
+	}
+	if !roundTrip.IsLeft() {
+		t.Error("expected a failed Result to convert to a left value")
+	}
+}
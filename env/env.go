@@ -0,0 +1,115 @@
+// Package env reads typed configuration from environment variables,
+// returning optional.Option for variables that may be unset and coded
+// errors for variables that are required.
+package env
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/zodimo/go-zbase-std/errcode"
+	"github.com/zodimo/go-zbase-std/optional"
+)
+
+// NotSetError is returned by Require when the variable is not set.
+var NotSetError = errcode.New(errcode.NotFound, "env: variable not set")
+
+// InvalidValueError is returned by Require when the variable cannot be
+// parsed as the requested type.
+var InvalidValueError = errcode.New(errcode.Invalid, "env: invalid value")
+
+// Value lists the types variables can be parsed as. Booleans accept the
+// forms of strconv.ParseBool, durations those of time.ParseDuration, and
+// slices comma-separated elements, with surrounding spaces trimmed.
+type Value interface {
+	string | int | int64 | float64 | bool | time.Duration | []string | []int | []time.Duration
+}
+
+// Get returns the value of the named variable parsed as T, or None if the
+// variable is unset or cannot be parsed. Use Require to tell the two apart.
+//
+// Example:
+//
+//	timeout := env.Get[time.Duration]("HTTP_TIMEOUT")
+func Get[T Value](name string) optional.Option[T] {
+	v, err := Require[T](name)
+	if err != nil {
+		return optional.None[T]()
+	}
+	return optional.Some(v)
+}
+
+// Require returns the value of the named variable parsed as T.
+//
+// Parameters:
+//   - name: The name of the environment variable.
+//
+// Returns:
+//   - T: The parsed value.
+//   - error: NotSetError if the variable is unset; InvalidValueError,
+//     wrapping the parse error, if it cannot be parsed as T.
+//
+// Example:
+//
+//	port, err := env.Require[int]("PORT")
+//	if err != nil {
+//		return err
+//	}
+func Require[T Value](name string) (T, error) {
+	var v T
+	raw, ok := os.LookupEnv(name)
+	if !ok {
+		return v, fmt.Errorf("%w: %s", NotSetError, name)
+	}
+	if err := parse(raw, &v); err != nil {
+		return v, fmt.Errorf("%w: %s: %w", InvalidValueError, name, err)
+	}
+	return v, nil
+}
+
+// parse parses raw into the value dst points to.
+func parse(raw string, dst any) error {
+	var err error
+	switch dst := dst.(type) {
+	case *string:
+		*dst = raw
+	case *int:
+		*dst, err = strconv.Atoi(raw)
+	case *int64:
+		*dst, err = strconv.ParseInt(raw, 10, 64)
+	case *float64:
+		*dst, err = strconv.ParseFloat(raw, 64)
+	case *bool:
+		*dst, err = strconv.ParseBool(raw)
+	case *time.Duration:
+		*dst, err = time.ParseDuration(raw)
+	case *[]string:
+		*dst, err = parseSlice(raw, func(s string) (string, error) { return s, nil })
+	case *[]int:
+		*dst, err = parseSlice(raw, strconv.Atoi)
+	case *[]time.Duration:
+		*dst, err = parseSlice(raw, time.ParseDuration)
+	}
+	return err
+}
+
+// parseSlice parses the comma-separated elements of raw with parseElem. An
+// empty raw value is an empty slice.
+func parseSlice[T any](raw string, parseElem func(string) (T, error)) ([]T, error) {
+	if strings.TrimSpace(raw) == "" {
+		return []T{}, nil
+	}
+	parts := strings.Split(raw, ",")
+	out := make([]T, 0, len(parts))
+	for _, part := range parts {
+		v, err := parseElem(strings.TrimSpace(part))
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, v)
+	}
+	return out, nil
+}
@@ -0,0 +1,95 @@
+package env
+
+import (
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/zodimo/go-zbase-std/errcode"
+)
+
+func TestGet(t *testing.T) {
+	// Arrange
+	t.Setenv("ENV_TEST_NAME", "api")
+	t.Setenv("ENV_TEST_PORT", "8080")
+	t.Setenv("ENV_TEST_DEBUG", "true")
+	t.Setenv("ENV_TEST_TIMEOUT", "1m30s")
+	t.Setenv("ENV_TEST_HOSTS", "a, b ,c")
+
+	// Act
+	name := Get[string]("ENV_TEST_NAME")
+	port := Get[int]("ENV_TEST_PORT")
+	debug := Get[bool]("ENV_TEST_DEBUG")
+	timeout := Get[time.Duration]("ENV_TEST_TIMEOUT")
+	hosts := Get[[]string]("ENV_TEST_HOSTS")
+
+	// Assert
+	if v, _ := name.Value(); v != "api" {
+		t.Errorf("expected api, got %q", v)
+	}
+	if v, _ := port.Value(); v != 8080 {
+		t.Errorf("expected 8080, got %d", v)
+	}
+	if v, _ := debug.Value(); !v {
+		t.Error("expected true")
+	}
+	if v, _ := timeout.Value(); v != 90*time.Second {
+		t.Errorf("expected 1m30s, got %v", v)
+	}
+	if v, _ := hosts.Value(); !slices.Equal(v, []string{"a", "b", "c"}) {
+		t.Errorf("expected [a b c], got %v", v)
+	}
+}
+
+func TestGet_UnsetOrInvalid(t *testing.T) {
+	// Arrange
+	t.Setenv("ENV_TEST_PORT", "eighty")
+
+	// Act
+	unset := Get[int]("ENV_TEST_UNSET")
+	invalid := Get[int]("ENV_TEST_PORT")
+
+	// Assert
+	if _, ok := unset.Value(); ok {
+		t.Error("expected None for an unset variable")
+	}
+	if _, ok := invalid.Value(); ok {
+		t.Error("expected None for an invalid value")
+	}
+}
+
+func TestRequire(t *testing.T) {
+	// Arrange
+	t.Setenv("ENV_TEST_PORTS", "80, 443")
+	t.Setenv("ENV_TEST_BAD_PORTS", "80,https")
+
+	// Act
+	ports, err := Require[[]int]("ENV_TEST_PORTS")
+	_, unsetErr := Require[int]("ENV_TEST_UNSET")
+	_, invalidErr := Require[[]int]("ENV_TEST_BAD_PORTS")
+
+	// Assert
+	if err != nil || !slices.Equal(ports, []int{80, 443}) {
+		t.Errorf("expected [80 443], got %v, %v", ports, err)
+	}
+	if !errors.Is(unsetErr, NotSetError) || errcode.Of(unsetErr) != errcode.NotFound {
+		t.Errorf("expected NotSetError with code not_found, got %v", unsetErr)
+	}
+	if !errors.Is(invalidErr, InvalidValueError) || errcode.Of(invalidErr) != errcode.Invalid {
+		t.Errorf("expected InvalidValueError with code invalid, got %v", invalidErr)
+	}
+}
+
+func TestRequire_EmptySlice(t *testing.T) {
+	// Arrange
+	t.Setenv("ENV_TEST_EMPTY", "")
+
+	// Act
+	got, err := Require[[]string]("ENV_TEST_EMPTY")
+
+	// Assert
+	if err != nil || got == nil || len(got) != 0 {
+		t.Errorf("expected an empty slice, got %v, %v", got, err)
+	}
+}
@@ -0,0 +1,194 @@
+// Package errcode classifies errors with typed codes that survive wrapping
+// and map onto HTTP and gRPC statuses.
+package errcode
+
+import (
+	"context"
+	"errors"
+	"net/http"
+)
+
+// Code classifies an error by what went wrong, independently of its
+// message.
+type Code int
+
+const (
+	// Unknown is the code of errors that carry no classification.
+	Unknown Code = iota
+
+	// Invalid reports a request that is malformed or fails validation.
+	Invalid
+
+	// NotFound reports that a requested entity does not exist.
+	NotFound
+
+	// Conflict reports that a request conflicts with the current state,
+	// such as a duplicate registration or a held lock.
+	Conflict
+
+	// Timeout reports that a deadline expired before completion.
+	Timeout
+
+	// Canceled reports that the caller abandoned the request.
+	Canceled
+
+	// Unavailable reports a transient failure that may succeed if retried
+	// later, such as a closed or overloaded resource.
+	Unavailable
+)
+
+// String returns the name of the code.
+func (c Code) String() string {
+	switch c {
+	case Invalid:
+		return "invalid"
+	case NotFound:
+		return "not_found"
+	case Conflict:
+		return "conflict"
+	case Timeout:
+		return "timeout"
+	case Canceled:
+		return "canceled"
+	case Unavailable:
+		return "unavailable"
+	default:
+		return "unknown"
+	}
+}
+
+// HTTPStatus returns the HTTP status code corresponding to the code.
+func (c Code) HTTPStatus() int {
+	switch c {
+	case Invalid:
+		return http.StatusBadRequest
+	case NotFound:
+		return http.StatusNotFound
+	case Conflict:
+		return http.StatusConflict
+	case Timeout:
+		return http.StatusGatewayTimeout
+	case Canceled:
+		return 499 // Client Closed Request, as used by nginx
+	case Unavailable:
+		return http.StatusServiceUnavailable
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// GRPCCode returns the numeric gRPC status code corresponding to the code,
+// matching the values of google.golang.org/grpc/codes without depending on
+// it: convert with codes.Code(c.GRPCCode()).
+func (c Code) GRPCCode() uint32 {
+	switch c {
+	case Invalid:
+		return 3 // InvalidArgument
+	case NotFound:
+		return 5 // NotFound
+	case Conflict:
+		return 6 // AlreadyExists
+	case Timeout:
+		return 4 // DeadlineExceeded
+	case Canceled:
+		return 1 // Canceled
+	case Unavailable:
+		return 14 // Unavailable
+	default:
+		return 2 // Unknown
+	}
+}
+
+// Coder is implemented by errors that carry a Code. Packages can implement
+// it on their own error types instead of wrapping them.
+type Coder interface {
+	Code() Code
+}
+
+// Error is an error annotated with a Code. Its message is the message of
+// the wrapped error.
+type Error struct {
+	code Code
+	err  error
+}
+
+// New creates an error with the given code and message.
+//
+// Example:
+//
+//	var UserNotFoundError = errcode.New(errcode.NotFound, "user not found")
+func New(code Code, msg string) error {
+	return &Error{code: code, err: errors.New(msg)}
+}
+
+// Wrap annotates err with code, keeping err reachable through errors.Is
+// and errors.As. It returns nil if err is nil.
+//
+// Parameters:
+//   - code: The code to attach.
+//   - err: The error to classify.
+//
+// Returns:
+//   - error: The coded error, or nil.
+//
+// Example:
+//
+//	if errors.Is(err, sql.ErrNoRows) {
+//		return errcode.Wrap(errcode.NotFound, err)
+//	}
+func Wrap(code Code, err error) error {
+	if err == nil {
+		return nil
+	}
+	return &Error{code: code, err: err}
+}
+
+func (e *Error) Error() string {
+	return e.err.Error()
+}
+
+// Unwrap returns the wrapped error.
+func (e *Error) Unwrap() error {
+	return e.err
+}
+
+// Code returns the code of the error.
+func (e *Error) Code() Code {
+	return e.code
+}
+
+// Of returns the code of the outermost Coder in err's chain. Errors
+// without a Coder are classified as Timeout or Canceled if they wrap the
+// corresponding context error, and as Unknown otherwise.
+//
+// Parameters:
+//   - err: The error to classify.
+//
+// Returns:
+//   - Code: The code of err; Unknown for nil.
+//
+// Example:
+//
+//	w.WriteHeader(errcode.Of(err).HTTPStatus())
+func Of(err error) Code {
+	if err == nil {
+		return Unknown
+	}
+	var coder Coder
+	if errors.As(err, &coder) {
+		return coder.Code()
+	}
+	switch {
+	case errors.Is(err, context.DeadlineExceeded):
+		return Timeout
+	case errors.Is(err, context.Canceled):
+		return Canceled
+	default:
+		return Unknown
+	}
+}
+
+// Is reports whether err is classified with code.
+func Is(err error, code Code) bool {
+	return err != nil && Of(err) == code
+}
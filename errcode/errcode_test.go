@@ -0,0 +1,137 @@
+package errcode
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func TestWrap(t *testing.T) {
+	// Arrange
+	cause := errors.New("no rows")
+
+	// Act
+	err := Wrap(NotFound, cause)
+
+	// Assert
+	if !errors.Is(err, cause) {
+		t.Error("expected the coded error to wrap its cause")
+	}
+	if err.Error() != "no rows" {
+		t.Errorf("expected the cause's message, got %q", err.Error())
+	}
+	if Of(err) != NotFound {
+		t.Errorf("expected code not_found, got %v", Of(err))
+	}
+}
+
+func TestWrap_Nil(t *testing.T) {
+	// Act
+	err := Wrap(Conflict, nil)
+
+	// Assert
+	if err != nil {
+		t.Errorf("expected nil, got %v", err)
+	}
+}
+
+func TestOf_ThroughWrapping(t *testing.T) {
+	// Arrange
+	err := fmt.Errorf("load user: %w", New(NotFound, "user not found"))
+
+	// Act
+	code := Of(err)
+
+	// Assert
+	if code != NotFound {
+		t.Errorf("expected code not_found, got %v", code)
+	}
+	var coded *Error
+	if !errors.As(err, &coded) {
+		t.Error("expected errors.As to find the coded error")
+	}
+}
+
+func TestOf_ContextErrors(t *testing.T) {
+	tests := []struct {
+		err      error
+		expected Code
+	}{
+		{nil, Unknown},
+		{errors.New("plain"), Unknown},
+		{context.DeadlineExceeded, Timeout},
+		{fmt.Errorf("query: %w", context.Canceled), Canceled},
+		{Wrap(Unavailable, context.DeadlineExceeded), Unavailable},
+	}
+	for _, tt := range tests {
+		// Act
+		code := Of(tt.err)
+
+		// Assert
+		if code != tt.expected {
+			t.Errorf("expected Of(%v) = %v, got %v", tt.err, tt.expected, code)
+		}
+	}
+}
+
+// customError implements Coder directly.
+type customError struct{}
+
+func (customError) Error() string { return "custom" }
+func (customError) Code() Code    { return Invalid }
+
+func TestOf_Coder(t *testing.T) {
+	// Act
+	code := Of(fmt.Errorf("wrapped: %w", customError{}))
+
+	// Assert
+	if code != Invalid {
+		t.Errorf("expected code invalid, got %v", code)
+	}
+}
+
+func TestIs(t *testing.T) {
+	// Arrange
+	err := New(Conflict, "duplicate")
+
+	// Act & Assert
+	if !Is(err, Conflict) {
+		t.Error("expected Is to match the code")
+	}
+	if Is(err, NotFound) {
+		t.Error("expected Is not to match another code")
+	}
+	if Is(nil, Unknown) {
+		t.Error("expected Is to be false for a nil error")
+	}
+}
+
+func TestCode_Statuses(t *testing.T) {
+	tests := []struct {
+		code Code
+		http int
+		grpc uint32
+		name string
+	}{
+		{Unknown, http.StatusInternalServerError, 2, "unknown"},
+		{Invalid, http.StatusBadRequest, 3, "invalid"},
+		{NotFound, http.StatusNotFound, 5, "not_found"},
+		{Conflict, http.StatusConflict, 6, "conflict"},
+		{Timeout, http.StatusGatewayTimeout, 4, "timeout"},
+		{Canceled, 499, 1, "canceled"},
+		{Unavailable, http.StatusServiceUnavailable, 14, "unavailable"},
+	}
+	for _, tt := range tests {
+		if got := tt.code.HTTPStatus(); got != tt.http {
+			t.Errorf("expected %v HTTP status %d, got %d", tt.code, tt.http, got)
+		}
+		if got := tt.code.GRPCCode(); got != tt.grpc {
+			t.Errorf("expected %v gRPC code %d, got %d", tt.code, tt.grpc, got)
+		}
+		if got := tt.code.String(); got != tt.name {
+			t.Errorf("expected name %q, got %q", tt.name, got)
+		}
+	}
+}
@@ -0,0 +1,145 @@
+// Package eventbus provides a typed in-process event bus: handlers
+// subscribe to an event type and are called for every published event of
+// that type.
+package eventbus
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	"github.com/zodimo/go-zbase-std/future"
+	"github.com/zodimo/go-zbase-std/multierr"
+	"github.com/zodimo/go-zbase-std/syncx"
+)
+
+// Bus dispatches published events to the handlers subscribed to their
+// type. Handlers subscribed to an interface type receive every event that
+// implements it. It is safe for concurrent use; subscribing is cheap for
+// publishers because the handler list is copy-on-write. The zero value is
+// an empty bus ready to use.
+type Bus struct {
+	subs syncx.COWSlice[*subscription]
+}
+
+// subscription is a handler for events of a single type.
+type subscription struct {
+	typ  reflect.Type
+	call func(ctx context.Context, evt any) error
+}
+
+// New creates an empty Bus.
+func New() *Bus {
+	return &Bus{}
+}
+
+// Subscribe registers fn to be called for every event of type T published
+// on b. If T is an interface type, fn is called for every event whose type
+// implements it.
+//
+// Parameters:
+//   - b: The bus to subscribe to.
+//   - fn: The handler. Its error is collected by Publish.
+//
+// Returns:
+//   - func(): Removes the subscription. Calling it more than once has no
+//     further effect.
+//
+// Example:
+//
+//	unsubscribe := eventbus.Subscribe(bus, func(ctx context.Context, evt OrderPlaced) error {
+//		return mailer.SendConfirmation(ctx, evt.OrderID)
+//	})
+//	defer unsubscribe()
+func Subscribe[T any](b *Bus, fn func(ctx context.Context, evt T) error) func() {
+	s := &subscription{
+		typ: reflect.TypeFor[T](),
+		call: func(ctx context.Context, evt any) error {
+			return fn(ctx, evt.(T))
+		},
+	}
+	b.subs.Append(s)
+	return func() {
+		b.subs.DeleteFunc(func(other *subscription) bool { return other == s })
+	}
+}
+
+// Publish calls the handlers of evt's type one after another, in the order
+// they subscribed, and waits for them to return. A handler that panics is
+// reported as an error and does not stop the others.
+//
+// Parameters:
+//   - ctx: Passed to the handlers. Handlers not yet called when ctx is
+//     done are skipped.
+//   - evt: The event to dispatch.
+//
+// Returns:
+//   - error: nil if every handler succeeded; otherwise the handler errors,
+//     and ctx's error if handlers were skipped, combined by multierr.
+func (b *Bus) Publish(ctx context.Context, evt any) error {
+	var errs multierr.Error
+	for _, s := range b.handlers(evt) {
+		if err := ctx.Err(); err != nil {
+			errs.Append(err)
+			break
+		}
+		errs.Append(call(ctx, s, evt))
+	}
+	return errs.ErrorOrNil()
+}
+
+// PublishAsync calls the handlers of evt's type concurrently and returns
+// immediately.
+//
+// Parameters:
+//   - ctx: Passed to the handlers; cancelling the returned future cancels
+//     the context they see.
+//   - evt: The event to dispatch.
+//
+// Returns:
+//   - *future.Future[struct{}]: Settled once every handler has returned,
+//     with their errors combined by multierr.
+func (b *Bus) PublishAsync(ctx context.Context, evt any) *future.Future[struct{}] {
+	subs := b.handlers(evt)
+	return future.Go(ctx, func(ctx context.Context) (struct{}, error) {
+		var g multierr.Group
+		for _, s := range subs {
+			g.Go(func() error { return call(ctx, s, evt) })
+		}
+		return struct{}{}, g.Wait()
+	})
+}
+
+// Len returns the number of subscriptions.
+func (b *Bus) Len() int {
+	return b.subs.Len()
+}
+
+// handlers returns the subscriptions that receive evt.
+func (b *Bus) handlers(evt any) []*subscription {
+	typ := reflect.TypeOf(evt)
+	if typ == nil {
+		return nil
+	}
+	var matched []*subscription
+	for _, s := range b.subs.Load() {
+		if s.typ == typ || (s.typ.Kind() == reflect.Interface && typ.Implements(s.typ)) {
+			matched = append(matched, s)
+		}
+	}
+	return matched
+}
+
+// call runs the handler of s, converting a panic into an error and naming
+// the event type in the error.
+func call(ctx context.Context, s *subscription, evt any) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("eventbus: handler for %v panicked: %v", s.typ, r)
+		}
+	}()
+	if err := s.call(ctx, evt); err != nil {
+		return fmt.Errorf("eventbus: handler for %v: %w", s.typ, err)
+	}
+	return nil
+}
@@ -0,0 +1,154 @@
+package eventbus
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/zodimo/go-zbase-std/multierr"
+)
+
+type orderPlaced struct {
+	id int
+}
+
+func (e orderPlaced) String() string { return fmt.Sprintf("order %d placed", e.id) }
+
+type orderCancelled struct {
+	id int
+}
+
+func TestBus_PublishDispatchesByType(t *testing.T) {
+	// Arrange
+	bus := New()
+	var placed, cancelled []int
+	Subscribe(bus, func(_ context.Context, evt orderPlaced) error {
+		placed = append(placed, evt.id)
+		return nil
+	})
+	Subscribe(bus, func(_ context.Context, evt orderCancelled) error {
+		cancelled = append(cancelled, evt.id)
+		return nil
+	})
+
+	// Act
+	err := bus.Publish(context.Background(), orderPlaced{id: 1})
+
+	// Assert
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if !slices.Equal(placed, []int{1}) || len(cancelled) != 0 {
+		t.Errorf("expected only the orderPlaced handler to run, got %v and %v", placed, cancelled)
+	}
+}
+
+func TestBus_InterfaceSubscription(t *testing.T) {
+	// Arrange
+	bus := New()
+	var seen []string
+	Subscribe(bus, func(_ context.Context, evt fmt.Stringer) error {
+		seen = append(seen, evt.String())
+		return nil
+	})
+
+	// Act
+	_ = bus.Publish(context.Background(), orderPlaced{id: 7})
+	_ = bus.Publish(context.Background(), orderCancelled{id: 8})
+
+	// Assert
+	if !slices.Equal(seen, []string{"order 7 placed"}) {
+		t.Errorf("expected only events implementing the interface, got %v", seen)
+	}
+}
+
+func TestBus_PublishCollectsHandlerErrors(t *testing.T) {
+	// Arrange
+	bus := New()
+	first, second := errors.New("first"), errors.New("second")
+	ran := 0
+	Subscribe(bus, func(context.Context, orderPlaced) error { ran++; return first })
+	Subscribe(bus, func(context.Context, orderPlaced) error { ran++; panic("boom") })
+	Subscribe(bus, func(context.Context, orderPlaced) error { ran++; return second })
+
+	// Act
+	err := bus.Publish(context.Background(), orderPlaced{id: 1})
+
+	// Assert
+	var merr *multierr.Error
+	if !errors.As(err, &merr) || merr.Len() != 3 {
+		t.Fatalf("expected 3 collected errors, got %v", err)
+	}
+	if !errors.Is(err, first) || !errors.Is(err, second) || !strings.Contains(err.Error(), "panicked: boom") {
+		t.Errorf("expected every handler error, got %v", err)
+	}
+	if ran != 3 {
+		t.Errorf("expected every handler to run, got %d", ran)
+	}
+}
+
+func TestBus_PublishSkipsHandlersAfterCancel(t *testing.T) {
+	// Arrange
+	bus := New()
+	ctx, cancel := context.WithCancel(context.Background())
+	ran := 0
+	Subscribe(bus, func(context.Context, orderPlaced) error { ran++; cancel(); return nil })
+	Subscribe(bus, func(context.Context, orderPlaced) error { ran++; return nil })
+
+	// Act
+	err := bus.Publish(ctx, orderPlaced{id: 1})
+
+	// Assert
+	if !errors.Is(err, context.Canceled) || ran != 1 {
+		t.Errorf("expected the second handler to be skipped, got %v after %d handlers", err, ran)
+	}
+}
+
+func TestBus_PublishAsync(t *testing.T) {
+	// Arrange
+	bus := New()
+	failure := errors.New("failed")
+	var mu sync.Mutex
+	var ids []int
+	for range 3 {
+		Subscribe(bus, func(_ context.Context, evt orderPlaced) error {
+			mu.Lock()
+			defer mu.Unlock()
+			ids = append(ids, evt.id)
+			return nil
+		})
+	}
+	Subscribe(bus, func(context.Context, orderPlaced) error { return failure })
+
+	// Act
+	_, err := bus.PublishAsync(context.Background(), orderPlaced{id: 5}).Await(context.Background())
+
+	// Assert
+	if !errors.Is(err, failure) {
+		t.Errorf("expected %v, got %v", failure, err)
+	}
+	if !slices.Equal(ids, []int{5, 5, 5}) {
+		t.Errorf("expected every handler to run, got %v", ids)
+	}
+}
+
+func TestBus_Unsubscribe(t *testing.T) {
+	// Arrange
+	bus := New()
+	ran := 0
+	unsubscribe := Subscribe(bus, func(context.Context, orderPlaced) error { ran++; return nil })
+
+	// Act
+	unsubscribe()
+	unsubscribe() // second call is a no-op
+	_ = bus.Publish(context.Background(), orderPlaced{id: 1})
+
+	// Assert
+	if ran != 0 || bus.Len() != 0 {
+		t.Errorf("expected no handler after unsubscribing, got %d runs and %d subscriptions", ran, bus.Len())
+	}
+}
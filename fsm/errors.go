@@ -0,0 +1,40 @@
+package fsm
+
+import (
+	"fmt"
+
+	"github.com/zodimo/go-zbase-std/errcode"
+)
+
+// InvalidTransitionError is returned by Fire when an event may not happen
+// in the current state, either because no transition is permitted or
+// because the guard of the transition rejected it.
+type InvalidTransitionError[S, E comparable] struct {
+	// State is the state the machine was in.
+	State S
+
+	// Event is the event that was fired.
+	Event E
+
+	// Cause is the error returned by the guard, or nil if no transition is
+	// permitted at all.
+	Cause error
+}
+
+func (e *InvalidTransitionError[S, E]) Error() string {
+	if e.Cause != nil {
+		return fmt.Sprintf("fsm: event %v rejected in state %v: %v", e.Event, e.State, e.Cause)
+	}
+	return fmt.Sprintf("fsm: event %v not permitted in state %v", e.Event, e.State)
+}
+
+// Unwrap returns the error of the guard that rejected the transition.
+func (e *InvalidTransitionError[S, E]) Unwrap() error {
+	return e.Cause
+}
+
+// Code classifies the error as Conflict for the errcode package: the event
+// conflicts with the current state.
+func (e *InvalidTransitionError[S, E]) Code() errcode.Code {
+	return errcode.Conflict
+}
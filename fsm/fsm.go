@@ -0,0 +1,173 @@
+// Package fsm provides a finite state machine with typed states and
+// events, guarded transitions and entry and exit callbacks.
+package fsm
+
+import (
+	"context"
+	"sync"
+)
+
+// Transition describes a change of state caused by an event.
+type Transition[S, E comparable] struct {
+	From  S
+	Event E
+	To    S
+}
+
+// Guard decides whether a permitted transition may happen. Returning an
+// error rejects it; Fire then returns an InvalidTransitionError wrapping
+// that error.
+type Guard[S, E comparable] func(ctx context.Context, t Transition[S, E]) error
+
+// Callback is run when a state is entered or exited.
+type Callback[S, E comparable] func(ctx context.Context, t Transition[S, E])
+
+// Machine is a finite state machine over states S and events E. Only the
+// transitions registered with Permit or PermitIf can happen. It is safe for
+// concurrent use; transitions are serialized, so guards and callbacks see a
+// consistent state but must not call Fire themselves.
+type Machine[S, E comparable] struct {
+	fire sync.Mutex // Serializes Fire.
+
+	mu          sync.RWMutex // Guards the fields below.
+	state       S
+	transitions map[transitionKey[S, E]]transition[S, E]
+	onEnter     map[S][]Callback[S, E]
+	onExit      map[S][]Callback[S, E]
+}
+
+// transitionKey identifies the transition of an event from a state.
+type transitionKey[S, E comparable] struct {
+	from  S
+	event E
+}
+
+// transition is a permitted transition and its optional guard.
+type transition[S, E comparable] struct {
+	to    S
+	guard Guard[S, E]
+}
+
+// New creates a Machine in the initial state with no permitted transitions.
+//
+// Parameters:
+//   - initial: The state the machine starts in.
+//
+// Returns:
+//   - *Machine[S, E]: The new machine.
+//
+// Example:
+//
+//	order := fsm.New[State, Event](Draft)
+//	order.Permit(Draft, Submit, Review)
+//	order.PermitIf(Review, Approve, Approved, requireManager)
+//	order.OnEnter(Approved, notifyCustomer)
+//	if err := order.Fire(ctx, Submit); err != nil {
+//		return err
+//	}
+func New[S, E comparable](initial S) *Machine[S, E] {
+	return &Machine[S, E]{
+		state:       initial,
+		transitions: make(map[transitionKey[S, E]]transition[S, E]),
+		onEnter:     make(map[S][]Callback[S, E]),
+		onExit:      make(map[S][]Callback[S, E]),
+	}
+}
+
+// Permit allows event to move the machine from one state to another,
+// replacing any transition registered for the same state and event.
+func (m *Machine[S, E]) Permit(from S, event E, to S) {
+	m.PermitIf(from, event, to, nil)
+}
+
+// PermitIf is like Permit, but the transition only happens if guard, when
+// non-nil, returns nil.
+func (m *Machine[S, E]) PermitIf(from S, event E, to S, guard Guard[S, E]) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.transitions[transitionKey[S, E]{from, event}] = transition[S, E]{to: to, guard: guard}
+}
+
+// OnEnter adds a callback that is run after the machine enters state,
+// including through a transition from state to itself.
+func (m *Machine[S, E]) OnEnter(state S, fn Callback[S, E]) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.onEnter[state] = append(m.onEnter[state], fn)
+}
+
+// OnExit adds a callback that is run before the machine leaves state,
+// including through a transition from state to itself.
+func (m *Machine[S, E]) OnExit(state S, fn Callback[S, E]) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.onExit[state] = append(m.onExit[state], fn)
+}
+
+// State returns the current state.
+func (m *Machine[S, E]) State() S {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.state
+}
+
+// Can reports whether a transition is permitted for event in the current
+// state, without evaluating its guard.
+func (m *Machine[S, E]) Can(event E) bool {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	_, ok := m.transitions[transitionKey[S, E]{m.state, event}]
+	return ok
+}
+
+// Fire applies event to the machine. If a transition is permitted and its
+// guard accepts it, the exit callbacks of the current state run, the state
+// changes, and the entry callbacks of the new state run.
+//
+// Parameters:
+//   - ctx: Passed to the guard and callbacks.
+//   - event: The event to apply.
+//
+// Returns:
+//   - error: ctx.Err() if ctx is already done; an
+//     *InvalidTransitionError[S, E] if the event is not permitted or its
+//     guard rejected it; nil otherwise.
+func (m *Machine[S, E]) Fire(ctx context.Context, event E) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	m.fire.Lock()
+	defer m.fire.Unlock()
+
+	m.mu.RLock()
+	from := m.state
+	next, ok := m.transitions[transitionKey[S, E]{from, event}]
+	m.mu.RUnlock()
+	if !ok {
+		return &InvalidTransitionError[S, E]{State: from, Event: event}
+	}
+	t := Transition[S, E]{From: from, Event: event, To: next.to}
+	if next.guard != nil {
+		if err := next.guard(ctx, t); err != nil {
+			return &InvalidTransitionError[S, E]{State: from, Event: event, Cause: err}
+		}
+	}
+
+	for _, fn := range m.callbacks(m.onExit, from) {
+		fn(ctx, t)
+	}
+	m.mu.Lock()
+	m.state = t.To
+	m.mu.Unlock()
+	for _, fn := range m.callbacks(m.onEnter, t.To) {
+		fn(ctx, t)
+	}
+	return nil
+}
+
+// callbacks returns a copy of the callbacks registered for state.
+func (m *Machine[S, E]) callbacks(registered map[S][]Callback[S, E], state S) []Callback[S, E] {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return append([]Callback[S, E](nil), registered[state]...)
+}
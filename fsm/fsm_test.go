@@ -0,0 +1,165 @@
+package fsm
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync"
+	"testing"
+
+	"github.com/zodimo/go-zbase-std/errcode"
+)
+
+type state string
+
+type event string
+
+const (
+	draft    state = "draft"
+	review   state = "review"
+	approved state = "approved"
+
+	submit  event = "submit"
+	approve event = "approve"
+	reject  event = "reject"
+)
+
+func newOrder() *Machine[state, event] {
+	m := New[state, event](draft)
+	m.Permit(draft, submit, review)
+	m.Permit(review, reject, draft)
+	m.Permit(review, approve, approved)
+	return m
+}
+
+func TestMachine_Fire(t *testing.T) {
+	// Arrange
+	m := newOrder()
+
+	// Act
+	err := m.Fire(context.Background(), submit)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if m.State() != review {
+		t.Errorf("expected state %q, got %q", review, m.State())
+	}
+}
+
+func TestMachine_FireNotPermitted(t *testing.T) {
+	// Arrange
+	m := newOrder()
+
+	// Act
+	err := m.Fire(context.Background(), approve)
+
+	// Assert
+	var invalid *InvalidTransitionError[state, event]
+	if !errors.As(err, &invalid) || invalid.State != draft || invalid.Event != approve {
+		t.Fatalf("expected an InvalidTransitionError for approve in draft, got %v", err)
+	}
+	if errcode.Of(err) != errcode.Conflict {
+		t.Errorf("expected code %v, got %v", errcode.Conflict, errcode.Of(err))
+	}
+	if m.State() != draft {
+		t.Errorf("expected state to stay %q, got %q", draft, m.State())
+	}
+}
+
+func TestMachine_GuardRejects(t *testing.T) {
+	// Arrange
+	m := New[state, event](review)
+	notManager := errors.New("approver is not a manager")
+	m.PermitIf(review, approve, approved, func(context.Context, Transition[state, event]) error {
+		return notManager
+	})
+
+	// Act
+	err := m.Fire(context.Background(), approve)
+
+	// Assert
+	var invalid *InvalidTransitionError[state, event]
+	if !errors.As(err, &invalid) || !errors.Is(err, notManager) {
+		t.Fatalf("expected an InvalidTransitionError wrapping the guard error, got %v", err)
+	}
+	if m.State() != review {
+		t.Errorf("expected state to stay %q, got %q", review, m.State())
+	}
+}
+
+func TestMachine_Callbacks(t *testing.T) {
+	// Arrange
+	m := newOrder()
+	var calls []string
+	m.OnExit(draft, func(_ context.Context, tr Transition[state, event]) {
+		calls = append(calls, "exit "+string(tr.From)+" in "+string(m.State()))
+	})
+	m.OnEnter(review, func(_ context.Context, tr Transition[state, event]) {
+		calls = append(calls, "enter "+string(tr.To)+" in "+string(m.State()))
+	})
+
+	// Act
+	_ = m.Fire(context.Background(), submit)
+
+	// Assert
+	want := []string{"exit draft in draft", "enter review in review"}
+	if !slices.Equal(calls, want) {
+		t.Errorf("expected %v, got %v", want, calls)
+	}
+}
+
+func TestMachine_Can(t *testing.T) {
+	// Arrange
+	m := newOrder()
+
+	// Act
+	canSubmit, canApprove := m.Can(submit), m.Can(approve)
+
+	// Assert
+	if !canSubmit || canApprove {
+		t.Errorf("expected submit to be permitted and approve not, got %v and %v", canSubmit, canApprove)
+	}
+}
+
+func TestMachine_FireCancelledContext(t *testing.T) {
+	// Arrange
+	m := newOrder()
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	// Act
+	err := m.Fire(ctx, submit)
+
+	// Assert
+	if !errors.Is(err, context.Canceled) || m.State() != draft {
+		t.Errorf("expected context.Canceled without a transition, got %v in %q", err, m.State())
+	}
+}
+
+func TestMachine_ConcurrentFire(t *testing.T) {
+	// Arrange
+	m := newOrder()
+	var wg sync.WaitGroup
+	var succeeded sync.Map
+
+	// Act
+	for i := range 10 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if m.Fire(context.Background(), submit) == nil {
+				succeeded.Store(i, true)
+			}
+		}()
+	}
+	wg.Wait()
+
+	// Assert
+	n := 0
+	succeeded.Range(func(any, any) bool { n++; return true })
+	if n != 1 {
+		t.Errorf("expected exactly one submit to succeed, got %d", n)
+	}
+}
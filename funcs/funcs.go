@@ -0,0 +1,115 @@
+// Package funcs wraps plain functions to shape how often they run or to
+// cache their results, without bespoke closures around timers and maps.
+package funcs
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/zodimo/go-zbase-std/cache"
+	"github.com/zodimo/go-zbase-std/clock"
+)
+
+// Debounce returns a function that delays calling fn until d has passed
+// without another call, so a burst of calls runs fn once, after the burst.
+// fn runs in its own goroutine.
+//
+// Parameters:
+//   - fn: The function to debounce.
+//   - d: The quiet period that must pass before fn runs.
+//   - opts: WithClock replaces the system clock.
+//
+// Returns:
+//   - call: Schedules fn, postponing any call already scheduled.
+//   - cancel: Drops the scheduled call, if any.
+//
+// Example:
+//
+//	save, cancel := funcs.Debounce(saveDraft, 500*time.Millisecond)
+//	defer cancel()
+//	editor.OnChange(save)
+func Debounce(fn func(), d time.Duration, opts ...Option) (call, cancel func()) {
+	cfg := newConfig(opts)
+	var mu sync.Mutex
+	var timer clock.Timer
+	call = func() {
+		mu.Lock()
+		defer mu.Unlock()
+		if timer != nil {
+			timer.Stop()
+		}
+		timer = cfg.clock.AfterFunc(d, fn)
+	}
+	cancel = func() {
+		mu.Lock()
+		defer mu.Unlock()
+		if timer != nil {
+			timer.Stop()
+			timer = nil
+		}
+	}
+	return call, cancel
+}
+
+// Throttle returns a function that calls fn at most once per interval,
+// dropping the calls in between. The first call runs fn immediately, in
+// the caller's goroutine.
+//
+// Parameters:
+//   - fn: The function to throttle.
+//   - interval: The minimum time between two runs of fn.
+//   - opts: WithClock replaces the system clock.
+//
+// Returns:
+//   - func() bool: Runs fn unless it ran less than interval ago, reporting
+//     whether it did.
+func Throttle(fn func(), interval time.Duration, opts ...Option) func() bool {
+	cfg := newConfig(opts)
+	var mu sync.Mutex
+	var next time.Time
+	return func() bool {
+		mu.Lock()
+		now := cfg.clock.Now()
+		if now.Before(next) {
+			mu.Unlock()
+			return false
+		}
+		next = now.Add(interval)
+		mu.Unlock()
+		fn()
+		return true
+	}
+}
+
+// Memoize returns a function that caches the results of fn by argument.
+// Concurrent calls with the same argument share a single call to fn.
+// Errors are returned but not cached, so a failed call is retried by the
+// next caller.
+//
+// Parameters:
+//   - fn: The function to memoize.
+//   - opts: WithTTL and WithMaxEntries bound how long and how many results
+//     are kept; without them results are kept forever. WithClock replaces
+//     the system clock that measures WithTTL.
+//
+// Returns:
+//   - func(K) (V, error): The memoized function.
+//
+// Example:
+//
+//	lookup := funcs.Memoize(resolveHost, funcs.WithTTL(time.Minute), funcs.WithMaxEntries(1000))
+//	addr, err := lookup("example.com")
+func Memoize[K comparable, V any](fn func(K) (V, error), opts ...Option) func(K) (V, error) {
+	cfg := newConfig(opts)
+	cacheOpts := []cache.Option[K, V]{cache.WithClock[K, V](cfg.clock)}
+	if cfg.ttl > 0 {
+		cacheOpts = append(cacheOpts, cache.WithTTL[K, V](cfg.ttl))
+	}
+	results := cache.New(cfg.maxEntries, cacheOpts...)
+	return func(key K) (V, error) {
+		return results.GetOrLoad(context.Background(), key, func(context.Context) (V, error) {
+			return fn(key)
+		})
+	}
+}
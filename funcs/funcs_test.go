@@ -0,0 +1,160 @@
+package funcs
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/zodimo/go-zbase-std/clock"
+)
+
+func TestDebounce(t *testing.T) {
+	// Arrange
+	fake := clock.NewFakeClock(time.Unix(0, 0))
+	var calls atomic.Int32
+	ran := make(chan struct{}, 1)
+	call, _ := Debounce(func() { calls.Add(1); ran <- struct{}{} }, time.Second, WithClock(fake))
+
+	// Act
+	call()
+	fake.Advance(900 * time.Millisecond)
+	call()
+	fake.Advance(900 * time.Millisecond)
+	before := calls.Load()
+	fake.Advance(100 * time.Millisecond)
+
+	// Assert
+	<-ran
+	if before != 0 || calls.Load() != 1 {
+		t.Errorf("expected one call after the quiet period, got %d before and %d after", before, calls.Load())
+	}
+}
+
+func TestDebounce_Cancel(t *testing.T) {
+	// Arrange
+	fake := clock.NewFakeClock(time.Unix(0, 0))
+	call, cancel := Debounce(func() { t.Error("expected the cancelled call not to run") }, time.Second, WithClock(fake))
+	call()
+
+	// Act
+	cancel()
+	fake.Advance(time.Second)
+
+	// Assert
+	if fake.Pending() != 0 {
+		t.Errorf("expected no pending call, got %d", fake.Pending())
+	}
+}
+
+func TestThrottle(t *testing.T) {
+	// Arrange
+	fake := clock.NewFakeClock(time.Unix(0, 0))
+	calls := 0
+	throttled := Throttle(func() { calls++ }, time.Second, WithClock(fake))
+
+	// Act
+	first := throttled()
+	second := throttled()
+	fake.Advance(time.Second)
+	third := throttled()
+
+	// Assert
+	if !first || second || !third || calls != 2 {
+		t.Errorf("expected true, false, true and 2 calls, got %v, %v, %v and %d", first, second, third, calls)
+	}
+}
+
+func TestMemoize(t *testing.T) {
+	// Arrange
+	var calls atomic.Int32
+	square := Memoize(func(n int) (int, error) {
+		calls.Add(1)
+		return n * n, nil
+	})
+
+	// Act
+	var wg sync.WaitGroup
+	for range 10 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, _ = square(4)
+		}()
+	}
+	wg.Wait()
+	v, err := square(4)
+
+	// Assert
+	if err != nil || v != 16 {
+		t.Errorf("expected 16, got %d and %v", v, err)
+	}
+	if calls.Load() != 1 {
+		t.Errorf("expected a single call, got %d", calls.Load())
+	}
+}
+
+func TestMemoize_DoesNotCacheErrors(t *testing.T) {
+	// Arrange
+	failure := errors.New("unavailable")
+	calls := 0
+	lookup := Memoize(func(string) (string, error) {
+		calls++
+		if calls == 1 {
+			return "", failure
+		}
+		return "ok", nil
+	})
+
+	// Act
+	_, err := lookup("key")
+	v, errAgain := lookup("key")
+
+	// Assert
+	if !errors.Is(err, failure) || errAgain != nil || v != "ok" {
+		t.Errorf("expected the failure then ok, got %v, %q and %v", err, v, errAgain)
+	}
+}
+
+func TestMemoize_TTLUsesClock(t *testing.T) {
+	// Arrange
+	fake := clock.NewFakeClock(time.Unix(0, 0))
+	var calls atomic.Int32
+	square := Memoize(func(n int) (int, error) {
+		calls.Add(1)
+		return n * n, nil
+	}, WithTTL(time.Minute), WithClock(fake))
+
+	// Act
+	_, _ = square(3)
+	fake.Advance(59 * time.Second)
+	_, _ = square(3)
+	cached := calls.Load()
+	fake.Advance(time.Second)
+	_, _ = square(3)
+
+	// Assert
+	if cached != 1 {
+		t.Errorf("expected the result to be cached before the TTL, got %d calls", cached)
+	}
+	if calls.Load() != 2 {
+		t.Errorf("expected the result to expire after the TTL on the given clock, got %d calls", calls.Load())
+	}
+}
+
+func TestMemoize_MaxEntries(t *testing.T) {
+	// Arrange
+	calls := 0
+	id := Memoize(func(n int) (int, error) { calls++; return n, nil }, WithMaxEntries(1))
+
+	// Act
+	_, _ = id(1)
+	_, _ = id(2)
+	_, _ = id(1)
+
+	// Assert
+	if calls != 3 {
+		t.Errorf("expected the evicted result to be recomputed, got %d calls", calls)
+	}
+}
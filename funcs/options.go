@@ -0,0 +1,50 @@
+package funcs
+
+import (
+	"time"
+
+	"github.com/zodimo/go-zbase-std/clock"
+)
+
+// Option configures the wrappers of this package. Options that do not
+// apply to a wrapper are ignored by it.
+type Option func(*config)
+
+// config holds the settings shared by the wrappers.
+type config struct {
+	clock      clock.Clock
+	ttl        time.Duration
+	maxEntries int
+}
+
+// newConfig applies opts to the defaults.
+func newConfig(opts []Option) config {
+	cfg := config{clock: clock.System()}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	return cfg
+}
+
+// WithClock makes Debounce, Throttle and the WithTTL expiry of Memoize
+// measure time with the given clock instead of the system clock.
+func WithClock(c clock.Clock) Option {
+	return func(cfg *config) {
+		cfg.clock = c
+	}
+}
+
+// WithTTL makes Memoize forget results d after computing them.
+func WithTTL(d time.Duration) Option {
+	return func(cfg *config) {
+		cfg.ttl = d
+	}
+}
+
+// WithMaxEntries makes Memoize keep at most n results, forgetting the
+// least recently used first.
+func WithMaxEntries(n int) Option {
+	return func(cfg *config) {
+		cfg.maxEntries = n
+	}
+}
@@ -0,0 +1,130 @@
+package future
+
+import (
+	"context"
+	"errors"
+)
+
+// NoFuturesError is returned by Any and Race when called without futures,
+// as neither can produce a result.
+var NoFuturesError = errors.New("future: no futures given")
+
+// All waits for every future to resolve and returns their values in the
+// order of futures. It returns as soon as one future is rejected, or ctx
+// is done, cancelling the futures that have not settled.
+//
+// Parameters:
+//   - ctx: The context bounding the wait.
+//   - futures: The futures to wait for.
+//
+// Returns:
+//   - []T: The resolved values, in the order of futures.
+//   - error: The first rejection error, or ctx's error.
+//
+// Example:
+//
+//	users, err := future.All(ctx, fetchUser(ctx, 1), fetchUser(ctx, 2))
+func All[T any](ctx context.Context, futures ...*Future[T]) ([]T, error) {
+	settled := notifySettled(futures)
+	values := make([]T, len(futures))
+	for range futures {
+		select {
+		case i := <-settled:
+			if futures[i].err != nil {
+				cancelAll(futures)
+				return nil, futures[i].err
+			}
+			values[i] = futures[i].value
+		case <-ctx.Done():
+			cancelAll(futures)
+			return nil, ctx.Err()
+		}
+	}
+	return values, nil
+}
+
+// Any returns the value of the first future to resolve, cancelling the
+// others. If every future is rejected, the rejection errors are returned
+// joined. If ctx is done first, every future is cancelled.
+//
+// Parameters:
+//   - ctx: The context bounding the wait.
+//   - futures: The futures to wait for.
+//
+// Returns:
+//   - T: The first resolved value.
+//   - error: The joined rejection errors, ctx's error, or NoFuturesError.
+//
+// Example:
+//
+//	replica, err := future.Any(ctx, query(ctx, primary), query(ctx, secondary))
+func Any[T any](ctx context.Context, futures ...*Future[T]) (T, error) {
+	var zero T
+	if len(futures) == 0 {
+		return zero, NoFuturesError
+	}
+	settled := notifySettled(futures)
+	errs := make([]error, 0, len(futures))
+	for range futures {
+		select {
+		case i := <-settled:
+			if futures[i].err == nil {
+				cancelAll(futures)
+				return futures[i].value, nil
+			}
+			errs = append(errs, futures[i].err)
+		case <-ctx.Done():
+			cancelAll(futures)
+			return zero, ctx.Err()
+		}
+	}
+	return zero, errors.Join(errs...)
+}
+
+// Race returns the outcome of the first future to settle, resolved or
+// rejected, cancelling the others. If ctx is done first, every future is
+// cancelled.
+//
+// Parameters:
+//   - ctx: The context bounding the wait.
+//   - futures: The futures to race.
+//
+// Returns:
+//   - T: The value of the first future to settle.
+//   - error: Its rejection error, ctx's error, or NoFuturesError.
+//
+// Example:
+//
+//	value, err := future.Race(ctx, lookup(ctx), timeoutAfter(ctx, time.Second))
+func Race[T any](ctx context.Context, futures ...*Future[T]) (T, error) {
+	var zero T
+	if len(futures) == 0 {
+		return zero, NoFuturesError
+	}
+	select {
+	case i := <-notifySettled(futures):
+		cancelAll(futures)
+		return futures[i].value, futures[i].err
+	case <-ctx.Done():
+		cancelAll(futures)
+		return zero, ctx.Err()
+	}
+}
+
+// notifySettled returns a channel receiving the index of each future as it
+// settles. The channel is buffered so that abandoned notifications never
+// block the settling goroutine.
+func notifySettled[T any](futures []*Future[T]) <-chan int {
+	settled := make(chan int, len(futures))
+	for i, f := range futures {
+		f.Then(func(T, error) { settled <- i })
+	}
+	return settled
+}
+
+// cancelAll cancels every future; settled futures are unaffected.
+func cancelAll[T any](futures []*Future[T]) {
+	for _, f := range futures {
+		f.Cancel()
+	}
+}
@@ -0,0 +1,151 @@
+package future
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// after returns a future resolved with value, or rejected with err, after d
+// unless cancelled first.
+func after[T any](d time.Duration, value T, err error) *Future[T] {
+	return Go(context.Background(), func(ctx context.Context) (T, error) {
+		select {
+		case <-time.After(d):
+			return value, err
+		case <-ctx.Done():
+			var zero T
+			return zero, ctx.Err()
+		}
+	})
+}
+
+func TestAll(t *testing.T) {
+	// Act
+	values, err := All(context.Background(), after(2*time.Millisecond, 1, nil), after(0, 2, nil), after(time.Millisecond, 3, nil))
+
+	// Assert
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if !reflect.DeepEqual(values, []int{1, 2, 3}) {
+		t.Errorf("expected values in order [1 2 3], got %v", values)
+	}
+}
+
+func TestAll_FirstErrorCancelsOthers(t *testing.T) {
+	// Arrange
+	cause := errors.New("boom")
+	slow := after(time.Hour, 1, nil)
+
+	// Act
+	_, err := All(context.Background(), slow, after(0, 0, cause))
+
+	// Assert
+	if !errors.Is(err, cause) {
+		t.Errorf("expected error %v, got %v", cause, err)
+	}
+	if _, err := slow.Await(context.Background()); !errors.Is(err, context.Canceled) {
+		t.Errorf("expected the pending future to be cancelled, got %v", err)
+	}
+}
+
+func TestAll_Empty(t *testing.T) {
+	// Act
+	values, err := All[int](context.Background())
+
+	// Assert
+	if err != nil || len(values) != 0 {
+		t.Errorf("expected no values and no error, got %v, %v", values, err)
+	}
+}
+
+func TestAny(t *testing.T) {
+	// Arrange
+	slow := after(time.Hour, 1, nil)
+
+	// Act
+	value, err := Any(context.Background(), after(0, 0, errors.New("failed")), after(time.Millisecond, 2, nil), slow)
+
+	// Assert
+	if err != nil || value != 2 {
+		t.Errorf("expected the first success (2, nil), got (%v, %v)", value, err)
+	}
+	if _, err := slow.Await(context.Background()); !errors.Is(err, context.Canceled) {
+		t.Errorf("expected the losing future to be cancelled, got %v", err)
+	}
+}
+
+func TestAny_AllFail(t *testing.T) {
+	// Arrange
+	first := errors.New("first")
+	second := errors.New("second")
+
+	// Act
+	_, err := Any(context.Background(), after(0, 0, first), after(time.Millisecond, 0, second))
+
+	// Assert
+	if !errors.Is(err, first) || !errors.Is(err, second) {
+		t.Errorf("expected joined errors, got %v", err)
+	}
+}
+
+func TestRace(t *testing.T) {
+	// Arrange
+	cause := errors.New("fast failure")
+	slow := after(time.Hour, 1, nil)
+
+	// Act
+	_, err := Race(context.Background(), slow, after(0, 0, cause))
+
+	// Assert
+	if !errors.Is(err, cause) {
+		t.Errorf("expected the first settled outcome %v, got %v", cause, err)
+	}
+	if _, err := slow.Await(context.Background()); !errors.Is(err, context.Canceled) {
+		t.Errorf("expected the losing future to be cancelled, got %v", err)
+	}
+}
+
+func TestCombinators_ContextDone(t *testing.T) {
+	tests := []struct {
+		name string
+		run  func(context.Context, ...*Future[int]) error
+	}{
+		{"All", func(ctx context.Context, f ...*Future[int]) error { _, err := All(ctx, f...); return err }},
+		{"Any", func(ctx context.Context, f ...*Future[int]) error { _, err := Any(ctx, f...); return err }},
+		{"Race", func(ctx context.Context, f ...*Future[int]) error { _, err := Race(ctx, f...); return err }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			slow := after(time.Hour, 1, nil)
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+			defer cancel()
+
+			// Act
+			err := tt.run(ctx, slow)
+
+			// Assert
+			if !errors.Is(err, context.DeadlineExceeded) {
+				t.Errorf("expected context.DeadlineExceeded, got %v", err)
+			}
+			if _, err := slow.Await(context.Background()); !errors.Is(err, context.Canceled) {
+				t.Errorf("expected the pending future to be cancelled, got %v", err)
+			}
+		})
+	}
+}
+
+func TestCombinators_NoFutures(t *testing.T) {
+	// Act
+	_, anyErr := Any[int](context.Background())
+	_, raceErr := Race[int](context.Background())
+
+	// Assert
+	if !errors.Is(anyErr, NoFuturesError) || !errors.Is(raceErr, NoFuturesError) {
+		t.Errorf("expected NoFuturesError, got %v and %v", anyErr, raceErr)
+	}
+}
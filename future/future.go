@@ -0,0 +1,172 @@
+// Package future provides typed futures for results produced
+// asynchronously, replacing goroutines that report back over bespoke
+// channels.
+package future
+
+import (
+	"context"
+	"sync"
+)
+
+// Future is the read side of an asynchronous result of type T. It settles
+// exactly once, with either a value or an error. It is safe for concurrent
+// use.
+type Future[T any] struct {
+	done   chan struct{}      // Closed once the future settles.
+	cancel context.CancelFunc // Cancels the computation started by Go, or nil.
+
+	mu        sync.Mutex
+	settled   bool
+	value     T
+	err       error
+	callbacks []func(T, error) // Run once settled, then released.
+}
+
+// Promise is the write side of a Future, used by the producer of the
+// result to settle it.
+type Promise[T any] struct {
+	future *Future[T]
+}
+
+// New creates an unsettled Future together with the Promise that settles
+// it.
+//
+// Returns:
+//   - *Promise[T]: The write side, kept by the producer.
+//   - *Future[T]: The read side, handed to consumers.
+//
+// Example:
+//
+//	p, f := future.New[int]()
+//	go func() { p.Resolve(compute()) }()
+//	value, err := f.Await(ctx)
+func New[T any]() (*Promise[T], *Future[T]) {
+	f := &Future[T]{done: make(chan struct{})}
+	return &Promise[T]{future: f}, f
+}
+
+// Go runs fn in a new goroutine and returns a Future settled with its
+// result. The context passed to fn is cancelled by Cancel on the returned
+// Future, and once fn returns.
+//
+// Parameters:
+//   - ctx: The context passed to fn.
+//   - fn: The function computing the result.
+//
+// Returns:
+//   - *Future[T]: The Future settled when fn returns.
+//
+// Example:
+//
+//	user := future.Go(ctx, func(ctx context.Context) (User, error) {
+//		return fetchUser(ctx, id)
+//	})
+func Go[T any](ctx context.Context, fn func(context.Context) (T, error)) *Future[T] {
+	p, f := New[T]()
+	ctx, f.cancel = context.WithCancel(ctx)
+	go func() {
+		defer f.cancel()
+		value, err := fn(ctx)
+		p.settle(value, err)
+	}()
+	return f
+}
+
+// Future returns the Future settled by the Promise.
+func (p *Promise[T]) Future() *Future[T] {
+	return p.future
+}
+
+// Resolve settles the Future with value. It reports whether this call
+// settled the Future; calls after the first settlement have no effect.
+func (p *Promise[T]) Resolve(value T) bool {
+	return p.settle(value, nil)
+}
+
+// Reject settles the Future with err. It reports whether this call settled
+// the Future; calls after the first settlement have no effect. A nil error
+// settles the Future with the zero value of T.
+func (p *Promise[T]) Reject(err error) bool {
+	var zero T
+	return p.settle(zero, err)
+}
+
+// settle records the outcome of the Future, wakes its waiters and runs its
+// callbacks in the calling goroutine.
+func (p *Promise[T]) settle(value T, err error) bool {
+	f := p.future
+	f.mu.Lock()
+	if f.settled {
+		f.mu.Unlock()
+		return false
+	}
+	f.settled = true
+	if err != nil {
+		f.err = err
+	} else {
+		f.value = value
+	}
+	callbacks := f.callbacks
+	f.callbacks = nil
+	f.mu.Unlock()
+	close(f.done)
+	for _, fn := range callbacks {
+		fn(f.value, f.err)
+	}
+	return true
+}
+
+// Await waits for the Future to settle and returns its outcome, or returns
+// ctx's error if ctx is done first.
+//
+// Parameters:
+//   - ctx: The context bounding the wait.
+//
+// Returns:
+//   - T: The resolved value, or the zero value of T.
+//   - error: The rejection error, or ctx's error.
+func (f *Future[T]) Await(ctx context.Context) (T, error) {
+	select {
+	case <-f.done:
+		return f.value, f.err
+	default:
+	}
+	select {
+	case <-f.done:
+		return f.value, f.err
+	case <-ctx.Done():
+		var zero T
+		return zero, ctx.Err()
+	}
+}
+
+// Done returns a channel that is closed once the Future settles, for use in
+// select statements.
+func (f *Future[T]) Done() <-chan struct{} {
+	return f.done
+}
+
+// Cancel asks the computation behind the Future to stop by cancelling the
+// context passed to it by Go. It has no effect on a Future created with New
+// or on one that has already settled. A cancelled computation still settles
+// the Future, typically with the context's error.
+func (f *Future[T]) Cancel() {
+	if f.cancel != nil {
+		f.cancel()
+	}
+}
+
+// Then registers fn to be called with the outcome of the Future. If the
+// Future has already settled, fn is called immediately in the calling
+// goroutine; otherwise it is called by the goroutine that settles the
+// Future, in registration order. Callbacks should not block.
+func (f *Future[T]) Then(fn func(value T, err error)) {
+	f.mu.Lock()
+	if !f.settled {
+		f.callbacks = append(f.callbacks, fn)
+		f.mu.Unlock()
+		return
+	}
+	f.mu.Unlock()
+	fn(f.value, f.err)
+}
@@ -0,0 +1,137 @@
+package future
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestPromise_Resolve(t *testing.T) {
+	// Arrange
+	p, f := New[int]()
+
+	// Act
+	settled := p.Resolve(42)
+	again := p.Resolve(7)
+
+	// Assert
+	if !settled || again {
+		t.Errorf("expected only the first Resolve to settle, got %v and %v", settled, again)
+	}
+	value, err := f.Await(context.Background())
+	if err != nil || value != 42 {
+		t.Errorf("expected (42, nil), got (%v, %v)", value, err)
+	}
+}
+
+func TestPromise_Reject(t *testing.T) {
+	// Arrange
+	p, f := New[string]()
+	cause := errors.New("boom")
+
+	// Act
+	p.Reject(cause)
+
+	// Assert
+	if _, err := f.Await(context.Background()); !errors.Is(err, cause) {
+		t.Errorf("expected error %v, got %v", cause, err)
+	}
+	if p.Future() != f {
+		t.Error("expected Future to return the promise's future")
+	}
+}
+
+func TestFuture_AwaitCancelled(t *testing.T) {
+	// Arrange
+	_, f := New[int]()
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	// Act
+	_, err := f.Await(ctx)
+
+	// Assert
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected context.DeadlineExceeded, got %v", err)
+	}
+}
+
+func TestGo(t *testing.T) {
+	// Act
+	f := Go(context.Background(), func(context.Context) (string, error) { return "done", nil })
+
+	// Assert
+	value, err := f.Await(context.Background())
+	if err != nil || value != "done" {
+		t.Errorf("expected (done, nil), got (%q, %v)", value, err)
+	}
+	select {
+	case <-f.Done():
+	default:
+		t.Error("expected Done to be closed once settled")
+	}
+}
+
+func TestFuture_ThenBeforeAndAfterSettling(t *testing.T) {
+	// Arrange
+	p, f := New[int]()
+	var got []int
+	f.Then(func(v int, _ error) { got = append(got, v) })
+	f.Then(func(v int, _ error) { got = append(got, v+1) })
+
+	// Act
+	p.Resolve(10)
+	f.Then(func(v int, _ error) { got = append(got, v+2) })
+
+	// Assert
+	if len(got) != 3 || got[0] != 10 || got[1] != 11 || got[2] != 12 {
+		t.Errorf("expected callbacks [10 11 12] in order, got %v", got)
+	}
+}
+
+func TestFuture_ThenRejected(t *testing.T) {
+	// Arrange
+	p, f := New[int]()
+	cause := errors.New("boom")
+	var got error
+	f.Then(func(_ int, err error) { got = err })
+
+	// Act
+	p.Reject(cause)
+
+	// Assert
+	if !errors.Is(got, cause) {
+		t.Errorf("expected callback error %v, got %v", cause, got)
+	}
+}
+
+func TestFuture_Cancel(t *testing.T) {
+	// Arrange
+	f := Go(context.Background(), func(ctx context.Context) (int, error) {
+		<-ctx.Done()
+		return 0, ctx.Err()
+	})
+
+	// Act
+	f.Cancel()
+
+	// Assert
+	if _, err := f.Await(context.Background()); !errors.Is(err, context.Canceled) {
+		t.Errorf("expected the computation to observe cancellation, got %v", err)
+	}
+}
+
+func TestFuture_CancelPromiseBacked(t *testing.T) {
+	// Arrange
+	p, f := New[int]()
+
+	// Act
+	f.Cancel()
+	p.Resolve(1)
+
+	// Assert
+	if value, err := f.Await(context.Background()); err != nil || value != 1 {
+		t.Errorf("expected Cancel to have no effect, got (%v, %v)", value, err)
+	}
+}
@@ -0,0 +1,51 @@
+// Package genericx provides small generic helpers for zero values and
+// conditional selection that the language does not offer built in.
+package genericx
+
+// Zero returns the zero value of T.
+//
+// Example:
+//
+//	if err != nil {
+//		return genericx.Zero[T](), err
+//	}
+func Zero[T any]() T {
+	var zero T
+	return zero
+}
+
+// IsZero reports whether v is the zero value of T.
+func IsZero[T comparable](v T) bool {
+	var zero T
+	return v == zero
+}
+
+// Coalesce returns the first of vs that is not the zero value of T, or the
+// zero value if all are zero or vs is empty.
+//
+// Example:
+//
+//	addr := genericx.Coalesce(flagAddr, os.Getenv("ADDR"), ":8080")
+func Coalesce[T comparable](vs ...T) T {
+	var zero T
+	for _, v := range vs {
+		if v != zero {
+			return v
+		}
+	}
+	return zero
+}
+
+// If returns a if cond is true and b otherwise. Both arguments are
+// evaluated before the call, so it is not a substitute for an if statement
+// when either is expensive or has side effects.
+//
+// Example:
+//
+//	label := genericx.If(n == 1, "item", "items")
+func If[T any](cond bool, a, b T) T {
+	if cond {
+		return a
+	}
+	return b
+}
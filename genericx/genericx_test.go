@@ -0,0 +1,70 @@
+package genericx
+
+import (
+	"testing"
+	"time"
+)
+
+func TestZero(t *testing.T) {
+	// Act
+	i, s, p, d := Zero[int](), Zero[string](), Zero[*int](), Zero[time.Duration]()
+
+	// Assert
+	if i != 0 || s != "" || p != nil || d != 0 {
+		t.Errorf("expected zero values, got %v, %q, %v, %v", i, s, p, d)
+	}
+}
+
+func TestIsZero(t *testing.T) {
+	type point struct{ X, Y int }
+	tests := []struct {
+		name     string
+		got      bool
+		expected bool
+	}{
+		{"zero int", IsZero(0), true},
+		{"non-zero int", IsZero(1), false},
+		{"empty string", IsZero(""), true},
+		{"non-empty string", IsZero("x"), false},
+		{"zero struct", IsZero(point{}), true},
+		{"non-zero struct", IsZero(point{Y: 1}), false},
+		{"nil pointer", IsZero[*int](nil), true},
+	}
+	for _, tt := range tests {
+		if tt.got != tt.expected {
+			t.Errorf("%s: expected %v, got %v", tt.name, tt.expected, tt.got)
+		}
+	}
+}
+
+func TestCoalesce(t *testing.T) {
+	tests := []struct {
+		name     string
+		values   []string
+		expected string
+	}{
+		{"first non-zero", []string{"", "a", "b"}, "a"},
+		{"leading value", []string{"a", ""}, "a"},
+		{"all zero", []string{"", ""}, ""},
+		{"empty", nil, ""},
+	}
+	for _, tt := range tests {
+		// Act
+		got := Coalesce(tt.values...)
+
+		// Assert
+		if got != tt.expected {
+			t.Errorf("%s: expected %q, got %q", tt.name, tt.expected, got)
+		}
+	}
+}
+
+func TestIf(t *testing.T) {
+	// Act
+	yes, no := If(true, "a", "b"), If(false, 1, 2)
+
+	// Assert
+	if yes != "a" || no != 2 {
+		t.Errorf("expected a and 2, got %q and %d", yes, no)
+	}
+}
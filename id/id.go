@@ -0,0 +1,227 @@
+// Package id generates lexicographically sortable, collision-resistant
+// identifiers in the style of ULIDs: a 48-bit millisecond timestamp
+// followed by 80 random bits, written as 26 Crockford base32 characters.
+// They suit mutex and registry keys and correlation IDs.
+package id
+
+import (
+	"crypto/rand"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/zodimo/go-zbase-std/clock"
+	"github.com/zodimo/go-zbase-std/errcode"
+)
+
+// InvalidError is returned when parsing a string that is not an ID.
+var InvalidError = errcode.New(errcode.Invalid, "id: invalid ID")
+
+// OverflowError is returned by Generator.New when more IDs were requested
+// within one millisecond than the random bits can order.
+var OverflowError = errcode.New(errcode.Unavailable, "id: too many IDs in one millisecond")
+
+// encodedLen is the length of an ID's string form.
+const encodedLen = 26
+
+// alphabet is Crockford's base32 alphabet.
+const alphabet = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+
+// maxTime is the largest timestamp an ID can hold, in Unix milliseconds.
+const maxTime = 1<<48 - 1
+
+// ID is a 128-bit identifier: a big-endian 48-bit Unix millisecond
+// timestamp followed by 80 random bits. IDs compare, and their strings
+// sort, in order of creation. The zero value is the nil ID.
+type ID [16]byte
+
+// Generator creates IDs. IDs created within the same millisecond have
+// increasing random parts, so a Generator's IDs are strictly increasing.
+// It is safe for concurrent use.
+type Generator struct {
+	mu       sync.Mutex
+	lastTime uint64
+	last     ID
+
+	clock   clock.Clock
+	entropy io.Reader
+}
+
+// defaultGenerator backs New and String.
+var defaultGenerator = NewGenerator()
+
+// NewGenerator creates a Generator configured by opts. Without options it
+// uses the system clock and crypto/rand.
+//
+// Example:
+//
+//	gen := id.NewGenerator(id.WithClock(fakeClock))
+//	key, err := gen.New()
+func NewGenerator(opts ...Option) *Generator {
+	g := &Generator{
+		clock:   clock.System(),
+		entropy: rand.Reader,
+	}
+	for _, opt := range opts {
+		opt(g)
+	}
+	return g
+}
+
+// New creates an ID stamped with the generator's current time.
+//
+// Returns:
+//   - ID: The new ID, greater than every ID the generator created before.
+//   - error: The error from the entropy source; OverflowError if the
+//     random part could not be incremented within the millisecond.
+func (g *Generator) New() (ID, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	ms := uint64(g.clock.Now().UnixMilli())
+	if ms <= g.lastTime && g.lastTime != 0 {
+		// Same millisecond, or the clock went backwards: keep the last
+		// timestamp and increment the random part to stay monotonic.
+		next := g.last
+		if !increment(next[6:]) {
+			return ID{}, OverflowError
+		}
+		g.last = next
+		return next, nil
+	}
+	var id ID
+	putTime(&id, ms)
+	if _, err := io.ReadFull(g.entropy, id[6:]); err != nil {
+		return ID{}, fmt.Errorf("id: reading entropy: %w", err)
+	}
+	g.lastTime, g.last = ms, id
+	return id, nil
+}
+
+// New creates an ID with the default generator, which uses the system
+// clock and crypto/rand. It panics if the random part overflows, which
+// takes about 2^80 IDs in one millisecond.
+func New() ID {
+	id, err := defaultGenerator.New()
+	if err != nil {
+		panic(err)
+	}
+	return id
+}
+
+// String creates an ID with the default generator and returns its string
+// form.
+func String() string {
+	return New().String()
+}
+
+// Parse parses the 26-character string form of an ID. It accepts lower
+// case and Crockford's aliases: I and L for 1, O for 0.
+//
+// Returns:
+//   - ID: The parsed ID.
+//   - error: InvalidError if s is not an ID.
+func Parse(s string) (ID, error) {
+	if len(s) != encodedLen {
+		return ID{}, fmt.Errorf("%w: %q has length %d", InvalidError, s, len(s))
+	}
+	var hi, lo uint64
+	for i := range encodedLen {
+		v := decodeChar(s[i])
+		if v < 0 || (i == 0 && v > 7) {
+			return ID{}, fmt.Errorf("%w: %q", InvalidError, s)
+		}
+		hi = hi<<5 | lo>>59
+		lo = lo<<5 | uint64(v)
+	}
+	var id ID
+	for i := range 8 {
+		id[i] = byte(hi >> (56 - 8*i))
+		id[8+i] = byte(lo >> (56 - 8*i))
+	}
+	return id, nil
+}
+
+// String returns the 26-character Crockford base32 form of the ID.
+func (id ID) String() string {
+	var hi, lo uint64
+	for i := range 8 {
+		hi = hi<<8 | uint64(id[i])
+		lo = lo<<8 | uint64(id[8+i])
+	}
+	var buf [encodedLen]byte
+	for i := encodedLen - 1; i >= 0; i-- {
+		buf[i] = alphabet[lo&31]
+		lo = lo>>5 | hi<<59
+		hi >>= 5
+	}
+	return string(buf[:])
+}
+
+// Time returns the timestamp of the ID.
+func (id ID) Time() time.Time {
+	var ms uint64
+	for _, b := range id[:6] {
+		ms = ms<<8 | uint64(b)
+	}
+	return time.UnixMilli(int64(ms))
+}
+
+// IsZero reports whether id is the nil ID.
+func (id ID) IsZero() bool {
+	return id == ID{}
+}
+
+// MarshalText implements encoding.TextMarshaler with the string form.
+func (id ID) MarshalText() ([]byte, error) {
+	return []byte(id.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler with Parse.
+func (id *ID) UnmarshalText(text []byte) error {
+	parsed, err := Parse(string(text))
+	if err != nil {
+		return err
+	}
+	*id = parsed
+	return nil
+}
+
+// putTime stores ms, truncated to 48 bits, in the timestamp of id.
+func putTime(id *ID, ms uint64) {
+	ms = min(ms, maxTime)
+	for i := range 6 {
+		id[i] = byte(ms >> (40 - 8*i))
+	}
+}
+
+// increment adds one to the big-endian number in b, reporting false if it
+// overflowed.
+func increment(b []byte) bool {
+	for i := len(b) - 1; i >= 0; i-- {
+		b[i]++
+		if b[i] != 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// decodeChar returns the value of a base32 character, or -1.
+func decodeChar(c byte) int {
+	if c >= 'a' && c <= 'z' {
+		c -= 'a' - 'A'
+	}
+	switch c {
+	case 'I', 'L':
+		return 1
+	case 'O':
+		return 0
+	}
+	for v := range len(alphabet) {
+		if alphabet[v] == c {
+			return v
+		}
+	}
+	return -1
+}
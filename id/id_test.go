@@ -0,0 +1,131 @@
+package id
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/zodimo/go-zbase-std/clock"
+)
+
+func TestGenerator_NewIsMonotonic(t *testing.T) {
+	// Arrange
+	fake := clock.NewFakeClock(time.UnixMilli(1_700_000_000_000))
+	g := NewGenerator(WithClock(fake))
+
+	// Act
+	first, _ := g.New()
+	second, _ := g.New()
+	fake.Advance(time.Millisecond)
+	third, _ := g.New()
+
+	// Assert
+	if !(first.String() < second.String() && second.String() < third.String()) {
+		t.Errorf("expected increasing IDs, got %s, %s, %s", first, second, third)
+	}
+	if !first.Time().Equal(time.UnixMilli(1_700_000_000_000)) || !third.Time().Equal(time.UnixMilli(1_700_000_000_001)) {
+		t.Errorf("expected the clock's timestamps, got %v and %v", first.Time(), third.Time())
+	}
+}
+
+func TestGenerator_ClockGoingBackwardsStaysMonotonic(t *testing.T) {
+	// Arrange
+	fake := clock.NewFakeClock(time.UnixMilli(1_700_000_000_000))
+	g := NewGenerator(WithClock(fake))
+	first, _ := g.New()
+
+	// Act
+	fake.Set(time.UnixMilli(1_600_000_000_000))
+	second, _ := g.New()
+
+	// Assert
+	if second.String() <= first.String() {
+		t.Errorf("expected %s to sort after %s", second, first)
+	}
+}
+
+func TestGenerator_Overflow(t *testing.T) {
+	// Arrange
+	fake := clock.NewFakeClock(time.UnixMilli(1))
+	g := NewGenerator(WithClock(fake), WithEntropy(bytes.NewReader(bytes.Repeat([]byte{0xff}, 10))))
+	_, _ = g.New()
+
+	// Act
+	_, err := g.New()
+
+	// Assert
+	if !errors.Is(err, OverflowError) {
+		t.Errorf("expected OverflowError, got %v", err)
+	}
+}
+
+func TestGenerator_EntropyError(t *testing.T) {
+	// Arrange
+	g := NewGenerator(WithEntropy(strings.NewReader("")))
+
+	// Act
+	_, err := g.New()
+
+	// Assert
+	if err == nil {
+		t.Error("expected the entropy error")
+	}
+}
+
+func TestParse_RoundTrip(t *testing.T) {
+	// Arrange
+	original := New()
+
+	// Act
+	parsed, err := Parse(strings.ToLower(original.String()))
+
+	// Assert
+	if err != nil || parsed != original {
+		t.Errorf("expected %s, got %s, %v", original, parsed, err)
+	}
+	if len(original.String()) != 26 {
+		t.Errorf("expected 26 characters, got %d", len(original.String()))
+	}
+}
+
+func TestParse_Known(t *testing.T) {
+	// Arrange
+	largest := ID{0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff}
+
+	// Act
+	encoded := largest.String()
+	zero := ID{}.String()
+
+	// Assert
+	if encoded != "7ZZZZZZZZZZZZZZZZZZZZZZZZZ" || zero != "00000000000000000000000000" {
+		t.Errorf("expected the maximum and zero encodings, got %s and %s", encoded, zero)
+	}
+}
+
+func TestParse_Invalid(t *testing.T) {
+	for _, s := range []string{"", "short", "8ZZZZZZZZZZZZZZZZZZZZZZZZZ", "01ARZ3NDEKTSV4RRFFQ69G5FA!"} {
+		t.Run(s, func(t *testing.T) {
+			if _, err := Parse(s); !errors.Is(err, InvalidError) {
+				t.Errorf("expected InvalidError, got %v", err)
+			}
+		})
+	}
+}
+
+func TestID_JSON(t *testing.T) {
+	// Arrange
+	original := New()
+
+	// Act
+	data, _ := json.Marshal(original)
+	var decoded ID
+	err := json.Unmarshal(data, &decoded)
+
+	// Assert
+	if err != nil || decoded != original {
+		t.Errorf("expected %s, got %s, %v", original, decoded, err)
+	}
+}
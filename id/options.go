@@ -0,0 +1,26 @@
+package id
+
+import (
+	"io"
+
+	"github.com/zodimo/go-zbase-std/clock"
+)
+
+// Option configures a Generator created by NewGenerator.
+type Option func(*Generator)
+
+// WithClock makes the generator read timestamps from the given clock
+// instead of the system clock.
+func WithClock(c clock.Clock) Option {
+	return func(g *Generator) {
+		g.clock = c
+	}
+}
+
+// WithEntropy makes the generator read random bits from r instead of
+// crypto/rand.
+func WithEntropy(r io.Reader) Option {
+	return func(g *Generator) {
+		g.entropy = r
+	}
+}
@@ -0,0 +1,95 @@
+// Package immutable provides persistent collections: every modification
+// returns a new collection that shares structure with the original, which
+// is left unchanged. Values can therefore be published to concurrent
+// readers, for example through an atomic.Pointer, and read without locking,
+// complementing the mutex package for read-mostly state.
+package immutable
+
+import (
+	"iter"
+
+	"github.com/zodimo/go-zbase-std/optional"
+)
+
+// List is a persistent singly-linked list. Prepend and Tail are O(1) and
+// share the rest of the list. The zero value is an empty list.
+type List[T any] struct {
+	head *listNode[T]
+	len  int
+}
+
+// listNode is a cell of a List. Nodes are never modified once created.
+type listNode[T any] struct {
+	value T
+	next  *listNode[T]
+}
+
+// ListOf creates a list holding items in order.
+//
+// Example:
+//
+//	base := immutable.ListOf(2, 3)
+//	extended := base.Prepend(1) // [1 2 3]; base is still [2 3]
+func ListOf[T any](items ...T) List[T] {
+	var l List[T]
+	for i := len(items) - 1; i >= 0; i-- {
+		l = l.Prepend(items[i])
+	}
+	return l
+}
+
+// Prepend returns a list with value in front of the values of l.
+func (l List[T]) Prepend(value T) List[T] {
+	return List[T]{head: &listNode[T]{value: value, next: l.head}, len: l.len + 1}
+}
+
+// Head returns the first value, or None if the list is empty.
+func (l List[T]) Head() optional.Option[T] {
+	if l.head == nil {
+		return optional.None[T]()
+	}
+	return optional.Some(l.head.value)
+}
+
+// Tail returns the list without its first value. The tail of an empty list
+// is empty.
+func (l List[T]) Tail() List[T] {
+	if l.head == nil {
+		return l
+	}
+	return List[T]{head: l.head.next, len: l.len - 1}
+}
+
+// Len returns the number of values in the list.
+func (l List[T]) Len() int {
+	return l.len
+}
+
+// All returns an iterator over the values in order.
+func (l List[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for n := l.head; n != nil; n = n.next {
+			if !yield(n.value) {
+				return
+			}
+		}
+	}
+}
+
+// Reverse returns a list holding the values of l in reverse order.
+func (l List[T]) Reverse() List[T] {
+	var reversed List[T]
+	for value := range l.All() {
+		reversed = reversed.Prepend(value)
+	}
+	return reversed
+}
+
+// Slice returns the values in order as a new slice.
+func (l List[T]) Slice() []T {
+	out := make([]T, 0, l.len)
+	for value := range l.All() {
+		out = append(out, value)
+	}
+	return out
+}
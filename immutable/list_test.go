@@ -0,0 +1,63 @@
+package immutable
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestList_PrependSharesTail(t *testing.T) {
+	// Arrange
+	base := ListOf(2, 3)
+
+	// Act
+	extended := base.Prepend(1)
+
+	// Assert
+	if got := extended.Slice(); !slices.Equal(got, []int{1, 2, 3}) {
+		t.Errorf("expected [1 2 3], got %v", got)
+	}
+	if got := base.Slice(); !slices.Equal(got, []int{2, 3}) {
+		t.Errorf("expected base to stay [2 3], got %v", got)
+	}
+	if extended.Tail().head != base.head {
+		t.Error("expected the extended list to share the base list")
+	}
+}
+
+func TestList_HeadAndTail(t *testing.T) {
+	// Arrange
+	var empty List[string]
+	l := ListOf("a", "b")
+
+	// Act
+	head := l.Head()
+	emptyHead := empty.Head()
+	rest := l.Tail().Tail().Tail()
+
+	// Assert
+	if v, ok := head.Value(); !ok || v != "a" {
+		t.Errorf("expected Some(a), got %v, %v", v, ok)
+	}
+	if _, ok := emptyHead.Value(); ok {
+		t.Error("expected None from an empty list")
+	}
+	if rest.Len() != 0 {
+		t.Errorf("expected the tail of an empty list to be empty, got length %d", rest.Len())
+	}
+}
+
+func TestList_Reverse(t *testing.T) {
+	// Arrange
+	l := ListOf(1, 2, 3)
+
+	// Act
+	reversed := l.Reverse()
+
+	// Assert
+	if got := reversed.Slice(); !slices.Equal(got, []int{3, 2, 1}) {
+		t.Errorf("expected [3 2 1], got %v", got)
+	}
+	if reversed.Len() != 3 {
+		t.Errorf("expected length 3, got %d", reversed.Len())
+	}
+}
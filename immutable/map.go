@@ -0,0 +1,302 @@
+package immutable
+
+import (
+	"hash/maphash"
+	"iter"
+	"math/bits"
+
+	"github.com/zodimo/go-zbase-std/optional"
+)
+
+// Trie shape: each level consumes hashBits bits of a key's hash.
+const (
+	hashBits = 5
+	hashMask = 1<<hashBits - 1
+)
+
+// hashSeed is shared by all maps so that maps derived from each other agree
+// on where keys live.
+var hashSeed = maphash.MakeSeed()
+
+// Map is a persistent hash map implemented as a hash array mapped trie. Set
+// and Delete copy only the path to the changed key, O(log32 n) nodes, and
+// share the rest with the original map. The zero value is an empty map.
+type Map[K comparable, V any] struct {
+	root *mapNode[K, V]
+	len  int
+}
+
+// mapNode is an interior node of the trie. The bitmap records which of the
+// 32 slots are occupied; children holds the occupied slots in order. Nodes
+// are never modified once published.
+type mapNode[K comparable, V any] struct {
+	bitmap   uint32
+	children []mapChild[K, V]
+}
+
+// mapChild is either a subtree or a leaf; exactly one field is set.
+type mapChild[K comparable, V any] struct {
+	node *mapNode[K, V]
+	leaf *mapLeaf[K, V]
+}
+
+// mapLeaf holds the entries whose keys share a full hash; more than one
+// entry means the hashes collided.
+type mapLeaf[K comparable, V any] struct {
+	hash    uint64
+	entries []mapEntry[K, V]
+}
+
+// mapEntry is a key-value pair stored in a leaf.
+type mapEntry[K comparable, V any] struct {
+	key   K
+	value V
+}
+
+// NewMap creates an empty map.
+//
+// Example:
+//
+//	routes := immutable.NewMap[string, Handler]()
+//	next := routes.Set("/health", health) // routes is unchanged
+//	current.Store(&next)                  // Publish to lock-free readers
+func NewMap[K comparable, V any]() Map[K, V] {
+	return Map[K, V]{}
+}
+
+// Get returns the value stored under key, or None if the key is absent.
+func (m Map[K, V]) Get(key K) optional.Option[V] {
+	if value, ok := m.lookup(key); ok {
+		return optional.Some(value)
+	}
+	return optional.None[V]()
+}
+
+// Has reports whether key is present.
+func (m Map[K, V]) Has(key K) bool {
+	_, ok := m.lookup(key)
+	return ok
+}
+
+// lookup returns the value stored under key and whether it was found.
+func (m Map[K, V]) lookup(key K) (V, bool) {
+	h := maphash.Comparable(hashSeed, key)
+	for n, shift := m.root, 0; n != nil; shift += hashBits {
+		bit := uint32(1) << (h >> shift & hashMask)
+		if n.bitmap&bit == 0 {
+			break
+		}
+		child := n.children[n.position(bit)]
+		if child.node != nil {
+			n = child.node
+			continue
+		}
+		if child.leaf.hash == h {
+			for _, e := range child.leaf.entries {
+				if e.key == key {
+					return e.value, true
+				}
+			}
+		}
+		break
+	}
+	var zero V
+	return zero, false
+}
+
+// Set returns a map with value stored under key.
+func (m Map[K, V]) Set(key K, value V) Map[K, V] {
+	return m.set(maphash.Comparable(hashSeed, key), key, value)
+}
+
+// set is Set with the hash of key supplied by the caller.
+func (m Map[K, V]) set(h uint64, key K, value V) Map[K, V] {
+	root := m.root
+	if root == nil {
+		root = &mapNode[K, V]{}
+	}
+	root, added := root.set(h, 0, key, value)
+	if added {
+		return Map[K, V]{root: root, len: m.len + 1}
+	}
+	return Map[K, V]{root: root, len: m.len}
+}
+
+// Delete returns a map without key. If key is absent, m is returned.
+func (m Map[K, V]) Delete(key K) Map[K, V] {
+	return m.delete(maphash.Comparable(hashSeed, key), key)
+}
+
+// delete is Delete with the hash of key supplied by the caller.
+func (m Map[K, V]) delete(h uint64, key K) Map[K, V] {
+	if m.root == nil {
+		return m
+	}
+	root, removed := m.root.delete(h, 0, key)
+	if !removed {
+		return m
+	}
+	return Map[K, V]{root: root, len: m.len - 1}
+}
+
+// Len returns the number of entries.
+func (m Map[K, V]) Len() int {
+	return m.len
+}
+
+// All returns an iterator over the entries in an unspecified order.
+func (m Map[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		if m.root != nil {
+			m.root.all(yield)
+		}
+	}
+}
+
+// position returns the index in children of the slot selected by bit.
+func (n *mapNode[K, V]) position(bit uint32) int {
+	return bits.OnesCount32(n.bitmap & (bit - 1))
+}
+
+// set returns a copy of n with key stored, and whether the key is new.
+func (n *mapNode[K, V]) set(h uint64, shift int, key K, value V) (*mapNode[K, V], bool) {
+	bit := uint32(1) << (h >> shift & hashMask)
+	pos := n.position(bit)
+	if n.bitmap&bit == 0 {
+		leaf := &mapLeaf[K, V]{hash: h, entries: []mapEntry[K, V]{{key, value}}}
+		return n.inserted(bit, pos, mapChild[K, V]{leaf: leaf}), true
+	}
+	child := n.children[pos]
+	if child.node != nil {
+		node, added := child.node.set(h, shift+hashBits, key, value)
+		return n.replaced(pos, mapChild[K, V]{node: node}), added
+	}
+	if child.leaf.hash != h {
+		leaf := &mapLeaf[K, V]{hash: h, entries: []mapEntry[K, V]{{key, value}}}
+		node := split(child.leaf, leaf, shift+hashBits)
+		return n.replaced(pos, mapChild[K, V]{node: node}), true
+	}
+	entries := make([]mapEntry[K, V], len(child.leaf.entries), len(child.leaf.entries)+1)
+	copy(entries, child.leaf.entries)
+	added := true
+	for i := range entries {
+		if entries[i].key == key {
+			entries[i].value = value
+			added = false
+			break
+		}
+	}
+	if added {
+		entries = append(entries, mapEntry[K, V]{key, value})
+	}
+	return n.replaced(pos, mapChild[K, V]{leaf: &mapLeaf[K, V]{hash: h, entries: entries}}), added
+}
+
+// split returns a node holding two leaves with different hashes, nesting
+// further while their hashes agree at shift.
+func split[K comparable, V any](a, b *mapLeaf[K, V], shift int) *mapNode[K, V] {
+	ia, ib := a.hash>>shift&hashMask, b.hash>>shift&hashMask
+	if ia == ib {
+		return &mapNode[K, V]{
+			bitmap:   1 << ia,
+			children: []mapChild[K, V]{{node: split(a, b, shift+hashBits)}},
+		}
+	}
+	if ia > ib {
+		a, b, ia, ib = b, a, ib, ia
+	}
+	return &mapNode[K, V]{
+		bitmap:   1<<ia | 1<<ib,
+		children: []mapChild[K, V]{{leaf: a}, {leaf: b}},
+	}
+}
+
+// delete returns a copy of n without key, or nil if the copy would be
+// empty, and whether the key was present.
+func (n *mapNode[K, V]) delete(h uint64, shift int, key K) (*mapNode[K, V], bool) {
+	bit := uint32(1) << (h >> shift & hashMask)
+	if n.bitmap&bit == 0 {
+		return n, false
+	}
+	pos := n.position(bit)
+	child := n.children[pos]
+	if child.node != nil {
+		node, removed := child.node.delete(h, shift+hashBits, key)
+		switch {
+		case !removed:
+			return n, false
+		case node == nil:
+			return n.removed(bit, pos), true
+		case len(node.children) == 1 && node.children[0].leaf != nil:
+			// Pull a lone leaf up so the trie stays as shallow as possible.
+			return n.replaced(pos, node.children[0]), true
+		default:
+			return n.replaced(pos, mapChild[K, V]{node: node}), true
+		}
+	}
+	if child.leaf.hash != h {
+		return n, false
+	}
+	for i, e := range child.leaf.entries {
+		if e.key != key {
+			continue
+		}
+		if len(child.leaf.entries) == 1 {
+			return n.removed(bit, pos), true
+		}
+		entries := make([]mapEntry[K, V], 0, len(child.leaf.entries)-1)
+		entries = append(entries, child.leaf.entries[:i]...)
+		entries = append(entries, child.leaf.entries[i+1:]...)
+		return n.replaced(pos, mapChild[K, V]{leaf: &mapLeaf[K, V]{hash: h, entries: entries}}), true
+	}
+	return n, false
+}
+
+// inserted returns a copy of n with child occupying the slot selected by
+// bit.
+func (n *mapNode[K, V]) inserted(bit uint32, pos int, child mapChild[K, V]) *mapNode[K, V] {
+	children := make([]mapChild[K, V], 0, len(n.children)+1)
+	children = append(children, n.children[:pos]...)
+	children = append(children, child)
+	children = append(children, n.children[pos:]...)
+	return &mapNode[K, V]{bitmap: n.bitmap | bit, children: children}
+}
+
+// replaced returns a copy of n with the child at pos replaced.
+func (n *mapNode[K, V]) replaced(pos int, child mapChild[K, V]) *mapNode[K, V] {
+	children := make([]mapChild[K, V], len(n.children))
+	copy(children, n.children)
+	children[pos] = child
+	return &mapNode[K, V]{bitmap: n.bitmap, children: children}
+}
+
+// removed returns a copy of n without the slot selected by bit, or nil if
+// the copy would be empty.
+func (n *mapNode[K, V]) removed(bit uint32, pos int) *mapNode[K, V] {
+	if len(n.children) == 1 {
+		return nil
+	}
+	children := make([]mapChild[K, V], 0, len(n.children)-1)
+	children = append(children, n.children[:pos]...)
+	children = append(children, n.children[pos+1:]...)
+	return &mapNode[K, V]{bitmap: n.bitmap &^ bit, children: children}
+}
+
+// all yields the entries of the subtree rooted at n, reporting whether the
+// iteration should continue.
+func (n *mapNode[K, V]) all(yield func(K, V) bool) bool {
+	for _, child := range n.children {
+		if child.node != nil {
+			if !child.node.all(yield) {
+				return false
+			}
+			continue
+		}
+		for _, e := range child.leaf.entries {
+			if !yield(e.key, e.value) {
+				return false
+			}
+		}
+	}
+	return true
+}
@@ -0,0 +1,150 @@
+package immutable
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestMap_SetLeavesOriginalUnchanged(t *testing.T) {
+	// Arrange
+	base := NewMap[string, int]().Set("a", 1)
+
+	// Act
+	updated := base.Set("a", 10).Set("b", 2)
+
+	// Assert
+	if v, _ := base.lookup("a"); v != 1 || base.Has("b") || base.Len() != 1 {
+		t.Errorf("expected base to stay {a:1}, got a=%d len=%d", v, base.Len())
+	}
+	if v, _ := updated.lookup("a"); v != 10 || updated.Len() != 2 {
+		t.Errorf("expected updated {a:10 b:2}, got a=%d len=%d", v, updated.Len())
+	}
+}
+
+func TestMap_Get(t *testing.T) {
+	// Arrange
+	var m Map[int, string]
+	m = m.Set(1, "one")
+
+	// Act
+	present := m.Get(1)
+	absent := m.Get(2)
+
+	// Assert
+	if v, ok := present.Value(); !ok || v != "one" {
+		t.Errorf("expected Some(one), got %v, %v", v, ok)
+	}
+	if _, ok := absent.Value(); ok {
+		t.Error("expected None for an absent key")
+	}
+}
+
+func TestMap_ManyKeys(t *testing.T) {
+	// Arrange
+	var m Map[int, int]
+	const n = 5000
+
+	// Act
+	for i := range n {
+		m = m.Set(i, i*i)
+	}
+	for i := 0; i < n; i += 2 {
+		m = m.Delete(i)
+	}
+
+	// Assert
+	if m.Len() != n/2 {
+		t.Fatalf("expected %d entries, got %d", n/2, m.Len())
+	}
+	for i := range n {
+		v, ok := m.lookup(i)
+		if ok != (i%2 == 1) || (ok && v != i*i) {
+			t.Fatalf("unexpected entry for %d: %d, %v", i, v, ok)
+		}
+	}
+	count := 0
+	for k, v := range m.All() {
+		if v != k*k {
+			t.Fatalf("expected %d for key %d, got %d", k*k, k, v)
+		}
+		count++
+	}
+	if count != n/2 {
+		t.Errorf("expected All to yield %d entries, got %d", n/2, count)
+	}
+}
+
+func TestMap_HashCollisions(t *testing.T) {
+	// Arrange: force every key to the same hash
+	var m Map[string, int]
+	m = m.set(42, "a", 1).set(42, "b", 2).set(7, "c", 3)
+
+	// Act
+	replaced := m.set(42, "a", 10)
+	deleted := m.delete(42, "a")
+	missing := m.delete(42, "z")
+
+	// Assert
+	if m.Len() != 3 || replaced.Len() != 3 {
+		t.Errorf("expected 3 entries, got %d and %d", m.Len(), replaced.Len())
+	}
+	if deleted.Len() != 2 || missing.Len() != 3 {
+		t.Errorf("expected lengths 2 and 3 after deletes, got %d and %d", deleted.Len(), missing.Len())
+	}
+	got := map[string]int{}
+	for k, v := range replaced.All() {
+		got[k] = v
+	}
+	if got["a"] != 10 || got["b"] != 2 || got["c"] != 3 {
+		t.Errorf("expected {a:10 b:2 c:3}, got %v", got)
+	}
+}
+
+func TestMap_DeleteAbsent(t *testing.T) {
+	// Arrange
+	m := NewMap[string, int]().Set("a", 1)
+
+	// Act
+	same := m.Delete("b")
+	empty := m.Delete("a")
+
+	// Assert
+	if same.root != m.root {
+		t.Error("expected deleting an absent key to return the same map")
+	}
+	if empty.Len() != 0 || empty.Has("a") {
+		t.Errorf("expected an empty map, got length %d", empty.Len())
+	}
+}
+
+func TestMap_ConcurrentReaders(t *testing.T) {
+	// Arrange
+	var m Map[int, int]
+	for i := range 100 {
+		m = m.Set(i, i)
+	}
+	snapshot := m
+	var wg sync.WaitGroup
+
+	// Act: readers use the snapshot while the writer derives new maps
+	for range 4 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range 100 {
+				if v, ok := snapshot.lookup(i); !ok || v != i {
+					t.Errorf("expected %d, got %d, %v", i, v, ok)
+				}
+			}
+		}()
+	}
+	for i := range 100 {
+		m = m.Set(i, -i)
+	}
+	wg.Wait()
+
+	// Assert
+	if v, _ := snapshot.lookup(5); v != 5 {
+		t.Errorf("expected snapshot to keep 5, got %d", v)
+	}
+}
@@ -0,0 +1,94 @@
+// Package flight shares a single run of a fallible, context-aware function
+// between concurrent callers, for the packages that load values on demand.
+package flight
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// Group deduplicates concurrent calls by key. The zero value is ready to
+// use. It is safe for concurrent use.
+type Group[K comparable, T any] struct {
+	mu    sync.Mutex
+	calls map[K]*call[T] // Calls in flight.
+}
+
+// call is a single run of a function that concurrent callers wait on.
+type call[T any] struct {
+	done  chan struct{} // Closed when the function returns.
+	value T
+	err   error
+
+	// abandoned reports that the call failed because the context of the
+	// caller running it ended, so waiters should try again themselves.
+	abandoned bool
+}
+
+// Do runs fn with ctx unless a call for key is already in flight, in which
+// case it waits for that call's outcome or for ctx to be done, whichever
+// comes first. If the call in flight fails because the context of the
+// caller running it ended, Do runs fn again with ctx. If fn panics, the
+// callers sharing the call get an error naming what, and the panic is
+// propagated in the caller running fn.
+//
+// Parameters:
+//   - ctx: The context bounding the wait, passed to fn.
+//   - key: Identifies the calls to deduplicate.
+//   - what: Describes fn in panic errors, such as "cache: loader".
+//   - fn: The function to run.
+//
+// Returns:
+//   - T: The value returned by fn, or the zero value of T on failure.
+//   - error: fn's error, or ctx's error if ctx is done first.
+func (g *Group[K, T]) Do(ctx context.Context, key K, what string, fn func(context.Context) (T, error)) (T, error) {
+	var zero T
+	for {
+		g.mu.Lock()
+		c := g.calls[key]
+		if c == nil {
+			c = &call[T]{done: make(chan struct{})}
+			if g.calls == nil {
+				g.calls = make(map[K]*call[T])
+			}
+			g.calls[key] = c
+			g.mu.Unlock()
+			g.run(ctx, key, c, what, fn)
+		} else {
+			g.mu.Unlock()
+		}
+		select {
+		case <-c.done:
+		case <-ctx.Done():
+			return zero, ctx.Err()
+		}
+		if c.err == nil {
+			return c.value, nil
+		}
+		if c.abandoned && ctx.Err() == nil {
+			continue // The caller running it gave up; try again with ctx.
+		}
+		return zero, c.err
+	}
+}
+
+// run runs fn for call c and publishes its outcome. If fn panics, waiters
+// see an error and the panic is propagated.
+func (g *Group[K, T]) run(ctx context.Context, key K, c *call[T], what string, fn func(context.Context) (T, error)) {
+	defer func() {
+		r := recover()
+		if r != nil {
+			c.err = fmt.Errorf("%s panicked: %v", what, r)
+		}
+		g.mu.Lock()
+		delete(g.calls, key)
+		g.mu.Unlock()
+		close(c.done)
+		if r != nil {
+			panic(r)
+		}
+	}()
+	c.value, c.err = fn(ctx)
+	c.abandoned = c.err != nil && ctx.Err() != nil
+}
@@ -0,0 +1,81 @@
+package flight
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+func TestGroup_RetriesAbandonedCall(t *testing.T) {
+	// Arrange
+	var g Group[string, int]
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	var calls atomic.Int32
+	fn := func(ctx context.Context) (int, error) {
+		calls.Add(1)
+		if err := ctx.Err(); err != nil {
+			return 0, err
+		}
+		return 7, nil
+	}
+
+	// Act
+	_, abandonedErr := g.Do(ctx, "k", "test", fn)
+	value, err := g.Do(context.Background(), "k", "test", fn)
+
+	// Assert
+	if !errors.Is(abandonedErr, context.Canceled) {
+		t.Errorf("expected context.Canceled, got %v", abandonedErr)
+	}
+	if err != nil || value != 7 {
+		t.Errorf("expected 7, got %d, %v", value, err)
+	}
+}
+
+func TestGroup_PanicIsReportedAndPropagated(t *testing.T) {
+	// Arrange
+	var g Group[string, int]
+	var recovered any
+
+	// Act
+	func() {
+		defer func() { recovered = recover() }()
+		_, _ = g.Do(context.Background(), "k", "test: fn", func(context.Context) (int, error) {
+			panic("kaboom")
+		})
+	}()
+	value, err := g.Do(context.Background(), "k", "test: fn", func(context.Context) (int, error) {
+		return 1, nil
+	})
+
+	// Assert
+	if recovered != "kaboom" {
+		t.Errorf("expected the panic to propagate, got %v", recovered)
+	}
+	if err != nil || value != 1 {
+		t.Errorf("expected the group to recover for the next call, got %d, %v", value, err)
+	}
+}
+
+func TestGroup_RunPublishesPanicError(t *testing.T) {
+	// Arrange
+	var g Group[string, int]
+	c := &call[int]{done: make(chan struct{})}
+
+	// Act
+	func() {
+		defer func() { _ = recover() }()
+		g.run(context.Background(), "k", c, "test: fn", func(context.Context) (int, error) {
+			panic("kaboom")
+		})
+	}()
+
+	// Assert
+	<-c.done
+	if c.err == nil || !strings.Contains(c.err.Error(), "test: fn panicked: kaboom") {
+		t.Errorf("expected waiters to see the panic as an error, got %v", c.err)
+	}
+}
@@ -0,0 +1,190 @@
+// Package iterx provides lazy combinators over iter.Seq and iter.Seq2.
+// Every combinator returns a new sequence without consuming its input, so
+// stages can be chained and only do work as the final sequence is ranged
+// over.
+package iterx
+
+import (
+	"context"
+	"iter"
+	"maps"
+	"slices"
+)
+
+// Map returns a sequence yielding fn applied to each value of seq.
+//
+// Example:
+//
+//	names := iterx.Map(iterx.FromSlice(users), func(u User) string { return u.Name })
+func Map[T, U any](seq iter.Seq[T], fn func(T) U) iter.Seq[U] {
+	return func(yield func(U) bool) {
+		for v := range seq {
+			if !yield(fn(v)) {
+				return
+			}
+		}
+	}
+}
+
+// Map2 returns a sequence yielding fn applied to each pair of seq.
+func Map2[K, V, K2, V2 any](seq iter.Seq2[K, V], fn func(K, V) (K2, V2)) iter.Seq2[K2, V2] {
+	return func(yield func(K2, V2) bool) {
+		for k, v := range seq {
+			if !yield(fn(k, v)) {
+				return
+			}
+		}
+	}
+}
+
+// Filter returns a sequence yielding the values of seq for which keep
+// returns true.
+func Filter[T any](seq iter.Seq[T], keep func(T) bool) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for v := range seq {
+			if keep(v) && !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// Filter2 returns a sequence yielding the pairs of seq for which keep
+// returns true.
+func Filter2[K, V any](seq iter.Seq2[K, V], keep func(K, V) bool) iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for k, v := range seq {
+			if keep(k, v) && !yield(k, v) {
+				return
+			}
+		}
+	}
+}
+
+// Reduce folds the values of seq into an accumulator, starting from init.
+//
+// Example:
+//
+//	total := iterx.Reduce(iterx.FromSlice(orders), 0, func(sum int, o Order) int {
+//		return sum + o.Amount
+//	})
+func Reduce[T, A any](seq iter.Seq[T], init A, fn func(A, T) A) A {
+	acc := init
+	for v := range seq {
+		acc = fn(acc, v)
+	}
+	return acc
+}
+
+// Take returns a sequence yielding at most the first n values of seq.
+func Take[T any](seq iter.Seq[T], n int) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		if n <= 0 {
+			return
+		}
+		taken := 0
+		for v := range seq {
+			if !yield(v) {
+				return
+			}
+			if taken++; taken == n {
+				return
+			}
+		}
+	}
+}
+
+// Take2 returns a sequence yielding at most the first n pairs of seq.
+func Take2[K, V any](seq iter.Seq2[K, V], n int) iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		if n <= 0 {
+			return
+		}
+		taken := 0
+		for k, v := range seq {
+			if !yield(k, v) {
+				return
+			}
+			if taken++; taken == n {
+				return
+			}
+		}
+	}
+}
+
+// Skip returns a sequence yielding the values of seq after the first n.
+func Skip[T any](seq iter.Seq[T], n int) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		skipped := 0
+		for v := range seq {
+			if skipped < n {
+				skipped++
+				continue
+			}
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// Skip2 returns a sequence yielding the pairs of seq after the first n.
+func Skip2[K, V any](seq iter.Seq2[K, V], n int) iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		skipped := 0
+		for k, v := range seq {
+			if skipped < n {
+				skipped++
+				continue
+			}
+			if !yield(k, v) {
+				return
+			}
+		}
+	}
+}
+
+// Collect returns the values of seq as a new slice.
+func Collect[T any](seq iter.Seq[T]) []T {
+	return slices.Collect(seq)
+}
+
+// Collect2 returns the pairs of seq as a new map. Later pairs overwrite
+// earlier pairs with the same key.
+func Collect2[K comparable, V any](seq iter.Seq2[K, V]) map[K]V {
+	return maps.Collect(seq)
+}
+
+// FromSlice returns a sequence yielding the elements of s in order.
+func FromSlice[T any](s []T) iter.Seq[T] {
+	return slices.Values(s)
+}
+
+// FromMap returns a sequence yielding the entries of m in an unspecified
+// order.
+func FromMap[K comparable, V any](m map[K]V) iter.Seq2[K, V] {
+	return maps.All(m)
+}
+
+// FromChan returns a sequence yielding the values received from ch until
+// ch is closed or ctx is done.
+//
+// Example:
+//
+//	for event := range iterx.Take(iterx.FromChan(ctx, events), 10) {
+//		handle(event)
+//	}
+func FromChan[T any](ctx context.Context, ch <-chan T) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for {
+			select {
+			case v, ok := <-ch:
+				if !ok || !yield(v) {
+					return
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	}
+}
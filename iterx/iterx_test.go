@@ -0,0 +1,116 @@
+package iterx
+
+import (
+	"context"
+	"slices"
+	"strconv"
+	"testing"
+)
+
+// naturals yields 0, 1, 2, ... forever, to prove combinators are lazy.
+func naturals(yield func(int) bool) {
+	for i := 0; ; i++ {
+		if !yield(i) {
+			return
+		}
+	}
+}
+
+func TestMapFilterTake_Lazy(t *testing.T) {
+	// Arrange
+	even := func(v int) bool { return v%2 == 0 }
+	square := func(v int) int { return v * v }
+
+	// Act
+	got := Collect(Take(Map(Filter(naturals, even), square), 4))
+
+	// Assert
+	if !slices.Equal(got, []int{0, 4, 16, 36}) {
+		t.Errorf("expected [0 4 16 36], got %v", got)
+	}
+}
+
+func TestSkip(t *testing.T) {
+	// Act
+	got := Collect(Skip(FromSlice([]int{1, 2, 3, 4}), 2))
+	none := Collect(Take(FromSlice([]int{1}), 0))
+
+	// Assert
+	if !slices.Equal(got, []int{3, 4}) {
+		t.Errorf("expected [3 4], got %v", got)
+	}
+	if len(none) != 0 {
+		t.Errorf("expected Take 0 to yield nothing, got %v", none)
+	}
+}
+
+func TestReduce(t *testing.T) {
+	// Act
+	sum := Reduce(FromSlice([]int{1, 2, 3}), 10, func(acc, v int) int { return acc + v })
+	joined := Reduce(FromSlice([]int{1, 2}), "", func(acc string, v int) string { return acc + strconv.Itoa(v) })
+
+	// Assert
+	if sum != 16 {
+		t.Errorf("expected 16, got %d", sum)
+	}
+	if joined != "12" {
+		t.Errorf("expected 12, got %q", joined)
+	}
+}
+
+func TestSeq2Combinators(t *testing.T) {
+	// Arrange
+	m := map[string]int{"a": 1, "b": 2, "c": 3}
+
+	// Act
+	doubled := Collect2(Map2(FromMap(m), func(k string, v int) (string, int) { return k + k, v * 2 }))
+	odd := Collect2(Filter2(FromMap(m), func(_ string, v int) bool { return v%2 == 1 }))
+	indexed := slices.All([]string{"x", "y", "z"})
+	taken := Collect2(Take2(indexed, 2))
+	skipped := Collect2(Skip2(indexed, 2))
+
+	// Assert
+	if len(doubled) != 3 || doubled["aa"] != 2 || doubled["cc"] != 6 {
+		t.Errorf("expected {aa:2 bb:4 cc:6}, got %v", doubled)
+	}
+	if len(odd) != 2 || odd["a"] != 1 || odd["c"] != 3 {
+		t.Errorf("expected {a:1 c:3}, got %v", odd)
+	}
+	if len(taken) != 2 || taken[1] != "y" {
+		t.Errorf("expected {0:x 1:y}, got %v", taken)
+	}
+	if len(skipped) != 1 || skipped[2] != "z" {
+		t.Errorf("expected {2:z}, got %v", skipped)
+	}
+}
+
+func TestFromChan(t *testing.T) {
+	// Arrange
+	ch := make(chan int, 3)
+	ch <- 1
+	ch <- 2
+	ch <- 3
+	close(ch)
+
+	// Act
+	got := Collect(FromChan(context.Background(), ch))
+
+	// Assert
+	if !slices.Equal(got, []int{1, 2, 3}) {
+		t.Errorf("expected [1 2 3], got %v", got)
+	}
+}
+
+func TestFromChan_ContextDone(t *testing.T) {
+	// Arrange
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	// Act
+	got := Collect(FromChan(ctx, make(chan int)))
+
+	// Assert
+	if len(got) != 0 {
+		t.Errorf("expected no values, got %v", got)
+	}
+}
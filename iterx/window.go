@@ -0,0 +1,100 @@
+package iterx
+
+import (
+	"iter"
+
+	"github.com/zodimo/go-zbase-std/optional"
+	"github.com/zodimo/go-zbase-std/tuple"
+)
+
+// Chunk returns a sequence yielding the values of seq in consecutive slices
+// of n values; the last slice may be shorter. Each slice is newly
+// allocated, so it may be retained. Values below 1 for n are treated as 1.
+//
+// Example:
+//
+//	for batch := range iterx.Chunk(iterx.FromSlice(ids), 100) {
+//		fetch(ctx, batch)
+//	}
+func Chunk[T any](seq iter.Seq[T], n int) iter.Seq[[]T] {
+	n = max(n, 1)
+	return func(yield func([]T) bool) {
+		chunk := make([]T, 0, n)
+		for v := range seq {
+			chunk = append(chunk, v)
+			if len(chunk) < n {
+				continue
+			}
+			if !yield(chunk) {
+				return
+			}
+			chunk = make([]T, 0, n)
+		}
+		if len(chunk) > 0 {
+			yield(chunk)
+		}
+	}
+}
+
+// Window returns a sequence yielding every run of n consecutive values of
+// seq, advancing one value at a time. A sequence shorter than n yields
+// nothing. Each slice is newly allocated, so it may be retained. Values
+// below 1 for n are treated as 1.
+//
+// Example:
+//
+//	for w := range iterx.Window(iterx.FromSlice(samples), 3) {
+//		smoothed = append(smoothed, (w[0]+w[1]+w[2])/3)
+//	}
+func Window[T any](seq iter.Seq[T], n int) iter.Seq[[]T] {
+	n = max(n, 1)
+	return func(yield func([]T) bool) {
+		window := make([]T, 0, n)
+		for v := range seq {
+			if len(window) == n {
+				window = window[:copy(window, window[1:])]
+			}
+			window = append(window, v)
+			if len(window) < n {
+				continue
+			}
+			out := make([]T, n)
+			copy(out, window)
+			if !yield(out) {
+				return
+			}
+		}
+	}
+}
+
+// Zip returns a sequence pairing the values of a and b in order. It stops
+// when either sequence is exhausted.
+//
+// Example:
+//
+//	for p := range iterx.Zip(iterx.FromSlice(names), iterx.FromSlice(scores)) {
+//		name, score := p.Unpack()
+//	}
+func Zip[A, B any](a iter.Seq[A], b iter.Seq[B]) iter.Seq[tuple.Pair[A, B]] {
+	return func(yield func(tuple.Pair[A, B]) bool) {
+		nextB, stop := iter.Pull(b)
+		defer stop()
+		for va := range a {
+			vb, ok := nextB()
+			if !ok || !yield(tuple.NewPair(va, vb)) {
+				return
+			}
+		}
+	}
+}
+
+// Find returns the first value of seq for which match returns true, or
+// None if there is none. It stops consuming seq at the first match.
+func Find[T any](seq iter.Seq[T], match func(T) bool) optional.Option[T] {
+	for v := range seq {
+		if match(v) {
+			return optional.Some(v)
+		}
+	}
+	return optional.None[T]()
+}
@@ -0,0 +1,77 @@
+package iterx
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestChunk(t *testing.T) {
+	// Act
+	chunks := Collect(Chunk(FromSlice([]int{1, 2, 3, 4, 5}), 2))
+
+	// Assert
+	if len(chunks) != 3 || !slices.Equal(chunks[0], []int{1, 2}) || !slices.Equal(chunks[2], []int{5}) {
+		t.Errorf("expected [[1 2] [3 4] [5]], got %v", chunks)
+	}
+}
+
+func TestChunk_Lazy(t *testing.T) {
+	// Act
+	first := Collect(Take(Chunk(naturals, 3), 2))
+
+	// Assert
+	if len(first) != 2 || !slices.Equal(first[1], []int{3, 4, 5}) {
+		t.Errorf("expected [[0 1 2] [3 4 5]], got %v", first)
+	}
+}
+
+func TestWindow(t *testing.T) {
+	// Act
+	windows := Collect(Window(FromSlice([]int{1, 2, 3, 4}), 3))
+	short := Collect(Window(FromSlice([]int{1, 2}), 3))
+
+	// Assert
+	if len(windows) != 2 || !slices.Equal(windows[0], []int{1, 2, 3}) || !slices.Equal(windows[1], []int{2, 3, 4}) {
+		t.Errorf("expected [[1 2 3] [2 3 4]], got %v", windows)
+	}
+	if len(short) != 0 {
+		t.Errorf("expected no windows from a short sequence, got %v", short)
+	}
+}
+
+func TestZip(t *testing.T) {
+	// Act
+	pairs := Collect(Zip(FromSlice([]string{"a", "b", "c"}), naturals))
+
+	// Assert
+	if len(pairs) != 3 {
+		t.Fatalf("expected 3 pairs, got %d", len(pairs))
+	}
+	if name, n := pairs[2].Unpack(); name != "c" || n != 2 {
+		t.Errorf("expected (c, 2), got (%s, %d)", name, n)
+	}
+}
+
+func TestZip_StopsAtShorter(t *testing.T) {
+	// Act
+	pairs := Collect(Zip(naturals, FromSlice([]bool{true})))
+
+	// Assert
+	if len(pairs) != 1 {
+		t.Errorf("expected 1 pair, got %d", len(pairs))
+	}
+}
+
+func TestFind(t *testing.T) {
+	// Act
+	found := Find(naturals, func(v int) bool { return v > 41 })
+	missing := Find(FromSlice([]int{1, 3}), func(v int) bool { return v%2 == 0 })
+
+	// Assert
+	if v, ok := found.Value(); !ok || v != 42 {
+		t.Errorf("expected Some(42), got %v, %v", v, ok)
+	}
+	if _, ok := missing.Value(); ok {
+		t.Error("expected None when nothing matches")
+	}
+}
@@ -0,0 +1,67 @@
+// Package lazy provides memoized lazy initialization of values that are
+// expensive to compute, such as clients and caches used as singletons.
+package lazy
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+// Lazy holds a value that is computed by its initializer on first use and
+// memoized afterwards. It is safe for concurrent use; the initializer runs
+// at most once.
+type Lazy[T any] struct {
+	once      sync.Once
+	init      func() T    // The initializer, released once it has run.
+	value     T           // The memoized value.
+	panicked  any         // The initializer's panic value, if it panicked.
+	evaluated atomic.Bool // Reports whether the initializer has run.
+}
+
+// Of creates a Lazy whose value is computed by init on the first call to
+// Get.
+//
+// Parameters:
+//   - init: The initializer computing the value.
+//
+// Returns:
+//   - *Lazy[T]: The unevaluated Lazy.
+//
+// Example:
+//
+//	var client = lazy.Of(func() *http.Client { return newClient(loadConfig()) })
+//
+//	resp, err := client.Get().Do(req)
+func Of[T any](init func() T) *Lazy[T] {
+	return &Lazy[T]{init: init}
+}
+
+// Get returns the value, running the initializer if this is the first
+// call. Concurrent callers wait for the initializer to finish. If the
+// initializer panicked, every call to Get panics with the same value.
+//
+// Returns:
+//   - T: The memoized value.
+func (l *Lazy[T]) Get() T {
+	l.once.Do(l.evaluate)
+	if l.panicked != nil {
+		panic(l.panicked)
+	}
+	return l.value
+}
+
+// IsEvaluated reports whether the initializer has run.
+func (l *Lazy[T]) IsEvaluated() bool {
+	return l.evaluated.Load()
+}
+
+// evaluate runs the initializer, recording a panic instead of letting it
+// escape sync.Once.
+func (l *Lazy[T]) evaluate() {
+	defer func() {
+		l.panicked = recover()
+		l.init = nil
+		l.evaluated.Store(true)
+	}()
+	l.value = l.init()
+}
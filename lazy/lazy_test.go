@@ -0,0 +1,89 @@
+package lazy
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+func TestLazy_Get(t *testing.T) {
+	// Arrange
+	calls := 0
+	l := Of(func() int { calls++; return 42 })
+
+	// Act
+	first := l.Get()
+	second := l.Get()
+
+	// Assert
+	if first != 42 || second != 42 {
+		t.Errorf("expected 42 from every Get, got %v and %v", first, second)
+	}
+	if calls != 1 {
+		t.Errorf("expected the initializer to run once, got %d", calls)
+	}
+}
+
+func TestLazy_IsEvaluated(t *testing.T) {
+	// Arrange
+	l := Of(func() string { return "value" })
+
+	// Act
+	before := l.IsEvaluated()
+	_ = l.Get()
+	after := l.IsEvaluated()
+
+	// Assert
+	if before {
+		t.Error("expected Lazy not to be evaluated before Get")
+	}
+	if !after {
+		t.Error("expected Lazy to be evaluated after Get")
+	}
+}
+
+func TestLazy_ConcurrentGet(t *testing.T) {
+	// Arrange
+	var calls atomic.Int32
+	l := Of(func() int { calls.Add(1); return 7 })
+	var wg sync.WaitGroup
+
+	// Act
+	for range 16 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if got := l.Get(); got != 7 {
+				t.Errorf("expected 7, got %v", got)
+			}
+		}()
+	}
+	wg.Wait()
+
+	// Assert
+	if calls.Load() != 1 {
+		t.Errorf("expected the initializer to run once, got %d", calls.Load())
+	}
+}
+
+func TestLazy_PanicRepeats(t *testing.T) {
+	// Arrange
+	l := Of(func() int { panic("boom") })
+	get := func() (r any) {
+		defer func() { r = recover() }()
+		l.Get()
+		return nil
+	}
+
+	// Act
+	first := get()
+	second := get()
+
+	// Assert
+	if first != "boom" || second != "boom" {
+		t.Errorf("expected every Get to panic with boom, got %v and %v", first, second)
+	}
+	if !l.IsEvaluated() {
+		t.Error("expected a panicking initializer to count as evaluated")
+	}
+}
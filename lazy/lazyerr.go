@@ -0,0 +1,80 @@
+package lazy
+
+import (
+	"context"
+	"sync/atomic"
+
+	"github.com/zodimo/go-zbase-std/internal/flight"
+)
+
+// LazyErr holds a value computed by a fallible, context-aware initializer
+// on first use. Unlike sync.OnceValues, a failed initialization is not
+// memoized: the next call to Get runs the initializer again. Only a
+// successful value is memoized. It is safe for concurrent use; at most one
+// initialization runs at a time.
+type LazyErr[T any] struct {
+	flight    flight.Group[struct{}, T]        // Shares a running initialization.
+	init      func(context.Context) (T, error) // Released once a value is memoized.
+	value     T                                // The memoized value.
+	evaluated atomic.Bool                      // Reports whether value is memoized.
+}
+
+// OfErr creates a LazyErr whose value is computed by init on the first
+// successful call to Get.
+//
+// Parameters:
+//   - init: The initializer computing the value. It receives the context
+//     of the Get call that runs it.
+//
+// Returns:
+//   - *LazyErr[T]: The unevaluated LazyErr.
+//
+// Example:
+//
+//	var db = lazy.OfErr(func(ctx context.Context) (*sql.DB, error) {
+//		return connect(ctx, dsn)
+//	})
+//
+//	conn, err := db.Get(ctx)
+func OfErr[T any](init func(context.Context) (T, error)) *LazyErr[T] {
+	return &LazyErr[T]{init: init}
+}
+
+// Get returns the memoized value, running the initializer with ctx if no
+// value has been memoized yet. Callers that arrive while another caller
+// runs the initializer wait for its outcome or for their own ctx to be
+// done, whichever comes first. A failed initialization is reported to the
+// callers waiting on it and retried by the next call to Get.
+//
+// Parameters:
+//   - ctx: The context bounding the wait, passed to the initializer.
+//
+// Returns:
+//   - T: The memoized value, or the zero value of T on failure.
+//   - error: The initializer's error, or ctx's error if ctx is done first.
+func (l *LazyErr[T]) Get(ctx context.Context) (T, error) {
+	if l.evaluated.Load() {
+		return l.value, nil
+	}
+	return l.flight.Do(ctx, struct{}{}, "lazy: initializer", l.run)
+}
+
+// IsEvaluated reports whether a value has been memoized.
+func (l *LazyErr[T]) IsEvaluated() bool {
+	return l.evaluated.Load()
+}
+
+// run runs the initializer with ctx and memoizes a successful value. The
+// flight group ensures that only one run is in progress at a time.
+func (l *LazyErr[T]) run(ctx context.Context) (T, error) {
+	if l.evaluated.Load() {
+		return l.value, nil // Memoized by a run that finished meanwhile.
+	}
+	value, err := l.init(ctx)
+	if err == nil {
+		l.value = value
+		l.init = nil
+		l.evaluated.Store(true)
+	}
+	return value, err
+}
@@ -0,0 +1,191 @@
+package lazy
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestLazyErr_Get(t *testing.T) {
+	// Arrange
+	calls := 0
+	l := OfErr(func(context.Context) (int, error) { calls++; return 42, nil })
+
+	// Act
+	first, err1 := l.Get(context.Background())
+	second, err2 := l.Get(context.Background())
+
+	// Assert
+	if err1 != nil || err2 != nil {
+		t.Fatalf("expected no errors, got %v and %v", err1, err2)
+	}
+	if first != 42 || second != 42 {
+		t.Errorf("expected 42 from every Get, got %v and %v", first, second)
+	}
+	if calls != 1 {
+		t.Errorf("expected the initializer to run once, got %d", calls)
+	}
+	if !l.IsEvaluated() {
+		t.Error("expected LazyErr to be evaluated after a successful Get")
+	}
+}
+
+func TestLazyErr_RetriesAfterFailure(t *testing.T) {
+	// Arrange
+	cause := errors.New("unavailable")
+	calls := 0
+	l := OfErr(func(context.Context) (string, error) {
+		calls++
+		if calls == 1 {
+			return "", cause
+		}
+		return "ready", nil
+	})
+
+	// Act
+	_, firstErr := l.Get(context.Background())
+	evaluatedAfterFailure := l.IsEvaluated()
+	value, secondErr := l.Get(context.Background())
+
+	// Assert
+	if !errors.Is(firstErr, cause) {
+		t.Errorf("expected the first Get to fail with %v, got %v", cause, firstErr)
+	}
+	if evaluatedAfterFailure {
+		t.Error("expected a failed initialization not to be memoized")
+	}
+	if secondErr != nil || value != "ready" {
+		t.Errorf("expected the retry to succeed with ready, got %q, %v", value, secondErr)
+	}
+}
+
+func TestLazyErr_CancelledWhileInitializing(t *testing.T) {
+	// Arrange
+	l := OfErr(func(ctx context.Context) (int, error) {
+		<-ctx.Done()
+		return 0, ctx.Err()
+	})
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	// Act
+	_, err := l.Get(ctx)
+
+	// Assert
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected context.DeadlineExceeded, got %v", err)
+	}
+}
+
+func TestLazyErr_WaiterCancelled(t *testing.T) {
+	// Arrange
+	release := make(chan struct{})
+	started := make(chan struct{})
+	l := OfErr(func(context.Context) (int, error) {
+		close(started)
+		<-release
+		return 1, nil
+	})
+	go func() { _, _ = l.Get(context.Background()) }()
+	<-started
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	// Act
+	_, err := l.Get(ctx)
+	close(release)
+
+	// Assert
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected the waiter to give up with context.DeadlineExceeded, got %v", err)
+	}
+}
+
+func TestLazyErr_WaiterRetriesAbandonedAttempt(t *testing.T) {
+	// Arrange
+	started := make(chan struct{}, 2)
+	l := OfErr(func(ctx context.Context) (int, error) {
+		started <- struct{}{}
+		select {
+		case <-ctx.Done():
+			return 0, ctx.Err()
+		case <-time.After(20 * time.Millisecond):
+			return 5, nil
+		}
+	})
+	firstCtx, cancelFirst := context.WithCancel(context.Background())
+	firstDone := make(chan error)
+	go func() {
+		_, err := l.Get(firstCtx)
+		firstDone <- err
+	}()
+	<-started
+	waiterDone := make(chan int)
+	go func() {
+		value, _ := l.Get(context.Background())
+		waiterDone <- value
+	}()
+	time.Sleep(5 * time.Millisecond) // Let the waiter join the first attempt
+
+	// Act
+	cancelFirst()
+
+	// Assert
+	if err := <-firstDone; !errors.Is(err, context.Canceled) {
+		t.Errorf("expected the first caller to be cancelled, got %v", err)
+	}
+	if value := <-waiterDone; value != 5 {
+		t.Errorf("expected the waiter to retry and get 5, got %v", value)
+	}
+}
+
+func TestLazyErr_ConcurrentGet(t *testing.T) {
+	// Arrange
+	var calls atomic.Int32
+	l := OfErr(func(context.Context) (int, error) {
+		calls.Add(1)
+		time.Sleep(time.Millisecond)
+		return 7, nil
+	})
+	var wg sync.WaitGroup
+
+	// Act
+	for range 16 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if got, err := l.Get(context.Background()); err != nil || got != 7 {
+				t.Errorf("expected 7, got %v, %v", got, err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	// Assert
+	if calls.Load() != 1 {
+		t.Errorf("expected the initializer to run once, got %d", calls.Load())
+	}
+}
+
+func TestLazyErr_PanicReleasesWaiters(t *testing.T) {
+	// Arrange
+	l := OfErr(func(context.Context) (int, error) { panic("boom") })
+
+	// Act
+	recovered := func() (r any) {
+		defer func() { r = recover() }()
+		_, _ = l.Get(context.Background())
+		return nil
+	}()
+
+	// Assert
+	if recovered != "boom" {
+		t.Errorf("expected Get to propagate the panic, got %v", recovered)
+	}
+	if l.IsEvaluated() {
+		t.Error("expected a panicking initializer not to memoize a value")
+	}
+}
@@ -0,0 +1,84 @@
+// Package lockfree provides queues built on atomic operations rather than
+// mutexes, for hot paths such as telemetry and event pipelines where lock
+// contention measurably limits throughput. The queues trade generality for
+// speed: each one is only safe for the producer and consumer counts its
+// name states, so prefer collections.BlockingQueue or a channel unless a
+// benchmark shows the lock is the bottleneck.
+package lockfree
+
+import (
+	"sync/atomic"
+
+	"github.com/zodimo/go-zbase-std/optional"
+)
+
+// MPSC is an unbounded multi-producer, single-consumer FIFO queue. Any number
+// of goroutines may call Push concurrently, but only one goroutine at a time
+// may call Pop. Push never blocks and never fails; it costs one allocation
+// and one atomic swap.
+//
+// A Pop that runs while a Push is part-way through may report the queue as
+// empty even though Len counts the new value; the value becomes visible as
+// soon as that Push returns. An MPSC must not be copied after first use.
+type MPSC[T any] struct {
+	head atomic.Pointer[mpscNode[T]] // Most recently pushed node; producers swap it.
+	_    [56]byte                    // Keeps head and tail on separate cache lines.
+	tail *mpscNode[T]                // Consumed sentinel; owned by the consumer.
+	len  atomic.Int64
+}
+
+// mpscNode is a link in an MPSC queue.
+type mpscNode[T any] struct {
+	next  atomic.Pointer[mpscNode[T]]
+	value T
+}
+
+// NewMPSC creates an empty multi-producer, single-consumer queue.
+//
+// Returns:
+//   - *MPSC[T]: The new queue.
+//
+// Example:
+//
+//	events := lockfree.NewMPSC[Event]()
+//	go func() { events.Push(Event{Name: "login"}) }()
+//	next := events.Pop()
+//	if evt, ok := next.Value(); ok {
+//		record(evt)
+//	}
+func NewMPSC[T any]() *MPSC[T] {
+	sentinel := &mpscNode[T]{}
+	q := &MPSC[T]{tail: sentinel}
+	q.head.Store(sentinel)
+	return q
+}
+
+// Push adds value to the back of the queue. It is safe to call from any
+// number of goroutines.
+func (q *MPSC[T]) Push(value T) {
+	n := &mpscNode[T]{value: value}
+	q.len.Add(1)
+	prev := q.head.Swap(n)
+	prev.next.Store(n)
+}
+
+// Pop removes and returns the front value, or None if the queue is empty. It
+// must only be called by one goroutine at a time.
+func (q *MPSC[T]) Pop() optional.Option[T] {
+	next := q.tail.next.Load()
+	if next == nil {
+		return optional.None[T]()
+	}
+	value := next.value
+	var zero T
+	next.value = zero // next becomes the sentinel; release the reference.
+	q.tail = next
+	q.len.Add(-1)
+	return optional.Some(value)
+}
+
+// Len returns the number of values pushed but not yet popped. Under
+// concurrent use the result is a snapshot that may already be stale.
+func (q *MPSC[T]) Len() int {
+	return int(q.len.Load())
+}
@@ -0,0 +1,160 @@
+package lockfree
+
+import (
+	"runtime"
+	"sync"
+	"testing"
+
+	"github.com/zodimo/go-zbase-std/collections"
+)
+
+func TestMPSC_FIFO(t *testing.T) {
+	// Arrange
+	q := NewMPSC[int]()
+	for _, v := range []int{1, 2, 3} {
+		q.Push(v)
+	}
+
+	// Act
+	length := q.Len()
+	var popped []int
+	for {
+		next := q.Pop()
+		v, ok := next.Value()
+		if !ok {
+			break
+		}
+		popped = append(popped, v)
+	}
+
+	// Assert
+	if length != 3 {
+		t.Errorf("expected Len 3, got %d", length)
+	}
+	expected := []int{1, 2, 3}
+	if len(popped) != len(expected) {
+		t.Fatalf("expected %v, got %v", expected, popped)
+	}
+	for i := range expected {
+		if popped[i] != expected[i] {
+			t.Fatalf("expected %v, got %v", expected, popped)
+		}
+	}
+	if q.Len() != 0 {
+		t.Errorf("expected Len 0, got %d", q.Len())
+	}
+}
+
+func TestMPSC_PopEmpty(t *testing.T) {
+	// Arrange
+	q := NewMPSC[string]()
+
+	// Act
+	popped := q.Pop()
+
+	// Assert
+	if _, ok := popped.Value(); ok {
+		t.Error("expected None from an empty queue")
+	}
+}
+
+func TestMPSC_ConcurrentProducers(t *testing.T) {
+	// Arrange
+	q := NewMPSC[int]()
+	const producers, perProducer = 8, 1000
+	var wg sync.WaitGroup
+	for p := 0; p < producers; p++ {
+		wg.Add(1)
+		go func(p int) {
+			defer wg.Done()
+			for i := 0; i < perProducer; i++ {
+				q.Push(p*perProducer + i)
+			}
+		}(p)
+	}
+
+	// Act: consume concurrently, checking each producer's values stay ordered
+	last := make([]int, producers)
+	for p := range last {
+		last[p] = -1
+	}
+	for received := 0; received < producers*perProducer; {
+		next := q.Pop()
+		v, ok := next.Value()
+		if !ok {
+			runtime.Gosched()
+			continue
+		}
+		p, i := v/perProducer, v%perProducer
+		if i <= last[p] {
+			t.Fatalf("expected producer %d values in order, got %d after %d", p, i, last[p])
+		}
+		last[p] = i
+		received++
+	}
+	wg.Wait()
+
+	// Assert
+	for p, i := range last {
+		if i != perProducer-1 {
+			t.Errorf("expected producer %d to finish at %d, got %d", p, perProducer-1, i)
+		}
+	}
+}
+
+// benchmarkProducersConsumer measures push throughput from parallel
+// producers while a single goroutine drains the queue through pop.
+func benchmarkProducersConsumer(b *testing.B, push func(int), pop func() bool) {
+	stop := make(chan struct{})
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for {
+			select {
+			case <-stop:
+				return
+			default:
+			}
+			if !pop() {
+				runtime.Gosched()
+			}
+		}
+	}()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			push(1)
+		}
+	})
+	close(stop)
+	<-done
+}
+
+func BenchmarkMPSC_Contended(b *testing.B) {
+	q := NewMPSC[int]()
+	benchmarkProducersConsumer(b, q.Push, func() bool {
+		next := q.Pop()
+		_, ok := next.Value()
+		return ok
+	})
+}
+
+func BenchmarkChannel_Contended(b *testing.B) {
+	ch := make(chan int, 1024)
+	benchmarkProducersConsumer(b, func(v int) { ch <- v }, func() bool {
+		select {
+		case <-ch:
+			return true
+		default:
+			return false
+		}
+	})
+}
+
+func BenchmarkSyncQueue_Contended(b *testing.B) {
+	q := collections.NewSyncQueue[int]()
+	benchmarkProducersConsumer(b, q.Push, func() bool {
+		next := q.Pop()
+		_, ok := next.Value()
+		return ok
+	})
+}
@@ -0,0 +1,87 @@
+package lockfree
+
+import (
+	"math/bits"
+	"sync/atomic"
+
+	"github.com/zodimo/go-zbase-std/optional"
+)
+
+// SPSC is a bounded single-producer, single-consumer FIFO queue backed by a
+// ring buffer. One goroutine may call Offer while another calls Poll; neither
+// operation allocates or blocks. An SPSC must not be copied after first use.
+type SPSC[T any] struct {
+	head  atomic.Uint64 // Index of the next value to poll; written by the consumer.
+	_     [56]byte      // Keeps head and tail on separate cache lines.
+	tail  atomic.Uint64 // Index of the next free slot; written by the producer.
+	_     [56]byte
+	mask  uint64
+	items []T
+}
+
+// NewSPSC creates an empty single-producer, single-consumer queue that holds
+// at least capacity values. The capacity is rounded up to a power of two. It
+// panics if capacity is not positive.
+//
+// Parameters:
+//   - capacity: The minimum number of values the queue holds.
+//
+// Returns:
+//   - *SPSC[T]: The new queue.
+//
+// Example:
+//
+//	samples := lockfree.NewSPSC[float64](1024)
+//	if !samples.Offer(0.42) {
+//		dropped.Add(1)
+//	}
+//	next := samples.Poll()
+func NewSPSC[T any](capacity int) *SPSC[T] {
+	if capacity <= 0 {
+		panic("lockfree: SPSC capacity must be positive")
+	}
+	size := uint64(1) << bits.Len64(uint64(capacity)-1)
+	return &SPSC[T]{mask: size - 1, items: make([]T, size)}
+}
+
+// Offer adds value to the back of the queue. It must only be called by one
+// goroutine at a time.
+//
+// Returns:
+//   - bool: false, leaving the queue unchanged, if it is full.
+func (q *SPSC[T]) Offer(value T) bool {
+	tail := q.tail.Load()
+	if tail-q.head.Load() == uint64(len(q.items)) {
+		return false
+	}
+	q.items[tail&q.mask] = value
+	q.tail.Store(tail + 1)
+	return true
+}
+
+// Poll removes and returns the front value, or None if the queue is empty. It
+// must only be called by one goroutine at a time.
+func (q *SPSC[T]) Poll() optional.Option[T] {
+	head := q.head.Load()
+	if head == q.tail.Load() {
+		return optional.None[T]()
+	}
+	slot := &q.items[head&q.mask]
+	value := *slot
+	var zero T
+	*slot = zero // Release the reference for the garbage collector.
+	q.head.Store(head + 1)
+	return optional.Some(value)
+}
+
+// Len returns the number of values in the queue. Under concurrent use the
+// result is a snapshot that may already be stale.
+func (q *SPSC[T]) Len() int {
+	head := q.head.Load()
+	return int(q.tail.Load() - head)
+}
+
+// Cap returns the maximum number of values the queue holds.
+func (q *SPSC[T]) Cap() int {
+	return len(q.items)
+}
@@ -0,0 +1,159 @@
+package lockfree
+
+import (
+	"runtime"
+	"testing"
+)
+
+func TestSPSC_OfferAndPoll(t *testing.T) {
+	// Arrange
+	q := NewSPSC[int](2)
+
+	// Act
+	first := q.Offer(1)
+	second := q.Offer(2)
+	third := q.Offer(3)
+	polled := q.Poll()
+
+	// Assert
+	if !first || !second || third {
+		t.Errorf("expected Offer true, true, false, got %v, %v, %v", first, second, third)
+	}
+	if v, ok := polled.Value(); !ok || v != 1 {
+		t.Errorf("expected Poll Some(1), got %v, %v", v, ok)
+	}
+	if q.Len() != 1 {
+		t.Errorf("expected Len 1, got %d", q.Len())
+	}
+}
+
+func TestSPSC_PollEmpty(t *testing.T) {
+	// Arrange
+	q := NewSPSC[string](4)
+
+	// Act
+	polled := q.Poll()
+
+	// Assert
+	if _, ok := polled.Value(); ok {
+		t.Error("expected None from an empty queue")
+	}
+}
+
+func TestNewSPSC_RoundsCapacity(t *testing.T) {
+	tests := []struct {
+		capacity, expected int
+	}{
+		{1, 1},
+		{3, 4},
+		{4, 4},
+		{1000, 1024},
+	}
+	for _, tt := range tests {
+		// Act
+		q := NewSPSC[int](tt.capacity)
+
+		// Assert
+		if q.Cap() != tt.expected {
+			t.Errorf("expected Cap %d for capacity %d, got %d", tt.expected, tt.capacity, q.Cap())
+		}
+	}
+}
+
+func TestNewSPSC_PanicsOnZeroCapacity(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("expected a panic for a zero capacity")
+		}
+	}()
+	NewSPSC[int](0)
+}
+
+func TestSPSC_ConcurrentHandoff(t *testing.T) {
+	// Arrange
+	q := NewSPSC[int](8)
+	const count = 10000
+	go func() {
+		for i := 0; i < count; {
+			if q.Offer(i) {
+				i++
+			} else {
+				runtime.Gosched()
+			}
+		}
+	}()
+
+	// Act
+	var received []int
+	for len(received) < count {
+		next := q.Poll()
+		v, ok := next.Value()
+		if !ok {
+			runtime.Gosched()
+			continue
+		}
+		received = append(received, v)
+	}
+
+	// Assert
+	for i, v := range received {
+		if v != i {
+			t.Fatalf("expected %d at index %d, got %d", i, i, v)
+		}
+	}
+}
+
+// benchmarkProducerConsumer measures handoff throughput from one producer to
+// one consumer, retrying offer while the queue is full.
+func benchmarkProducerConsumer(b *testing.B, offer func(int) bool, poll func() bool) {
+	stop := make(chan struct{})
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for {
+			select {
+			case <-stop:
+				return
+			default:
+			}
+			if !poll() {
+				runtime.Gosched()
+			}
+		}
+	}()
+	for b.Loop() {
+		for !offer(1) {
+			runtime.Gosched()
+		}
+	}
+	close(stop)
+	<-done
+}
+
+func BenchmarkSPSC_Handoff(b *testing.B) {
+	q := NewSPSC[int](1024)
+	benchmarkProducerConsumer(b, q.Offer, func() bool {
+		next := q.Poll()
+		_, ok := next.Value()
+		return ok
+	})
+}
+
+func BenchmarkChannel_Handoff(b *testing.B) {
+	ch := make(chan int, 1024)
+	benchmarkProducerConsumer(b, func(v int) bool {
+		select {
+		case ch <- v:
+			return true
+		default:
+			return false
+		}
+	}, func() bool {
+		select {
+		case <-ch:
+			return true
+		default:
+			return false
+		}
+	})
+}
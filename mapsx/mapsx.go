@@ -0,0 +1,83 @@
+// Package mapsx provides map helpers that complement the standard maps
+// package, with lookups returning optional.Option.
+package mapsx
+
+import (
+	"iter"
+	"maps"
+
+	"github.com/zodimo/go-zbase-std/optional"
+)
+
+// GetOption returns the value stored under key as Some, or None if key is
+// absent.
+func GetOption[M ~map[K]V, K comparable, V any](m M, key K) optional.Option[V] {
+	v, ok := m[key]
+	if !ok {
+		return optional.None[V]()
+	}
+	return optional.Some(v)
+}
+
+// Invert returns a map from the values of m to their keys. If several keys
+// share a value, which of them it maps to is unspecified.
+func Invert[M ~map[K]V, K, V comparable](m M) map[V]K {
+	inverted := make(map[V]K, len(m))
+	for k, v := range m {
+		inverted[v] = k
+	}
+	return inverted
+}
+
+// MergeWith returns a new map holding the entries of every given map. When
+// a key appears in more than one, conflict combines the value merged so far
+// with the value from the later map.
+//
+// Example:
+//
+//	totals := mapsx.MergeWith(func(a, b int) int { return a + b }, januarySales, februarySales)
+func MergeWith[M ~map[K]V, K comparable, V any](conflict func(a, b V) V, ms ...M) M {
+	merged := make(M)
+	for _, m := range ms {
+		for k, v := range m {
+			if prev, ok := merged[k]; ok {
+				v = conflict(prev, v)
+			}
+			merged[k] = v
+		}
+	}
+	return merged
+}
+
+// FilterKeys returns a new map holding the entries of m whose key satisfies
+// keep.
+func FilterKeys[M ~map[K]V, K comparable, V any](m M, keep func(K) bool) M {
+	return filter(m, func(k K, _ V) bool { return keep(k) })
+}
+
+// FilterValues returns a new map holding the entries of m whose value
+// satisfies keep.
+func FilterValues[M ~map[K]V, K comparable, V any](m M, keep func(V) bool) M {
+	return filter(m, func(_ K, v V) bool { return keep(v) })
+}
+
+// Keys returns a sequence over the keys of m, in unspecified order.
+func Keys[M ~map[K]V, K comparable, V any](m M) iter.Seq[K] {
+	return maps.Keys(m)
+}
+
+// Values returns a sequence over the values of m, in unspecified order.
+func Values[M ~map[K]V, K comparable, V any](m M) iter.Seq[V] {
+	return maps.Values(m)
+}
+
+// filter returns a new map holding the entries of m satisfying keep.
+func filter[M ~map[K]V, K comparable, V any](m M, keep func(K, V) bool) M {
+	out := make(M)
+	for k, v := range m {
+		if keep(k, v) {
+			out[k] = v
+		}
+	}
+	return out
+}
@@ -0,0 +1,84 @@
+package mapsx
+
+import (
+	"maps"
+	"slices"
+	"testing"
+)
+
+func TestGetOption(t *testing.T) {
+	// Arrange
+	m := map[string]int{"a": 1}
+
+	// Act
+	found, missing := GetOption(m, "a"), GetOption(m, "b")
+
+	// Assert
+	if v, ok := found.Value(); !ok || v != 1 {
+		t.Errorf("expected Some(1), got %v, %v", v, ok)
+	}
+	if _, ok := missing.Value(); ok {
+		t.Error("expected None for a missing key")
+	}
+}
+
+func TestInvert(t *testing.T) {
+	// Arrange
+	m := map[string]int{"one": 1, "two": 2}
+
+	// Act
+	got := Invert(m)
+
+	// Assert
+	if want := map[int]string{1: "one", 2: "two"}; !maps.Equal(got, want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+}
+
+func TestMergeWith(t *testing.T) {
+	// Arrange
+	a := map[string]int{"x": 1, "y": 2}
+	b := map[string]int{"y": 10, "z": 3}
+	sum := func(a, b int) int { return a + b }
+
+	// Act
+	got := MergeWith(sum, a, b)
+
+	// Assert
+	if want := map[string]int{"x": 1, "y": 12, "z": 3}; !maps.Equal(got, want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+	if a["y"] != 2 {
+		t.Errorf("expected the inputs to be unchanged, got %v", a)
+	}
+}
+
+func TestFilterKeysAndValues(t *testing.T) {
+	// Arrange
+	m := map[string]int{"a": 1, "b": 2, "c": 3}
+
+	// Act
+	keys := FilterKeys(m, func(k string) bool { return k != "b" })
+	values := FilterValues(m, func(v int) bool { return v > 1 })
+
+	// Assert
+	if want := map[string]int{"a": 1, "c": 3}; !maps.Equal(keys, want) {
+		t.Errorf("expected %v, got %v", want, keys)
+	}
+	if want := map[string]int{"b": 2, "c": 3}; !maps.Equal(values, want) {
+		t.Errorf("expected %v, got %v", want, values)
+	}
+}
+
+func TestKeysAndValues(t *testing.T) {
+	// Arrange
+	m := map[string]int{"a": 1, "b": 2}
+
+	// Act
+	keys, values := slices.Sorted(Keys(m)), slices.Sorted(Values(m))
+
+	// Assert
+	if !slices.Equal(keys, []string{"a", "b"}) || !slices.Equal(values, []int{1, 2}) {
+		t.Errorf("expected [a b] and [1 2], got %v and %v", keys, values)
+	}
+}
@@ -0,0 +1,37 @@
+package multierr
+
+import "sync"
+
+// Group runs functions in goroutines and collects every error they return,
+// unlike errgroup.Group, which keeps only the first. The zero value is
+// ready to use.
+type Group struct {
+	wg   sync.WaitGroup
+	errs Error
+}
+
+// Go runs fn in a new goroutine, collecting its error.
+func (g *Group) Go(fn func() error) {
+	g.wg.Add(1)
+	go func() {
+		defer g.wg.Done()
+		g.errs.Append(fn())
+	}()
+}
+
+// Wait waits for every function started by Go and returns their errors
+// collected in an *Error, or nil if all succeeded.
+//
+// Example:
+//
+//	var g multierr.Group
+//	for _, host := range hosts {
+//		g.Go(func() error { return ping(host) })
+//	}
+//	if err := g.Wait(); err != nil {
+//		// err lists every failed host
+//	}
+func (g *Group) Wait() error {
+	g.wg.Wait()
+	return g.errs.ErrorOrNil()
+}
@@ -0,0 +1,38 @@
+package multierr
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestGroup_CollectsAllErrors(t *testing.T) {
+	// Arrange
+	var g Group
+	a := errors.New("a")
+	b := errors.New("b")
+
+	// Act
+	g.Go(func() error { return a })
+	g.Go(func() error { return nil })
+	g.Go(func() error { return b })
+	err := g.Wait()
+
+	// Assert
+	if !errors.Is(err, a) || !errors.Is(err, b) {
+		t.Errorf("expected both errors, got %v", err)
+	}
+}
+
+func TestGroup_NoErrors(t *testing.T) {
+	// Arrange
+	var g Group
+
+	// Act
+	g.Go(func() error { return nil })
+	err := g.Wait()
+
+	// Assert
+	if err != nil {
+		t.Errorf("expected nil, got %v", err)
+	}
+}
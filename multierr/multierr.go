@@ -0,0 +1,119 @@
+// Package multierr aggregates multiple errors into a single error that
+// keeps every member reachable through errors.Is and errors.As.
+package multierr
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// Error collects errors. The zero value is an empty collection ready to
+// use, and it is safe for concurrent use.
+type Error struct {
+	mu   sync.Mutex
+	errs []error
+}
+
+// Append adds the non-nil errors among errs to the collection.
+//
+// Parameters:
+//   - errs: The errors to add; nil errors are ignored.
+//
+// Example:
+//
+//	var errs multierr.Error
+//	for _, item := range items {
+//		errs.Append(validate(item))
+//	}
+//	return errs.ErrorOrNil()
+func (e *Error) Append(errs ...error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	for _, err := range errs {
+		if err != nil {
+			e.errs = append(e.errs, err)
+		}
+	}
+}
+
+// Errors returns a copy of the collected errors in the order they were
+// appended.
+func (e *Error) Errors() []error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return append([]error(nil), e.errs...)
+}
+
+// Len returns the number of collected errors.
+func (e *Error) Len() int {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return len(e.errs)
+}
+
+// ErrorOrNil returns the collection as an error, or nil if it is empty. Use
+// it to return a collection from a function, as a nil *Error stored in an
+// error interface is not a nil error.
+func (e *Error) ErrorOrNil() error {
+	if e.Len() == 0 {
+		return nil
+	}
+	return e
+}
+
+// Error lists the collected errors with their indexes, one per line.
+// Multi-line member messages are indented under their index.
+func (e *Error) Error() string {
+	errs := e.Errors()
+	var b strings.Builder
+	if len(errs) == 1 {
+		b.WriteString("1 error occurred:")
+	} else {
+		fmt.Fprintf(&b, "%d errors occurred:", len(errs))
+	}
+	for i, err := range errs {
+		msg := strings.ReplaceAll(err.Error(), "\n", "\n\t    ")
+		fmt.Fprintf(&b, "\n\t[%d] %s", i, msg)
+	}
+	return b.String()
+}
+
+// Unwrap returns the collected errors, letting errors.Is and errors.As
+// match any member.
+func (e *Error) Unwrap() []error {
+	return e.Errors()
+}
+
+// Append combines err with the non-nil errors among errs. It returns nil if
+// every error is nil, the single non-nil error unchanged if there is only
+// one, and an *Error otherwise. If err is already an *Error, errs are
+// added to it.
+//
+// Parameters:
+//   - err: The accumulated error, which may be nil.
+//   - errs: The errors to add.
+//
+// Returns:
+//   - error: The combined error.
+//
+// Example:
+//
+//	err = multierr.Append(err, file.Close())
+func Append(err error, errs ...error) error {
+	if merr, ok := err.(*Error); ok && merr != nil {
+		merr.Append(errs...)
+		return merr
+	}
+	var combined Error
+	combined.Append(err)
+	combined.Append(errs...)
+	switch combined.Len() {
+	case 0:
+		return nil
+	case 1:
+		return combined.errs[0]
+	default:
+		return &combined
+	}
+}
@@ -0,0 +1,133 @@
+package multierr
+
+import (
+	"errors"
+	"io/fs"
+	"sync"
+	"testing"
+)
+
+func TestError_AppendIgnoresNil(t *testing.T) {
+	// Arrange
+	var errs Error
+
+	// Act
+	errs.Append(nil, errors.New("a"), nil)
+
+	// Assert
+	if errs.Len() != 1 {
+		t.Errorf("expected 1 error, got %d", errs.Len())
+	}
+}
+
+func TestError_ErrorOrNil(t *testing.T) {
+	// Arrange
+	var empty, full Error
+	full.Append(errors.New("a"))
+
+	// Act
+	emptyErr := empty.ErrorOrNil()
+	fullErr := full.ErrorOrNil()
+
+	// Assert
+	if emptyErr != nil {
+		t.Errorf("expected nil for an empty collection, got %v", emptyErr)
+	}
+	if fullErr == nil {
+		t.Error("expected an error for a non-empty collection")
+	}
+}
+
+func TestError_ErrorFormatting(t *testing.T) {
+	// Arrange
+	var errs Error
+	errs.Append(errors.New("first"), errors.New("second\ndetail"))
+
+	// Act
+	got := errs.Error()
+
+	// Assert
+	expected := "2 errors occurred:\n\t[0] first\n\t[1] second\n\t    detail"
+	if got != expected {
+		t.Errorf("expected %q, got %q", expected, got)
+	}
+}
+
+func TestError_ErrorFormattingSingle(t *testing.T) {
+	// Arrange
+	var errs Error
+	errs.Append(errors.New("only"))
+
+	// Act
+	got := errs.Error()
+
+	// Assert
+	if expected := "1 error occurred:\n\t[0] only"; got != expected {
+		t.Errorf("expected %q, got %q", expected, got)
+	}
+}
+
+func TestError_IsAndAs(t *testing.T) {
+	// Arrange
+	sentinel := errors.New("sentinel")
+	pathErr := &fs.PathError{Op: "open", Path: "/x", Err: fs.ErrNotExist}
+	var errs Error
+	errs.Append(sentinel, pathErr)
+
+	// Act
+	err := errs.ErrorOrNil()
+
+	// Assert
+	if !errors.Is(err, sentinel) || !errors.Is(err, fs.ErrNotExist) {
+		t.Error("expected errors.Is to match every member")
+	}
+	var target *fs.PathError
+	if !errors.As(err, &target) || target != pathErr {
+		t.Errorf("expected errors.As to find the path error, got %v", target)
+	}
+}
+
+func TestError_ConcurrentAppend(t *testing.T) {
+	// Arrange
+	var errs Error
+	var wg sync.WaitGroup
+
+	// Act
+	for range 50 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs.Append(errors.New("x"))
+		}()
+	}
+	wg.Wait()
+
+	// Assert
+	if errs.Len() != 50 {
+		t.Errorf("expected 50 errors, got %d", errs.Len())
+	}
+}
+
+func TestAppend(t *testing.T) {
+	// Arrange
+	a := errors.New("a")
+	b := errors.New("b")
+
+	// Act
+	none := Append(nil, nil)
+	single := Append(nil, a)
+	both := Append(a, b)
+	grown := Append(both, errors.New("c"))
+
+	// Assert
+	if none != nil {
+		t.Errorf("expected nil, got %v", none)
+	}
+	if single != a {
+		t.Errorf("expected the single error unchanged, got %v", single)
+	}
+	var merr *Error
+	if !errors.As(grown, &merr) || merr.Len() != 3 {
+		t.Errorf("expected an *Error with 3 members, got %v", grown)
+	}
+}
@@ -0,0 +1,67 @@
+// Package must provides helpers that turn errors and failed lookups into
+// panics, for initialization code and tests where failure is a bug. The
+// panic value is an error naming the file and line of the failing call and
+// wrapping the original error, so it stays inspectable after recover.
+package must
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"runtime"
+)
+
+// NotOKError is wrapped by the panic of OK.
+var NotOKError = errors.New("value not ok")
+
+// Get returns v, or panics if err is non-nil.
+//
+// Parameters:
+//   - v: The value to return.
+//   - err: The error that accompanied v.
+//
+// Returns:
+//   - T: v, if err is nil.
+//
+// Example:
+//
+//	var pattern = must.Get(regexp.Compile(`^[a-z]+$`))
+func Get[T any](v T, err error) T {
+	if err != nil {
+		panic(at(err))
+	}
+	return v
+}
+
+// OK returns v, or panics if ok is false.
+//
+// Example:
+//
+//	port := must.OK(os.LookupEnv("PORT"))
+func OK[T any](v T, ok bool) T {
+	if !ok {
+		panic(at(NotOKError))
+	}
+	return v
+}
+
+// Do panics if err is non-nil.
+//
+// Example:
+//
+//	must.Do(os.MkdirAll(dir, 0o755))
+func Do(err error) {
+	if err != nil {
+		panic(at(err))
+	}
+}
+
+// at wraps err with the location of the call to the exported helper that
+// called at.
+func at(err error) error {
+	_, file, line, ok := runtime.Caller(2)
+	if !ok {
+		return fmt.Errorf("must: %w", err)
+	}
+	return fmt.Errorf("must: %s:%d: %w", filepath.Base(file), line, err)
+}
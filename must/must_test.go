@@ -0,0 +1,75 @@
+package must
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+// recovered runs fn and returns the error it panicked with, if any.
+func recovered(fn func()) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = r.(error)
+		}
+	}()
+	fn()
+	return nil
+}
+
+func TestGet(t *testing.T) {
+	// Arrange
+	failure := errors.New("boom")
+
+	// Act
+	v := Get(42, nil)
+	err := recovered(func() { Get(0, failure) })
+
+	// Assert
+	if v != 42 {
+		t.Errorf("expected 42, got %d", v)
+	}
+	if !errors.Is(err, failure) {
+		t.Errorf("expected a panic wrapping %v, got %v", failure, err)
+	}
+	if !strings.Contains(err.Error(), "must_test.go:") {
+		t.Errorf("expected the panic to name the calling file, got %q", err)
+	}
+}
+
+func TestOK(t *testing.T) {
+	// Arrange
+	m := map[string]int{"a": 1}
+
+	// Act
+	v := OK(m["a"], true)
+	err := recovered(func() {
+		v, ok := m["b"]
+		OK(v, ok)
+	})
+
+	// Assert
+	if v != 1 {
+		t.Errorf("expected 1, got %d", v)
+	}
+	if !errors.Is(err, NotOKError) {
+		t.Errorf("expected a panic wrapping NotOKError, got %v", err)
+	}
+}
+
+func TestDo(t *testing.T) {
+	// Arrange
+	failure := errors.New("boom")
+
+	// Act
+	okErr := recovered(func() { Do(nil) })
+	err := recovered(func() { Do(failure) })
+
+	// Assert
+	if okErr != nil {
+		t.Errorf("expected no panic, got %v", okErr)
+	}
+	if !errors.Is(err, failure) || !strings.Contains(err.Error(), "must_test.go:") {
+		t.Errorf("expected a located panic wrapping %v, got %v", failure, err)
+	}
+}
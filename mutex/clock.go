@@ -0,0 +1,13 @@
+package mutex
+
+import (
+	"github.com/zodimo/go-zbase-std/clock"
+)
+
+// Clock is the source of time used by the mutex package for timestamps and
+// timeouts. It can be replaced through WithClock so time-dependent behavior
+// is deterministic in tests; see clock.FakeClock for a fake.
+type Clock = clock.Clock
+
+// Timer is a pending AfterFunc call.
+type Timer = clock.Timer
@@ -0,0 +1,87 @@
+package mutex
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/zodimo/go-zbase-std/clock"
+)
+
+// stubClock is a Clock whose Now is frozen at a fixed time.
+type stubClock struct {
+	clock.Clock
+	now time.Time
+}
+
+func newStubClock(now time.Time) stubClock {
+	return stubClock{Clock: clock.System(), now: now}
+}
+
+func (c stubClock) Now() time.Time { return c.now }
+
+func TestSystemClock_Now(t *testing.T) {
+	// Arrange
+	before := time.Now()
+
+	// Act
+	now := clock.System().Now()
+
+	// Assert
+	if now.Before(before) {
+		t.Errorf("expected system clock to return the current time, got %v", now)
+	}
+}
+
+func TestWithClock(t *testing.T) {
+	// Arrange
+	frozen := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	// Act
+	mutex := NewCancellableMutex("clocked", WithClock(newStubClock(frozen)))
+	_ = mutex.Lock(context.Background())
+
+	// Assert
+	cm := mutex.(*cancellableMutex)
+	if !cm.createdAt.Equal(frozen) {
+		t.Errorf("expected creation time from injected clock, got %v", cm.createdAt)
+	}
+	if snap := cm.snapshot(); snap.HeldFor != 0 || snap.Age != 0 {
+		t.Errorf("expected zero ages with a frozen clock, got %+v", snap)
+	}
+}
+
+// steppingClock is a Clock whose Now advances by step on every call.
+type steppingClock struct {
+	clock.Clock
+	mu   sync.Mutex
+	now  time.Time
+	step time.Duration
+}
+
+func (c *steppingClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(c.step)
+	return c.now
+}
+
+func TestWithRWClock(t *testing.T) {
+	// Arrange
+	stepping := &steppingClock{Clock: clock.System(), step: 5 * time.Second}
+	rw := NewCancellableRWMutex("clocked", WithRWClock(stepping))
+	_ = rw.Lock(context.Background())
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	// Act
+	err := rw.RLock(ctx)
+
+	// Assert
+	var cancelled *LockCancelledError
+	if !errors.As(err, &cancelled) || cancelled.Waited != 5*time.Second {
+		t.Errorf("expected a wait of 5s measured by the injected clock, got %v", err)
+	}
+}
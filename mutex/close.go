@@ -0,0 +1,149 @@
+package mutex
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	"github.com/zodimo/go-zbase-std/errcode"
+)
+
+// ClosedError is returned, wrapped in a *LockCancelledError, by Lock calls
+// on a mutex whose registry has been closed, and by Register on a closed
+// registry.
+var ClosedError = errcode.New(errcode.Unavailable, "mutex registry closed")
+
+// shutdowner is implemented by mutexes that can take part in a graceful
+// registry shutdown.
+type shutdowner interface {
+	// shutdown rejects all future Lock attempts.
+	shutdown()
+
+	// waitIdle blocks until no Lock calls are waiting or ctx is done.
+	waitIdle(ctx context.Context) error
+
+	// abortWaiters makes every waiting Lock call return ClosedError.
+	abortWaiters()
+}
+
+// shutdown sets mutexClosed so future Lock attempts are rejected. The
+// current holder, if any, keeps the lock until it calls Unlock.
+func (cm *cancellableMutex) shutdown() {
+	cm.mu.Lock()
+	defer cm.mu.Unlock()
+	cm.state.Or(mutexClosed)
+}
+
+// waitIdle blocks until the wait queue is empty or ctx is done.
+func (cm *cancellableMutex) waitIdle(ctx context.Context) error {
+	cm.mu.Lock()
+	if cm.queue.Len() == 0 {
+		cm.mu.Unlock()
+		return nil
+	}
+	if cm.drained == nil {
+		cm.drained = make(chan struct{})
+	}
+	drained := cm.drained
+	cm.mu.Unlock()
+
+	select {
+	case <-drained:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// abortWaiters removes every waiter from the queue and makes its Lock call
+// return ClosedError.
+func (cm *cancellableMutex) abortWaiters() {
+	cm.mu.Lock()
+	defer cm.mu.Unlock()
+	for cm.queue.Len() > 0 {
+		w := cm.queue.dequeue()
+		w.aborted = true
+		close(w.ready)
+	}
+	cm.dequeued()
+}
+
+// Close shuts the registry down. Register and GetOrNew stop adding mutexes,
+// Lock attempts on registered mutexes are rejected with ClosedError, and
+// Close waits for Lock calls that are already waiting to be served. If ctx
+// is done first, the remaining waiters are aborted with ClosedError and the
+// context error is returned. Holders keep their locks until they Unlock.
+// The OnUnregister hooks run for every mutex that Close removes.
+//
+// Parameters:
+//   - ctx: Bounds how long Close waits for current waiters.
+//
+// Returns:
+//   - error: The context error if waiters had to be aborted; nil otherwise.
+func (mr *mutexRegistry) Close(ctx context.Context) error {
+	return mr.closeKeys(ctx, "")
+}
+
+// isClosed reports whether key falls under a prefix closed by closeKeys.
+func (mr *mutexRegistry) isClosed(key string) bool {
+	mr.closedMu.RLock()
+	defer mr.closedMu.RUnlock()
+	for _, prefix := range mr.closedPrefixes {
+		if strings.HasPrefix(key, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// closeKeys rejects future registrations under prefix, then shuts down and
+// removes every mutex whose registry key starts with prefix, as described
+// by Close, running the OnUnregister hooks for each removed mutex.
+func (mr *mutexRegistry) closeKeys(ctx context.Context, prefix string) error {
+	mr.closedMu.Lock()
+	if !slices.Contains(mr.closedPrefixes, prefix) {
+		mr.closedPrefixes = append(mr.closedPrefixes, prefix)
+	}
+	mr.closedMu.Unlock()
+	var closing []shutdowner
+	removed := make(map[string]CancellableMutex)
+	mr.mutexMap.Range(func(key string, value CancellableMutex) bool {
+		if !strings.HasPrefix(key, prefix) {
+			return true
+		}
+		if s, ok := value.(shutdowner); ok {
+			s.shutdown()
+			closing = append(closing, s)
+		}
+		if mr.mutexMap.CompareAndDelete(key, value) {
+			removed[key] = value
+		}
+		return true
+	})
+	mr.hooksMu.RLock()
+	hooks := mr.unregisterHooks
+	mr.hooksMu.RUnlock()
+	for key, mutex := range removed {
+		mr.runLifecycleHooks(hooks, key, mutex)
+	}
+
+	var err error
+	for _, s := range closing {
+		if err = s.waitIdle(ctx); err != nil {
+			break
+		}
+	}
+	if err != nil {
+		for _, s := range closing {
+			s.abortWaiters()
+		}
+	}
+	return err
+}
+
+// Close shuts the namespace down, including nested namespaces, as described
+// by MutexRegistry.Close: Register and GetOrNew in it return ClosedError
+// from then on. The rest of the registry stays open.
+func (n *namespacedRegistry) Close(ctx context.Context) error {
+	return n.root.closeKeys(ctx, n.prefix)
+}
@@ -0,0 +1,204 @@
+package mutex
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestMutexRegistry_CloseRejectsNewWork(t *testing.T) {
+	// Arrange
+	reg := NewMutexRegistry()
+	mutex := mustGetOrNew(t, reg, "closing")
+
+	// Act
+	err := reg.Close(context.Background())
+
+	// Assert
+	if err != nil {
+		t.Fatalf("expected idle registry to close cleanly, got %v", err)
+	}
+	if err := mutex.Lock(context.Background()); !errors.Is(err, ClosedError) {
+		t.Errorf("expected Lock on closed registry's mutex to fail with ClosedError, got %v", err)
+	}
+	if err := reg.Register(NewCancellableMutex("late")); !errors.Is(err, ClosedError) {
+		t.Errorf("expected Register on closed registry to fail with ClosedError, got %v", err)
+	}
+	if late, err := reg.GetOrNew("late"); late != nil || !errors.Is(err, ClosedError) {
+		t.Errorf("expected GetOrNew on closed registry to fail with ClosedError, got %v, %v", late, err)
+	}
+	if reg.HasMutex("closing") {
+		t.Error("expected Close to release registered mutexes")
+	}
+}
+
+func TestMutexRegistry_CloseWaitsForWaiters(t *testing.T) {
+	// Arrange
+	reg := NewMutexRegistry()
+	mutex := mustGetOrNew(t, reg, "busy")
+	_ = mutex.Lock(context.Background())
+	waited := make(chan error)
+	go func() {
+		err := mutex.Lock(context.Background())
+		if err == nil {
+			mutex.Unlock()
+		}
+		waited <- err
+	}()
+	waitForWaiters(t, mutex, 1)
+	closed := make(chan error)
+
+	// Act
+	go func() { closed <- reg.Close(context.Background()) }()
+	waitForClose(t, mutex)
+	select {
+	case err := <-closed:
+		t.Fatalf("expected Close to wait for the queued waiter, returned %v", err)
+	default:
+	}
+	mutex.Unlock()
+
+	// Assert
+	if err := <-waited; err != nil {
+		t.Errorf("expected queued waiter to be served during graceful close, got %v", err)
+	}
+	if err := <-closed; err != nil {
+		t.Errorf("expected Close to succeed once waiters drained, got %v", err)
+	}
+}
+
+// waitForClose blocks until a Close of the mutex's registry is waiting for
+// the mutex's queue to drain.
+func waitForClose(t *testing.T, mutex CancellableMutex) {
+	t.Helper()
+	cm := mutex.(*cancellableMutex)
+	for deadline := time.Now().Add(time.Second); time.Now().Before(deadline); {
+		cm.mu.Lock()
+		waiting := cm.drained != nil
+		cm.mu.Unlock()
+		if waiting {
+			return
+		}
+		time.Sleep(time.Millisecond)
+	}
+	t.Fatal("timed out waiting for Close to wait on the mutex")
+}
+
+func TestMutexRegistry_CloseAbortsWaitersOnDeadline(t *testing.T) {
+	// Arrange
+	reg := NewMutexRegistry()
+	mutex := mustGetOrNew(t, reg, "stuck")
+	_ = mutex.Lock(context.Background())
+	defer mutex.Unlock()
+	waited := make(chan error)
+	go func() { waited <- mutex.Lock(context.Background()) }()
+	waitForWaiters(t, mutex, 1)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	// Act
+	err := reg.Close(ctx)
+
+	// Assert
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected Close to report its deadline, got %v", err)
+	}
+	if err := <-waited; !errors.Is(err, ClosedError) {
+		t.Errorf("expected parked waiter to be aborted with ClosedError, got %v", err)
+	}
+}
+
+func TestNamespace_CloseOnlyOwnKeys(t *testing.T) {
+	// Arrange
+	resetRegistry()
+	reg := GetMutexRegistry()
+	ns := reg.Namespace("worker/")
+	inside := mustGetOrNew(t, ns, "job")
+	outside := mustGetOrNew(t, reg, "other")
+
+	// Act
+	err := ns.Close(context.Background())
+
+	// Assert
+	if err != nil {
+		t.Fatalf("expected namespace to close cleanly, got %v", err)
+	}
+	if err := inside.Lock(context.Background()); !errors.Is(err, ClosedError) {
+		t.Errorf("expected namespaced mutex to be closed, got %v", err)
+	}
+	if err := outside.Lock(context.Background()); err != nil {
+		t.Errorf("expected mutex outside the namespace to stay usable, got %v", err)
+	}
+}
+
+func TestNamespace_CloseRejectsNewWork(t *testing.T) {
+	// Arrange
+	reg := NewMutexRegistry()
+	ns := reg.Namespace("worker")
+	nested := ns.Namespace("batch")
+	sibling := reg.Namespace("workers")
+
+	// Act
+	err := ns.Close(context.Background())
+
+	// Assert
+	if err != nil {
+		t.Fatalf("expected namespace to close cleanly, got %v", err)
+	}
+	if m, err := ns.GetOrNew("job"); m != nil || !errors.Is(err, ClosedError) {
+		t.Errorf("expected GetOrNew in the closed namespace to fail with ClosedError, got %v, %v", m, err)
+	}
+	if err := ns.Register(NewCancellableMutex("job")); !errors.Is(err, ClosedError) {
+		t.Errorf("expected Register in the closed namespace to fail with ClosedError, got %v", err)
+	}
+	if _, err := nested.GetOrNew("job"); !errors.Is(err, ClosedError) {
+		t.Errorf("expected a nested namespace to be closed too, got %v", err)
+	}
+	if _, err := sibling.GetOrNew("job"); err != nil {
+		t.Errorf("expected a sibling namespace to stay open, got %v", err)
+	}
+	if _, err := reg.GetOrNew("job"); err != nil {
+		t.Errorf("expected the rest of the registry to stay open, got %v", err)
+	}
+}
+
+func TestMutexRegistry_CloseDuringRegistration(t *testing.T) {
+	// Arrange
+	reg := NewMutexRegistry()
+	reg.OnRegister(func(CancellableMutex) error {
+		// Close ranges over the mutexes before the one being registered
+		// is stored.
+		return reg.Close(context.Background())
+	})
+
+	// Act
+	m, err := reg.GetOrNew("late")
+
+	// Assert
+	if m != nil || !errors.Is(err, ClosedError) {
+		t.Errorf("expected ClosedError for a registration racing Close, got %v, %v", m, err)
+	}
+	if reg.HasMutex("late") {
+		t.Error("expected the closed registry not to keep the late mutex")
+	}
+}
+
+func TestMutexRegistry_CloseRunsUnregisterHooks(t *testing.T) {
+	// Arrange
+	reg := NewMutexRegistry()
+	var unregistered []string
+	reg.OnUnregister(func(m CancellableMutex) { unregistered = append(unregistered, m.GetKey()) })
+	mustGetOrNew(t, reg, "closing")
+
+	// Act
+	err := reg.Close(context.Background())
+
+	// Assert
+	if err != nil {
+		t.Fatalf("expected idle registry to close cleanly, got %v", err)
+	}
+	if len(unregistered) != 1 || unregistered[0] != "closing" {
+		t.Errorf("expected unregister hook for [closing], got %v", unregistered)
+	}
+}
@@ -0,0 +1,48 @@
+package mutex
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/zodimo/go-zbase-std/errcode"
+)
+
+// LockCancelledError is returned when a Lock call gives up before acquiring
+// the mutex. It records which key was being locked and how long the caller
+// waited, and wraps the cause, so errors.Is(err, context.DeadlineExceeded)
+// and errors.Is(err, context.Canceled) keep working.
+type LockCancelledError struct {
+	// Key is the key of the mutex that could not be acquired.
+	Key string
+
+	// Waited is how long the caller waited before giving up.
+	Waited time.Duration
+
+	// Cause is the context error that ended the wait.
+	Cause error
+
+	// HolderStack is the stack trace of the goroutine holding the mutex when
+	// the wait ended. It is only set for mutexes created with
+	// WithStackCapture.
+	HolderStack string
+}
+
+func (e *LockCancelledError) Error() string {
+	msg := fmt.Sprintf("lock %q not acquired after %s: %v", e.Key, e.Waited, e.Cause)
+	if e.HolderStack != "" {
+		msg += "\nheld by:\n" + e.HolderStack
+	}
+	return msg
+}
+
+// Unwrap returns the cause of the cancellation.
+func (e *LockCancelledError) Unwrap() error {
+	return e.Cause
+}
+
+// Code classifies the cancellation for the errcode package by its cause:
+// Timeout for an expired deadline, Canceled for a cancelled context, and
+// the code of coded causes such as Unavailable for ClosedError.
+func (e *LockCancelledError) Code() errcode.Code {
+	return errcode.Of(e.Cause)
+}
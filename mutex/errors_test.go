@@ -0,0 +1,131 @@
+package mutex
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/zodimo/go-zbase-std/errcode"
+)
+
+func TestLockCancelledError_ErrorMethod(t *testing.T) {
+	// Arrange
+	err := &LockCancelledError{Key: "orders", Waited: 2 * time.Second, Cause: context.DeadlineExceeded}
+
+	// Act
+	got := err.Error()
+	expected := `lock "orders" not acquired after 2s: context deadline exceeded`
+
+	// Assert
+	if got != expected {
+		t.Errorf("Error() = %q; want %q", got, expected)
+	}
+}
+
+func TestCancellableMutex_LockReturnsLockCancelledError(t *testing.T) {
+	// Arrange
+	mutex := NewCancellableMutex("typed")
+	_ = mutex.Lock(context.Background())
+	defer mutex.Unlock()
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	// Act
+	err := mutex.Lock(ctx)
+
+	// Assert
+	var lockErr *LockCancelledError
+	if !errors.As(err, &lockErr) {
+		t.Fatalf("expected *LockCancelledError, got %T", err)
+	}
+	if lockErr.Key != "typed" {
+		t.Errorf("expected key typed, got %q", lockErr.Key)
+	}
+	if lockErr.Waited < 5*time.Millisecond {
+		t.Errorf("expected recorded wait of roughly 10ms, got %v", lockErr.Waited)
+	}
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Error("expected error to wrap context.DeadlineExceeded")
+	}
+}
+
+func TestCancellableRWMutex_RLockReturnsLockCancelledError(t *testing.T) {
+	// Arrange
+	rw := NewCancellableRWMutex("typed-rw")
+	_ = rw.Lock(context.Background())
+	defer rw.Unlock()
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	// Act
+	err := rw.RLock(ctx)
+
+	// Assert
+	var lockErr *LockCancelledError
+	if !errors.As(err, &lockErr) || lockErr.Key != "typed-rw" {
+		t.Fatalf("expected *LockCancelledError for typed-rw, got %v", err)
+	}
+	if !errors.Is(err, context.Canceled) {
+		t.Error("expected error to wrap context.Canceled")
+	}
+}
+
+func TestLockCancelledError_ErrorIncludesHolderStack(t *testing.T) {
+	// Arrange
+	mutex := NewCancellableMutex("debug-error", WithStackCapture())
+	_ = mutex.Lock(context.Background())
+	defer mutex.Unlock()
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	// Act
+	err := mutex.Lock(ctx)
+
+	// Assert
+	var lockErr *LockCancelledError
+	if !errors.As(err, &lockErr) {
+		t.Fatalf("expected *LockCancelledError, got %T", err)
+	}
+	if !strings.Contains(lockErr.HolderStack, "TestLockCancelledError_ErrorIncludesHolderStack") {
+		t.Errorf("expected holder stack of the locking test, got %q", lockErr.HolderStack)
+	}
+	if !strings.Contains(err.Error(), "held by:\n") {
+		t.Errorf("expected error message to include the holder stack, got %q", err.Error())
+	}
+}
+
+func TestLockCancelledError_Code(t *testing.T) {
+	tests := []struct {
+		cause    error
+		expected errcode.Code
+	}{
+		{context.DeadlineExceeded, errcode.Timeout},
+		{context.Canceled, errcode.Canceled},
+		{ClosedError, errcode.Unavailable},
+		{RetriesExhaustedError, errcode.Unavailable},
+	}
+	for _, tt := range tests {
+		// Arrange
+		err := error(&LockCancelledError{Key: "coded", Cause: tt.cause})
+
+		// Act
+		code := errcode.Of(err)
+
+		// Assert
+		if code != tt.expected {
+			t.Errorf("expected code %v for cause %v, got %v", tt.expected, tt.cause, code)
+		}
+	}
+}
+
+func TestSentinelErrors_Codes(t *testing.T) {
+	// Assert
+	if !errcode.Is(AlreadyRegisteredError, errcode.Conflict) {
+		t.Error("expected AlreadyRegisteredError to be a conflict")
+	}
+	if !errcode.Is(RecursiveLockError, errcode.Conflict) {
+		t.Error("expected RecursiveLockError to be a conflict")
+	}
+}
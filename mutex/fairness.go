@@ -0,0 +1,76 @@
+package mutex
+
+import (
+	"time"
+)
+
+// StarvationFunc is called when a Lock call has been waiting for longer than
+// the threshold configured with WithStarvationThreshold. It runs in its own
+// goroutine while the caller is still waiting.
+type StarvationFunc func(mutex CancellableMutex, waited time.Duration)
+
+// FairnessStats summarizes how long Lock calls had to wait for a mutex.
+// Only calls that found the mutex contended and had to queue are counted.
+type FairnessStats struct {
+	// Waits is the number of completed waits, acquired or abandoned.
+	Waits int64 `json:"waits"`
+
+	// MaxWait is the longest completed wait.
+	MaxWait time.Duration `json:"max_wait"`
+
+	// MeanWait is the average duration of the completed waits.
+	MeanWait time.Duration `json:"mean_wait"`
+
+	// Starved is the number of completed waits that exceeded the starvation
+	// threshold. It is zero when no threshold is configured.
+	Starved int64 `json:"starved"`
+}
+
+// WithStarvationThreshold counts waits longer than d as starved in
+// FairnessStats and, if starved is non-nil, calls it for every waiter that
+// has been queued for longer than d.
+func WithStarvationThreshold(d time.Duration, starved StarvationFunc) MutexOption {
+	return func(cm *cancellableMutex) {
+		cm.starvationAfter = d
+		cm.starvation = starved
+	}
+}
+
+// FairnessStats returns wait time statistics for the mutex.
+func (cm *cancellableMutex) FairnessStats() FairnessStats {
+	stats := FairnessStats{
+		Waits:   cm.waits.Load(),
+		MaxWait: time.Duration(cm.maxWait.Load()),
+		Starved: cm.starved.Load(),
+	}
+	if stats.Waits > 0 {
+		stats.MeanWait = time.Duration(cm.totalWait.Load() / stats.Waits)
+	}
+	return stats
+}
+
+// watchStarvation schedules the starvation callback for a waiter that has
+// just been queued. It returns nil if no callback is configured.
+func (cm *cancellableMutex) watchStarvation() Timer {
+	if cm.starvation == nil || cm.starvationAfter <= 0 {
+		return nil
+	}
+	return cm.clock.AfterFunc(cm.starvationAfter, func() {
+		cm.starvation(cm, cm.starvationAfter)
+	})
+}
+
+// recordWait adds a completed wait to the fairness statistics.
+func (cm *cancellableMutex) recordWait(waited time.Duration) {
+	cm.waits.Add(1)
+	cm.totalWait.Add(int64(waited))
+	for {
+		longest := cm.maxWait.Load()
+		if int64(waited) <= longest || cm.maxWait.CompareAndSwap(longest, int64(waited)) {
+			break
+		}
+	}
+	if cm.starvationAfter > 0 && waited > cm.starvationAfter {
+		cm.starved.Add(1)
+	}
+}
@@ -0,0 +1,102 @@
+package mutex
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/zodimo/go-zbase-std/clock"
+)
+
+func TestCancellableMutex_FairnessStatsEmpty(t *testing.T) {
+	// Arrange
+	mutex := NewCancellableMutex("fair")
+	_ = mutex.Lock(context.Background())
+	mutex.Unlock()
+
+	// Act
+	stats := mutex.FairnessStats()
+
+	// Assert
+	if stats != (FairnessStats{}) {
+		t.Errorf("expected uncontended locking not to record waits, got %+v", stats)
+	}
+}
+
+// waitForPending blocks until c has n pending timers.
+func waitForPending(t *testing.T, c *clock.FakeClock, n int) {
+	t.Helper()
+	for deadline := time.Now().Add(time.Second); time.Now().Before(deadline); {
+		if c.Pending() == n {
+			return
+		}
+		time.Sleep(time.Millisecond)
+	}
+	t.Fatalf("timed out waiting for %d pending timers, have %d", n, c.Pending())
+}
+
+func TestCancellableMutex_FairnessStatsRecordsWaits(t *testing.T) {
+	// Arrange
+	c := clock.NewFakeClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	mutex := NewCancellableMutex("fair", WithClock(c), WithStarvationThreshold(5*time.Millisecond, nil))
+	_ = mutex.Lock(context.Background())
+	done := make(chan struct{})
+	go func() {
+		_ = mutex.Lock(context.Background())
+		mutex.Unlock()
+		close(done)
+	}()
+	waitForWaiters(t, mutex, 1)
+	c.Advance(10 * time.Millisecond)
+
+	// Act
+	mutex.Unlock()
+	<-done
+	stats := mutex.FairnessStats()
+
+	// Assert
+	expected := FairnessStats{Waits: 1, MaxWait: 10 * time.Millisecond, MeanWait: 10 * time.Millisecond, Starved: 1}
+	if stats != expected {
+		t.Errorf("expected %+v, got %+v", expected, stats)
+	}
+}
+
+func TestCancellableMutex_StarvationCallback(t *testing.T) {
+	// Arrange
+	c := clock.NewFakeClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	starved := make(chan time.Duration, 1)
+	mutex := NewCancellableMutex("starving", WithClock(c), WithStarvationThreshold(5*time.Millisecond, func(m CancellableMutex, waited time.Duration) {
+		starved <- waited
+	}))
+	_ = mutex.Lock(context.Background())
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		_ = mutex.Lock(ctx)
+		close(done)
+	}()
+	waitForPending(t, c, 1)
+
+	// Act
+	c.Advance(5*time.Millisecond - 1)
+	early := c.Pending()
+	c.Advance(1)
+
+	// Assert
+	if early != 1 {
+		t.Error("expected no starvation report before the threshold")
+	}
+	select {
+	case waited := <-starved:
+		if waited != 5*time.Millisecond {
+			t.Errorf("expected a report after 5ms, got %v", waited)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("expected starvation callback once the threshold passed")
+	}
+
+	// Cleanup
+	cancel()
+	<-done
+	mutex.Unlock()
+}
@@ -0,0 +1,264 @@
+package mutex
+
+import (
+	"context"
+	"errors"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/zodimo/go-zbase-std/optional"
+)
+
+// Polling bounds used while waiting for another process to release a file
+// lock.
+const (
+	fileMinPoll = time.Millisecond
+	fileMaxPoll = 50 * time.Millisecond
+)
+
+// FileMutex is a CancellableMutex backed by an advisory lock on a file, so
+// that processes on the same host, such as CLI invocations and a daemon,
+// can coordinate on the same key. Within a process, goroutines are
+// serialised by an in-process mutex before the file lock is attempted, so
+// priorities, preemption, holder labels and fairness statistics apply to
+// the goroutines of a single process only.
+//
+// File locks are advisory: they only exclude other processes that lock the
+// same path. The file is locked with flock on Unix and LockFileEx on
+// Windows; other platforms fail to acquire the lock with
+// errors.ErrUnsupported.
+type FileMutex struct {
+	local *cancellableMutex
+	path  string
+	file  *os.File // Open and locked while the mutex is held.
+}
+
+// NewFileMutex creates a FileMutex that coordinates on the file at path,
+// creating it if necessary when the mutex is first locked.
+//
+// Parameters:
+//   - key: The unique identifier for the mutex in a registry.
+//   - path: The file to lock. Every process must use the same path.
+//   - opts: Options applied to the in-process mutex.
+//
+// Returns:
+//   - *FileMutex: The new file-backed mutex.
+//
+// Example:
+//
+//	mutex := NewFileMutex("db-migrate", filepath.Join(os.TempDir(), "db-migrate.lock"))
+//	if err := GetMutexRegistry().Register(mutex); err != nil {
+//		// Handle registration error
+//	}
+func NewFileMutex(key, path string, opts ...MutexOption) *FileMutex {
+	return &FileMutex{
+		local: NewCancellableMutex(key, opts...).(*cancellableMutex),
+		path:  path,
+	}
+}
+
+// Path returns the path of the locked file.
+func (fm *FileMutex) Path() string {
+	return fm.path
+}
+
+// Lock acquires the mutex, first within the process and then across
+// processes, or returns a *LockCancelledError if the context is done first.
+func (fm *FileMutex) Lock(ctx context.Context) error {
+	return fm.acquire(ctx, fm.local.Lock)
+}
+
+// LockWithPriority acquires the mutex like Lock; the priority orders
+// goroutines of this process only.
+func (fm *FileMutex) LockWithPriority(ctx context.Context, priority int) error {
+	return fm.acquire(ctx, func(ctx context.Context) error {
+		return fm.local.LockWithPriority(ctx, priority)
+	})
+}
+
+// LockPreempting acquires the mutex like Lock, signalling a holder in this
+// process to release it. Holders in other processes are not signalled.
+func (fm *FileMutex) LockPreempting(ctx context.Context) error {
+	return fm.acquire(ctx, fm.local.LockPreempting)
+}
+
+// TryLockUntil attempts to acquire the mutex no later than t as measured by
+// the mutex's clock.
+func (fm *FileMutex) TryLockUntil(t time.Time) (bool, error) {
+	start := fm.local.clock.Now()
+	if ok, err := fm.local.TryLockUntil(t); !ok {
+		return false, err
+	}
+	poll := newFilePoll()
+	next := func() (time.Duration, bool) {
+		remaining := t.Sub(fm.local.clock.Now())
+		if remaining <= 0 {
+			return 0, false
+		}
+		delay, _ := poll()
+		return min(delay, remaining), true
+	}
+	err := fm.lockFile(context.Background(), start, next, context.DeadlineExceeded)
+	if err == nil {
+		return true, nil
+	}
+	fm.local.Unlock()
+	if errors.Is(err, context.DeadlineExceeded) {
+		return false, nil
+	}
+	return false, err
+}
+
+// LockWithRetry acquires the mutex without queueing, pausing for the delays
+// produced by backoff between attempts both within and across processes.
+func (fm *FileMutex) LockWithRetry(ctx context.Context, backoff Strategy) error {
+	start := fm.local.clock.Now()
+	if err := fm.local.LockWithRetry(ctx, backoff); err != nil {
+		return err
+	}
+	if err := fm.lockFile(ctx, start, backoff.Next, RetriesExhaustedError); err != nil {
+		fm.local.Unlock()
+		return err
+	}
+	return nil
+}
+
+// LockWithHooks acquires the mutex like Lock, running the hooks of the
+// hold as described by CancellableMutex.
+func (fm *FileMutex) LockWithHooks(ctx context.Context, hooks Hooks) error {
+	if err := fm.Lock(ctx); err != nil {
+		return hooks.cancelled(err)
+	}
+	fm.local.hookRelease(hooks.OnReleased)
+	hooks.acquired()
+	return nil
+}
+
+// acquire locks the in-process mutex with lockLocal and then polls for the
+// file lock until it is acquired or ctx is done.
+func (fm *FileMutex) acquire(ctx context.Context, lockLocal func(context.Context) error) error {
+	start := fm.local.clock.Now()
+	if err := lockLocal(ctx); err != nil {
+		return err
+	}
+	if err := fm.lockFile(ctx, start, newFilePoll(), nil); err != nil {
+		fm.local.Unlock()
+		return err
+	}
+	return nil
+}
+
+// lockFile opens and locks the file, pausing for the delays produced by
+// next while another process holds it. If next runs out of delays, the
+// returned *LockCancelledError wraps exhausted. The caller must hold the
+// in-process mutex.
+func (fm *FileMutex) lockFile(ctx context.Context, start time.Time, next func() (time.Duration, bool), exhausted error) error {
+	fail := func(cause error) error {
+		return &LockCancelledError{Key: fm.local.key, Waited: fm.local.clock.Now().Sub(start), Cause: cause}
+	}
+	file, err := os.OpenFile(fm.path, os.O_RDWR|os.O_CREATE, 0o666)
+	if err != nil {
+		return fail(err)
+	}
+	for {
+		ok, err := tryLockFile(file)
+		if err != nil {
+			_ = file.Close()
+			return fail(err)
+		}
+		if ok {
+			fm.file = file
+			return nil
+		}
+		delay, ok := next()
+		if !ok {
+			_ = file.Close()
+			return fail(exhausted)
+		}
+		select {
+		case <-fm.local.clock.After(delay):
+		case <-ctx.Done():
+			_ = file.Close()
+			return fail(ctx.Err())
+		}
+	}
+}
+
+// newFilePoll returns an endless exponential backoff between fileMinPoll
+// and fileMaxPoll.
+func newFilePoll() func() (time.Duration, bool) {
+	delay := fileMinPoll
+	return func() (time.Duration, bool) {
+		current := delay
+		delay = min(2*delay, fileMaxPoll)
+		return current, true
+	}
+}
+
+// Preempted returns a channel that is closed when a goroutine of this
+// process calls LockPreempting while the mutex is held.
+func (fm *FileMutex) Preempted() <-chan struct{} {
+	return fm.local.Preempted()
+}
+
+// Unlock releases the file lock and then the in-process mutex.
+func (fm *FileMutex) Unlock() {
+	if file := fm.file; file != nil {
+		fm.file = nil
+		_ = unlockFile(file)
+		_ = file.Close()
+	}
+	fm.local.Unlock()
+}
+
+// Locker returns a sync.Locker that acquires the mutex with ctx.
+func (fm *FileMutex) Locker(ctx context.Context) sync.Locker {
+	return NewLocker(ctx, fm)
+}
+
+// GetKey returns the unique identifier for the mutex.
+func (fm *FileMutex) GetKey() string {
+	return fm.local.GetKey()
+}
+
+// IsLocked reports whether a goroutine of this process holds the mutex, or
+// is acquiring its file lock.
+func (fm *FileMutex) IsLocked() bool {
+	return fm.local.IsLocked()
+}
+
+// Holder returns the goroutine of this process holding the mutex, if any.
+func (fm *FileMutex) Holder() optional.Option[HolderInfo] {
+	return fm.local.Holder()
+}
+
+// FairnessStats returns the fairness statistics of the in-process mutex.
+func (fm *FileMutex) FairnessStats() FairnessStats {
+	return fm.local.FairnessStats()
+}
+
+// Complete reports whether the mutex has a key.
+func (fm *FileMutex) Complete() bool {
+	return fm.local.Complete()
+}
+
+// snapshot reports the state of the in-process mutex.
+func (fm *FileMutex) snapshot() MutexSnapshot {
+	return fm.local.snapshot()
+}
+
+// shutdown rejects new Lock calls.
+func (fm *FileMutex) shutdown() {
+	fm.local.shutdown()
+}
+
+// waitIdle waits until the mutex is released by this process.
+func (fm *FileMutex) waitIdle(ctx context.Context) error {
+	return fm.local.waitIdle(ctx)
+}
+
+// abortWaiters fails the Lock calls of waiting goroutines.
+func (fm *FileMutex) abortWaiters() {
+	fm.local.abortWaiters()
+}
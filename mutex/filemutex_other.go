@@ -0,0 +1,18 @@
+//go:build !(darwin || dragonfly || freebsd || linux || netbsd || openbsd || windows)
+
+package mutex
+
+import (
+	"errors"
+	"os"
+)
+
+// tryLockFile fails: file locks are not supported on this platform.
+func tryLockFile(*os.File) (bool, error) {
+	return false, errors.ErrUnsupported
+}
+
+// unlockFile is a no-op on this platform.
+func unlockFile(*os.File) error {
+	return nil
+}
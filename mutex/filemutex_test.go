@@ -0,0 +1,188 @@
+package mutex
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// fileHelperEnv names the lock file a re-executed test binary should hold.
+const fileHelperEnv = "MUTEX_FILE_HELPER_PATH"
+
+// TestFileMutex_HelperProcess is not a real test: it is run in a separate
+// process by TestFileMutex_CrossProcess to hold the file lock until its
+// standard input is closed.
+func TestFileMutex_HelperProcess(t *testing.T) {
+	path := os.Getenv(fileHelperEnv)
+	if path == "" {
+		t.Skip("helper process only")
+	}
+	mutex := NewFileMutex("helper", path)
+	if err := mutex.Lock(context.Background()); err != nil {
+		t.Fatalf("failed to lock mutex: %v", err)
+	}
+	os.Stdout.WriteString("locked\n")
+	_, _ = io.Copy(io.Discard, os.Stdin)
+	mutex.Unlock()
+}
+
+func TestFileMutex_CrossProcess(t *testing.T) {
+	// Arrange
+	path := filepath.Join(t.TempDir(), "cross.lock")
+	cmd := exec.Command(os.Args[0], "-test.run=^TestFileMutex_HelperProcess$")
+	cmd.Env = append(os.Environ(), fileHelperEnv+"="+path)
+	stdin, _ := cmd.StdinPipe()
+	stdout, _ := cmd.StdoutPipe()
+	if err := cmd.Start(); err != nil {
+		t.Fatalf("failed to start helper process: %v", err)
+	}
+	if line, _ := bufio.NewReader(stdout).ReadString('\n'); line != "locked\n" {
+		t.Fatalf("expected helper to lock the file, got %q", line)
+	}
+	mutex := NewFileMutex("cross", path)
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	// Act
+	err := mutex.Lock(ctx)
+
+	// Assert
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected lock held by another process to time out, got %v", err)
+	}
+	if mutex.IsLocked() {
+		t.Error("expected in-process mutex to be released after a failed file lock")
+	}
+
+	// Act: the helper releases the file
+	_ = stdin.Close()
+	_ = cmd.Wait()
+	err = mutex.Lock(context.Background())
+
+	// Assert
+	if err != nil {
+		t.Fatalf("expected lock after the helper exited, got %v", err)
+	}
+	mutex.Unlock()
+}
+
+func TestFileMutex_ExcludesOtherInstances(t *testing.T) {
+	// Arrange
+	path := filepath.Join(t.TempDir(), "shared.lock")
+	first := NewFileMutex("first", path)
+	second := NewFileMutex("second", path)
+	if err := first.Lock(context.Background()); err != nil {
+		t.Fatalf("failed to lock mutex: %v", err)
+	}
+
+	// Act
+	ok, err := second.TryLockUntil(time.Now().Add(20 * time.Millisecond))
+
+	// Assert
+	if ok || err != nil {
+		t.Errorf("expected TryLockUntil to give up on a held file, got %v, %v", ok, err)
+	}
+
+	// Act: release the first instance
+	first.Unlock()
+	ok, err = second.TryLockUntil(time.Now().Add(time.Second))
+
+	// Assert
+	if !ok || err != nil {
+		t.Errorf("expected TryLockUntil to acquire a released file, got %v, %v", ok, err)
+	}
+	second.Unlock()
+}
+
+func TestFileMutex_LockWithRetryExhausted(t *testing.T) {
+	// Arrange
+	path := filepath.Join(t.TempDir(), "retry.lock")
+	holder := NewFileMutex("holder", path)
+	_ = holder.Lock(context.Background())
+	defer holder.Unlock()
+	mutex := NewFileMutex("retry", path)
+
+	// Act
+	err := mutex.LockWithRetry(context.Background(), &fixedStrategy{delay: time.Millisecond, remaining: 3})
+
+	// Assert
+	if !errors.Is(err, RetriesExhaustedError) {
+		t.Errorf("expected RetriesExhaustedError, got %v", err)
+	}
+}
+
+func TestFileMutex_OpenError(t *testing.T) {
+	// Arrange
+	mutex := NewFileMutex("missing", filepath.Join(t.TempDir(), "missing", "dir.lock"))
+
+	// Act
+	err := mutex.Lock(context.Background())
+
+	// Assert
+	var lockErr *LockCancelledError
+	if !errors.As(err, &lockErr) {
+		t.Fatalf("expected *LockCancelledError, got %T", err)
+	}
+	if !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("expected error to wrap os.ErrNotExist, got %v", err)
+	}
+}
+
+func TestFileMutex_Registry(t *testing.T) {
+	// Arrange
+	reg := NewMutexRegistry()
+	mutex := NewFileMutex("file-backed", filepath.Join(t.TempDir(), "registry.lock"))
+
+	// Act
+	err := reg.Register(mutex)
+	got := reg.GetMutex("file-backed")
+
+	// Assert
+	if err != nil {
+		t.Fatalf("expected registration to succeed, got %v", err)
+	}
+	if registered, some := got.Value(); !some || registered != CancellableMutex(mutex) {
+		t.Errorf("expected registry to return the file mutex, got %v", registered)
+	}
+	if err := reg.Close(context.Background()); err != nil {
+		t.Errorf("expected close to succeed, got %v", err)
+	}
+	if err := mutex.Lock(context.Background()); !errors.Is(err, ClosedError) {
+		t.Errorf("expected ClosedError after registry close, got %v", err)
+	}
+}
+
+func TestFileMutex_LockWithHooks(t *testing.T) {
+	// Arrange
+	path := filepath.Join(t.TempDir(), "hooks.lock")
+	holder := NewFileMutex("holder", path)
+	_ = holder.Lock(context.Background())
+	mutex := NewFileMutex("hooks", path)
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	var cancelled error
+
+	// Act
+	err := mutex.LockWithHooks(ctx, Hooks{OnCancelled: func(err error) { cancelled = err }})
+	holder.Unlock()
+	released := false
+	lockErr := mutex.LockWithHooks(context.Background(), Hooks{OnReleased: func() { released = true }})
+	mutex.Unlock()
+
+	// Assert
+	if err == nil || cancelled != err {
+		t.Errorf("expected OnCancelled with the returned error %v, got %v", err, cancelled)
+	}
+	if lockErr != nil {
+		t.Fatalf("expected lock to succeed, got %v", lockErr)
+	}
+	if !released {
+		t.Error("expected OnReleased to run on Unlock")
+	}
+}
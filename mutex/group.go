@@ -0,0 +1,120 @@
+package mutex
+
+import (
+	"context"
+	"sync"
+)
+
+// LockGroup collects the mutexes acquired by a sequence of Lock calls so
+// that they can be released together with a single deferred UnlockAll.
+// It is safe for concurrent use.
+type LockGroup struct {
+	mu      sync.Mutex
+	held    []CancellableMutex
+	pending map[string]struct{} // Keys whose Lock is in progress.
+}
+
+// NewLockGroup creates an empty LockGroup.
+//
+// Returns:
+//   - *LockGroup: The new, empty lock group.
+//
+// Example:
+//
+//	g := mutex.NewLockGroup()
+//	defer g.UnlockAll()
+//	if err := g.Lock(ctx, "accounts/1"); err != nil {
+//		return err
+//	}
+//	if err := g.Lock(ctx, "accounts/2"); err != nil {
+//		return err // accounts/1 is released by UnlockAll
+//	}
+func NewLockGroup() *LockGroup {
+	return &LockGroup{}
+}
+
+// Lock acquires the mutex registered under key in the global registry,
+// creating it if necessary, and adds it to the group.
+//
+// Parameters:
+//   - ctx: The context bounding the wait for the lock.
+//   - key: The key of the mutex to acquire.
+//
+// Returns:
+//   - error: RecursiveLockError if the group already holds the key, the
+//     error returned by the registry's GetOrNew, or the error returned by
+//     the mutex's Lock.
+func (g *LockGroup) Lock(ctx context.Context, key string) error {
+	m, err := GetMutexRegistry().GetOrNew(key)
+	if err != nil {
+		return err
+	}
+	return g.LockMutex(ctx, m)
+}
+
+// LockMutex acquires m and adds it to the group. It lets a group collect
+// mutexes from other registries, or unregistered ones.
+//
+// Parameters:
+//   - ctx: The context bounding the wait for the lock.
+//   - m: The mutex to acquire.
+//
+// Returns:
+//   - error: RecursiveLockError if the group already holds m's key or is
+//     acquiring it in another goroutine, or the error returned by m's Lock.
+func (g *LockGroup) LockMutex(ctx context.Context, m CancellableMutex) error {
+	key := m.GetKey()
+	if !g.reserve(key) {
+		return RecursiveLockError
+	}
+	err := m.Lock(ctx)
+	g.mu.Lock()
+	delete(g.pending, key)
+	if err == nil {
+		g.held = append(g.held, m)
+	}
+	g.mu.Unlock()
+	return err
+}
+
+// reserve marks key as being locked by the group, so that concurrent calls
+// for the same key fail instead of both acquiring it. It reports false if
+// the group already holds or is acquiring the key.
+func (g *LockGroup) reserve(key string) bool {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if _, ok := g.pending[key]; ok {
+		return false
+	}
+	for _, m := range g.held {
+		if m.GetKey() == key {
+			return false
+		}
+	}
+	if g.pending == nil {
+		g.pending = make(map[string]struct{})
+	}
+	g.pending[key] = struct{}{}
+	return true
+}
+
+// Len returns the number of mutexes held by the group.
+func (g *LockGroup) Len() int {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return len(g.held)
+}
+
+// UnlockAll releases every mutex held by the group in the reverse order of
+// acquisition and empties the group. Every mutex is released even if an
+// Unlock, for example through an OnReleased hook, panics; the panic is
+// propagated once the remaining mutexes are released.
+func (g *LockGroup) UnlockAll() {
+	g.mu.Lock()
+	held := g.held
+	g.held = nil
+	g.mu.Unlock()
+	for _, m := range held {
+		defer m.Unlock() // Deferred calls run in reverse, even after a panic.
+	}
+}
@@ -0,0 +1,176 @@
+package mutex
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestLockGroup_UnlockAllReverseOrder(t *testing.T) {
+	// Arrange
+	g := NewLockGroup()
+	var released []string
+	for _, key := range []string{"a", "b", "c"} {
+		_ = g.LockMutex(context.Background(), &releaseRecorder{CancellableMutex: NewCancellableMutex(key), released: &released})
+	}
+
+	// Act
+	g.UnlockAll()
+
+	// Assert
+	expected := []string{"c", "b", "a"}
+	if len(released) != len(expected) {
+		t.Fatalf("expected releases %v, got %v", expected, released)
+	}
+	for i := range expected {
+		if released[i] != expected[i] {
+			t.Fatalf("expected releases %v, got %v", expected, released)
+		}
+	}
+	if g.Len() != 0 {
+		t.Errorf("expected empty group after UnlockAll, got %d", g.Len())
+	}
+}
+
+// releaseRecorder records the key of every Unlock call.
+type releaseRecorder struct {
+	CancellableMutex
+	released *[]string
+	panics   bool
+}
+
+func (r *releaseRecorder) Unlock() {
+	r.CancellableMutex.Unlock()
+	*r.released = append(*r.released, r.GetKey())
+	if r.panics {
+		panic("unlock failed")
+	}
+}
+
+func TestLockGroup_FailedAcquisitionReleasesEarlierLocks(t *testing.T) {
+	// Arrange
+	resetRegistry()
+	blocker := mustGetOrNew(t, GetMutexRegistry(), "group-b")
+	_ = blocker.Lock(context.Background())
+	defer blocker.Unlock()
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	// Act
+	err := func() error {
+		g := NewLockGroup()
+		defer g.UnlockAll()
+		if err := g.Lock(ctx, "group-a"); err != nil {
+			return err
+		}
+		return g.Lock(ctx, "group-b")
+	}()
+
+	// Assert
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected context.DeadlineExceeded, got %v", err)
+	}
+	if mustGetOrNew(t, GetMutexRegistry(), "group-a").IsLocked() {
+		t.Error("expected group-a to be released by UnlockAll")
+	}
+}
+
+func TestLockGroup_UnlockAllAfterPanic(t *testing.T) {
+	// Arrange
+	var released []string
+	first := NewCancellableMutex("first")
+	second := NewCancellableMutex("second")
+	g := NewLockGroup()
+	_ = g.LockMutex(context.Background(), first)
+	_ = g.LockMutex(context.Background(), &releaseRecorder{CancellableMutex: second, released: &released, panics: true})
+
+	// Act
+	recovered := func() (r any) {
+		defer func() { r = recover() }()
+		g.UnlockAll()
+		return nil
+	}()
+
+	// Assert
+	if recovered == nil {
+		t.Error("expected the Unlock panic to propagate")
+	}
+	if first.IsLocked() || second.IsLocked() {
+		t.Error("expected every mutex to be released despite the panic")
+	}
+}
+
+func TestLockGroup_RecursiveLock(t *testing.T) {
+	// Arrange
+	resetRegistry()
+	g := NewLockGroup()
+	defer g.UnlockAll()
+	_ = g.Lock(context.Background(), "twice")
+
+	// Act
+	err := g.Lock(context.Background(), "twice")
+
+	// Assert
+	if !errors.Is(err, RecursiveLockError) {
+		t.Errorf("expected RecursiveLockError, got %v", err)
+	}
+	if g.Len() != 1 {
+		t.Errorf("expected 1 held mutex, got %d", g.Len())
+	}
+}
+
+func TestLockGroup_ConcurrentLockOfSameKey(t *testing.T) {
+	// Arrange
+	g := NewLockGroup()
+	defer g.UnlockAll()
+	m := NewCancellableMutex("shared")
+	_ = m.Lock(context.Background())
+	first := make(chan error, 1)
+	go func() { first <- g.LockMutex(context.Background(), m) }()
+	for deadline := time.Now().Add(time.Second); ; time.Sleep(time.Millisecond) {
+		g.mu.Lock()
+		_, reserved := g.pending["shared"]
+		g.mu.Unlock()
+		if reserved || time.Now().After(deadline) {
+			break
+		}
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	// Act
+	second := g.LockMutex(ctx, m)
+	m.Unlock()
+
+	// Assert
+	if !errors.Is(second, RecursiveLockError) {
+		t.Errorf("expected RecursiveLockError while the key is being acquired, got %v", second)
+	}
+	if err := <-first; err != nil {
+		t.Fatalf("expected the first LockMutex to succeed, got %v", err)
+	}
+	if g.Len() != 1 {
+		t.Errorf("expected the group to hold 1 mutex, got %d", g.Len())
+	}
+}
+
+func TestLockGroup_FailedLockReleasesReservation(t *testing.T) {
+	// Arrange
+	g := NewLockGroup()
+	defer g.UnlockAll()
+	m := NewCancellableMutex("busy")
+	_ = m.Lock(context.Background())
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_ = g.LockMutex(ctx, m)
+	m.Unlock()
+
+	// Act
+	err := g.LockMutex(context.Background(), m)
+
+	// Assert
+	if err != nil {
+		t.Errorf("expected the key to be lockable after a failed attempt, got %v", err)
+	}
+}
@@ -0,0 +1,62 @@
+package mutex
+
+import (
+	"context"
+
+	"github.com/zodimo/go-zbase-std/errcode"
+)
+
+// RecursiveLockError is returned by WithLock when the context shows that the
+// caller already holds the mutex, which would otherwise deadlock.
+var RecursiveLockError = errcode.New(errcode.Conflict, "mutex already held by this context")
+
+// heldKey is the context key under which the held lock keys are stored.
+type heldKey struct{}
+
+// heldKeys is an immutable linked list of the keys held by a context chain.
+type heldKeys struct {
+	key    string
+	parent *heldKeys
+}
+
+// ContextWithHeld returns a copy of ctx recording that the caller holds the
+// mutex with the given key. Deeper call layers can then use Holds to detect
+// accidental recursive acquisition. WithLock does this automatically.
+func ContextWithHeld(ctx context.Context, key string) context.Context {
+	parent, _ := ctx.Value(heldKey{}).(*heldKeys)
+	return context.WithValue(ctx, heldKey{}, &heldKeys{key: key, parent: parent})
+}
+
+// Holds reports whether ctx records that the mutex with the given key is
+// held, as set by ContextWithHeld or WithLock.
+func Holds(ctx context.Context, key string) bool {
+	for held, _ := ctx.Value(heldKey{}).(*heldKeys); held != nil; held = held.parent {
+		if held.key == key {
+			return true
+		}
+	}
+	return false
+}
+
+// WithLock acquires the mutex, runs fn with a context recording that the
+// mutex is held, and releases the mutex when fn returns or panics.
+//
+// If ctx already holds the mutex, RecursiveLockError is returned without
+// calling fn. Callers that would rather skip re-locking can check Holds
+// first.
+//
+// Example:
+//
+//	err := mutex.WithLock(ctx, m, func(ctx context.Context) error {
+//		return update(ctx)
+//	})
+func WithLock(ctx context.Context, m CancellableMutex, fn func(ctx context.Context) error) error {
+	if Holds(ctx, m.GetKey()) {
+		return RecursiveLockError
+	}
+	if err := m.Lock(ctx); err != nil {
+		return err
+	}
+	defer m.Unlock()
+	return fn(ContextWithHeld(ctx, m.GetKey()))
+}
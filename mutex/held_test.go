@@ -0,0 +1,86 @@
+package mutex
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestHolds(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+
+	// Act
+	held := ContextWithHeld(ContextWithHeld(ctx, "a"), "b")
+
+	// Assert
+	if Holds(ctx, "a") {
+		t.Error("expected background context to hold nothing")
+	}
+	if !Holds(held, "a") || !Holds(held, "b") {
+		t.Error("expected context to hold both a and b")
+	}
+	if Holds(held, "c") {
+		t.Error("expected context not to hold c")
+	}
+}
+
+func TestWithLock(t *testing.T) {
+	// Arrange
+	mutex := NewCancellableMutex("test-withlock")
+	var lockedInside, heldInside bool
+
+	// Act
+	err := WithLock(context.Background(), mutex, func(ctx context.Context) error {
+		lockedInside = mutex.IsLocked()
+		heldInside = Holds(ctx, "test-withlock")
+		return nil
+	})
+
+	// Assert
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if !lockedInside || !heldInside {
+		t.Errorf("expected mutex locked and held inside fn, got locked=%v held=%v", lockedInside, heldInside)
+	}
+	if mutex.IsLocked() {
+		t.Error("expected mutex to be unlocked after WithLock returns")
+	}
+}
+
+func TestWithLock_Recursive(t *testing.T) {
+	// Arrange
+	mutex := NewCancellableMutex("test-recursive")
+
+	// Act
+	err := WithLock(context.Background(), mutex, func(ctx context.Context) error {
+		return WithLock(ctx, mutex, func(context.Context) error {
+			t.Error("expected recursive fn not to be called")
+			return nil
+		})
+	})
+
+	// Assert
+	if !errors.Is(err, RecursiveLockError) {
+		t.Errorf("expected RecursiveLockError, got %v", err)
+	}
+}
+
+func TestWithLock_UnlocksOnPanic(t *testing.T) {
+	// Arrange
+	mutex := NewCancellableMutex("test-panic")
+
+	// Act
+	func() {
+		defer func() { _ = recover() }()
+		_ = WithLock(context.Background(), mutex, func(context.Context) error {
+			panic("boom")
+		})
+	}()
+
+	// Assert
+	if mutex.IsLocked() {
+		t.Error("expected mutex to be unlocked after fn panicked")
+	}
+}
@@ -0,0 +1,80 @@
+package mutex
+
+import (
+	"context"
+	"runtime"
+	"time"
+)
+
+// HolderInfo describes the current holder of a CancellableMutex.
+type HolderInfo struct {
+	// Label is the caller-supplied label attached through WithHolderLabel.
+	// It is empty when the holder did not supply one.
+	Label string `json:"label,omitempty"`
+
+	// AcquiredAt is the time at which the lock was acquired.
+	AcquiredAt time.Time `json:"acquired_at"`
+
+	// Stack is the stack trace of the goroutine that called Lock. It is
+	// only captured for mutexes created with WithStackCapture.
+	Stack string `json:"stack,omitempty"`
+}
+
+// holderRecord identifies the holder of a cancellableMutex: the label from
+// its context and, in debug mode, the stack of the goroutine that locked.
+type holderRecord struct {
+	label string
+	stack string
+}
+
+// WithStackCapture enables debug mode: every Lock call records the stack
+// trace of the calling goroutine, which is then reported in HolderInfo,
+// registry snapshots and LockCancelledError messages. Capturing stacks is
+// expensive and meant for diagnosing incidents, not for steady state.
+func WithStackCapture() MutexOption {
+	return func(cm *cancellableMutex) {
+		cm.captureStacks = true
+	}
+}
+
+// newHolderRecord identifies a caller of Lock from its context, capturing
+// its stack if the mutex is in debug mode.
+func (cm *cancellableMutex) newHolderRecord(ctx context.Context) holderRecord {
+	record := holderRecord{label: holderLabel(ctx)}
+	if cm.captureStacks {
+		record.stack = captureStack()
+	}
+	return record
+}
+
+// captureStack returns the stack trace of the calling goroutine.
+func captureStack() string {
+	buf := make([]byte, 4096)
+	for {
+		n := runtime.Stack(buf, false)
+		if n < len(buf) || len(buf) >= 1<<20 {
+			return string(buf[:n])
+		}
+		buf = make([]byte, 2*len(buf))
+	}
+}
+
+// holderLabelKey is the context key under which the holder label is stored.
+type holderLabelKey struct{}
+
+// WithHolderLabel returns a copy of ctx carrying a label that Lock records
+// as part of the HolderInfo once the lock is acquired. Labels make it
+// possible to find out who owns a stuck lock.
+//
+// Example:
+//
+//	err := m.Lock(mutex.WithHolderLabel(ctx, "reconciler"))
+func WithHolderLabel(ctx context.Context, label string) context.Context {
+	return context.WithValue(ctx, holderLabelKey{}, label)
+}
+
+// holderLabel returns the label stored in ctx by WithHolderLabel, if any.
+func holderLabel(ctx context.Context) string {
+	label, _ := ctx.Value(holderLabelKey{}).(string)
+	return label
+}
@@ -0,0 +1,136 @@
+package mutex
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestCancellableMutex_HolderUnlocked(t *testing.T) {
+	// Arrange
+	mutex := NewCancellableMutex("test-holder")
+
+	// Act
+	holder := mutex.Holder()
+
+	// Assert
+	if _, some := holder.Value(); some {
+		t.Error("expected no holder for an unlocked mutex")
+	}
+}
+
+func TestCancellableMutex_HolderLabel(t *testing.T) {
+	// Arrange
+	mutex := NewCancellableMutex("test-holder")
+	ctx := WithHolderLabel(context.Background(), "reconciler")
+
+	// Act
+	if err := mutex.Lock(ctx); err != nil {
+		t.Fatalf("failed to lock mutex: %v", err)
+	}
+	holder := mutex.Holder()
+
+	// Assert
+	info, some := holder.Value()
+	if !some {
+		t.Fatal("expected a holder for a locked mutex")
+	}
+	if info.Label != "reconciler" {
+		t.Errorf("expected holder label %q, got %q", "reconciler", info.Label)
+	}
+	if info.AcquiredAt.IsZero() {
+		t.Error("expected holder to record the acquisition time")
+	}
+
+	// Act: Unlock clears the holder
+	mutex.Unlock()
+	holder = mutex.Holder()
+
+	// Assert
+	if _, some := holder.Value(); some {
+		t.Error("expected holder to be cleared after Unlock")
+	}
+}
+
+func TestMutexRegistry_SnapshotHolder(t *testing.T) {
+	// Arrange
+	resetRegistry()
+	reg := GetMutexRegistry()
+	mutex := NewCancellableMutex("labelled")
+	_ = reg.Register(mutex)
+	_ = mutex.Lock(WithHolderLabel(context.Background(), "job-42"))
+	defer mutex.Unlock()
+
+	// Act
+	snap := reg.Snapshot()
+
+	// Assert
+	if snap.Mutexes[0].Holder == nil || snap.Mutexes[0].Holder.Label != "job-42" {
+		t.Errorf("expected snapshot holder label job-42, got %+v", snap.Mutexes[0].Holder)
+	}
+}
+
+func TestCancellableMutex_HolderStackCapture(t *testing.T) {
+	// Arrange
+	mutex := NewCancellableMutex("debug", WithStackCapture())
+
+	// Act
+	if err := mutex.Lock(context.Background()); err != nil {
+		t.Fatalf("failed to lock mutex: %v", err)
+	}
+	holder := mutex.Holder()
+	info, _ := holder.Value()
+	mutex.Unlock()
+
+	// Assert
+	if !strings.Contains(info.Stack, "TestCancellableMutex_HolderStackCapture") {
+		t.Errorf("expected holder stack to include the locking test, got %q", info.Stack)
+	}
+}
+
+func TestCancellableMutex_HolderStackDisabledByDefault(t *testing.T) {
+	// Arrange
+	mutex := NewCancellableMutex("no-debug")
+
+	// Act
+	_ = mutex.Lock(context.Background())
+	holder := mutex.Holder()
+	info, _ := holder.Value()
+	mutex.Unlock()
+
+	// Assert
+	if info.Stack != "" {
+		t.Errorf("expected no holder stack without WithStackCapture, got %q", info.Stack)
+	}
+}
+
+func TestCancellableMutex_HolderStackHandoff(t *testing.T) {
+	// Arrange
+	mutex := NewCancellableMutex("debug-handoff", WithStackCapture())
+	_ = mutex.Lock(context.Background())
+	acquired := make(chan HolderInfo)
+	go func() {
+		if err := lockFromWaiter(mutex); err != nil {
+			t.Errorf("failed to lock mutex: %v", err)
+		}
+		holder := mutex.Holder()
+		info, _ := holder.Value()
+		mutex.Unlock()
+		acquired <- info
+	}()
+	waitForWaiters(t, mutex, 1)
+
+	// Act
+	mutex.Unlock()
+	info := <-acquired
+
+	// Assert
+	if !strings.Contains(info.Stack, "lockFromWaiter") {
+		t.Errorf("expected handed-off holder stack to be the waiter's, got %q", info.Stack)
+	}
+}
+
+// lockFromWaiter locks mutex from a recognisable stack frame.
+func lockFromWaiter(mutex CancellableMutex) error {
+	return mutex.Lock(context.Background())
+}
@@ -0,0 +1,91 @@
+package mutex
+
+import (
+	"context"
+	"sync"
+)
+
+// KeyedMutex provides per-key mutual exclusion without a registry. Mutexes
+// are created on first use of a key and discarded as soon as no caller
+// holds or waits for them, so memory is proportional to the keys in use.
+// The zero value is not usable; create one with NewKeyedMutex.
+type KeyedMutex struct {
+	mu      sync.Mutex             // Guards entries.
+	entries map[string]*keyedEntry // Mutexes of the keys in use.
+	opts    []MutexOption          // Applied to every mutex created.
+}
+
+// keyedEntry is a mutex together with the number of callers using it.
+type keyedEntry struct {
+	mutex CancellableMutex
+	refs  int
+}
+
+// NewKeyedMutex creates an empty KeyedMutex whose per-key mutexes are
+// configured with the given options.
+//
+// Example:
+//
+//	km := mutex.NewKeyedMutex()
+//	unlock, err := km.Lock(ctx, orderID)
+//	if err != nil {
+//		return err
+//	}
+//	defer unlock()
+func NewKeyedMutex(opts ...MutexOption) *KeyedMutex {
+	return &KeyedMutex{
+		entries: make(map[string]*keyedEntry),
+		opts:    opts,
+	}
+}
+
+// Lock acquires the mutex for key and returns a function that releases it.
+// If the context is canceled before the mutex is acquired, the error from
+// Lock is returned and the returned UnlockFunc is nil. Calling the returned
+// UnlockFunc more than once has no further effect.
+func (km *KeyedMutex) Lock(ctx context.Context, key string) (UnlockFunc, error) {
+	entry := km.acquire(key)
+	if err := entry.mutex.Lock(ctx); err != nil {
+		km.release(key, entry)
+		return nil, err
+	}
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			entry.mutex.Unlock()
+			km.release(key, entry)
+		})
+	}, nil
+}
+
+// Len returns the number of keys currently held or waited for.
+func (km *KeyedMutex) Len() int {
+	km.mu.Lock()
+	defer km.mu.Unlock()
+	return len(km.entries)
+}
+
+// acquire returns the entry for key, creating it if needed, and takes a
+// reference to it.
+func (km *KeyedMutex) acquire(key string) *keyedEntry {
+	km.mu.Lock()
+	defer km.mu.Unlock()
+	entry, ok := km.entries[key]
+	if !ok {
+		entry = &keyedEntry{mutex: NewCancellableMutex(key, km.opts...)}
+		km.entries[key] = entry
+	}
+	entry.refs++
+	return entry
+}
+
+// release drops a reference to the entry for key, discarding the entry
+// once it is unused.
+func (km *KeyedMutex) release(key string, entry *keyedEntry) {
+	km.mu.Lock()
+	defer km.mu.Unlock()
+	entry.refs--
+	if entry.refs == 0 {
+		delete(km.entries, key)
+	}
+}
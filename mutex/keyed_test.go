@@ -0,0 +1,106 @@
+package mutex
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestKeyedMutex_LockAndCollect(t *testing.T) {
+	// Arrange
+	km := NewKeyedMutex()
+
+	// Act
+	unlock, err := km.Lock(context.Background(), "order-1")
+
+	// Assert
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if km.Len() != 1 {
+		t.Errorf("expected 1 key in use, got %d", km.Len())
+	}
+	unlock()
+	unlock() // second call is a no-op
+	if km.Len() != 0 {
+		t.Errorf("expected key to be discarded after unlock, have %d", km.Len())
+	}
+}
+
+func TestKeyedMutex_SameKeyExcludes(t *testing.T) {
+	// Arrange
+	km := NewKeyedMutex()
+	unlock, _ := km.Lock(context.Background(), "order-1")
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	// Act
+	_, err := km.Lock(ctx, "order-1")
+
+	// Assert
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected second Lock on the same key to time out, got %v", err)
+	}
+	if km.Len() != 1 {
+		t.Errorf("expected timed-out waiter to drop its reference, have %d keys", km.Len())
+	}
+	unlock()
+	if km.Len() != 0 {
+		t.Errorf("expected key to be discarded, have %d", km.Len())
+	}
+}
+
+func TestKeyedMutex_DistinctKeysIndependent(t *testing.T) {
+	// Arrange
+	km := NewKeyedMutex()
+	unlockA, _ := km.Lock(context.Background(), "a")
+	defer unlockA()
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	// Act
+	unlockB, err := km.Lock(ctx, "b")
+
+	// Assert
+	if err != nil {
+		t.Fatalf("expected distinct key to lock independently, got %v", err)
+	}
+	unlockB()
+}
+
+func TestKeyedMutex_WaiterKeepsEntryAlive(t *testing.T) {
+	// Arrange
+	km := NewKeyedMutex()
+	unlock, _ := km.Lock(context.Background(), "shared")
+	acquired := make(chan UnlockFunc)
+	go func() {
+		next, err := km.Lock(context.Background(), "shared")
+		if err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+		acquired <- next
+	}()
+	for {
+		km.mu.Lock()
+		refs := km.entries["shared"].refs
+		km.mu.Unlock()
+		if refs == 2 {
+			break
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	// Act
+	unlock()
+	next := <-acquired
+
+	// Assert
+	if km.Len() != 1 {
+		t.Errorf("expected entry to survive while the waiter holds it, have %d", km.Len())
+	}
+	next()
+	if km.Len() != 0 {
+		t.Errorf("expected entry to be discarded, have %d", km.Len())
+	}
+}
@@ -0,0 +1,46 @@
+package mutex
+
+import (
+	"context"
+	"sync"
+)
+
+// lockerAdapter adapts a CancellableMutex to sync.Locker using a context
+// captured when the adapter was created.
+type lockerAdapter struct {
+	ctx   context.Context
+	mutex CancellableMutex
+}
+
+// NewLocker returns a sync.Locker whose Lock acquires m with ctx, so the
+// mutex can be passed to APIs that require a sync.Locker, such as
+// sync.NewCond. Because sync.Locker cannot report errors, Lock panics with
+// the error from m.Lock if ctx is canceled before the lock is acquired.
+//
+// Example:
+//
+//	cond := sync.NewCond(mutex.NewLocker(ctx, m))
+func NewLocker(ctx context.Context, m CancellableMutex) sync.Locker {
+	return &lockerAdapter{
+		ctx:   ctx,
+		mutex: m,
+	}
+}
+
+// Lock acquires the mutex with the stored context, panicking on failure.
+func (l *lockerAdapter) Lock() {
+	if err := l.mutex.Lock(l.ctx); err != nil {
+		panic(err)
+	}
+}
+
+// Unlock releases the mutex.
+func (l *lockerAdapter) Unlock() {
+	l.mutex.Unlock()
+}
+
+// Locker returns a sync.Locker that acquires the mutex with ctx. See
+// NewLocker.
+func (cm *cancellableMutex) Locker(ctx context.Context) sync.Locker {
+	return NewLocker(ctx, cm)
+}
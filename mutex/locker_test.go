@@ -0,0 +1,78 @@
+package mutex
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+)
+
+func TestCancellableMutex_Locker(t *testing.T) {
+	// Arrange
+	mutex := NewCancellableMutex("locker")
+	locker := mutex.Locker(context.Background())
+
+	// Act
+	locker.Lock()
+	locked := mutex.IsLocked()
+	locker.Unlock()
+
+	// Assert
+	if !locked {
+		t.Error("expected Locker.Lock to lock the underlying mutex")
+	}
+	if mutex.IsLocked() {
+		t.Error("expected Locker.Unlock to unlock the underlying mutex")
+	}
+}
+
+func TestCancellableMutex_LockerPanicsOnCancel(t *testing.T) {
+	// Arrange
+	mutex := NewCancellableMutex("locker")
+	_ = mutex.Lock(context.Background())
+	defer mutex.Unlock()
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	locker := mutex.Locker(ctx)
+
+	// Act
+	var recovered any
+	func() {
+		defer func() { recovered = recover() }()
+		locker.Lock()
+	}()
+
+	// Assert
+	err, ok := recovered.(error)
+	if !ok || !errors.Is(err, context.Canceled) {
+		t.Errorf("expected panic with context.Canceled, got %v", recovered)
+	}
+}
+
+func TestCancellableMutex_LockerWithCond(t *testing.T) {
+	// Arrange
+	mutex := NewCancellableMutex("cond")
+	cond := sync.NewCond(mutex.Locker(context.Background()))
+	ready := false
+	done := make(chan struct{})
+	go func() {
+		cond.L.Lock()
+		for !ready {
+			cond.Wait()
+		}
+		cond.L.Unlock()
+		close(done)
+	}()
+
+	// Act
+	cond.L.Lock()
+	ready = true
+	cond.L.Unlock()
+	cond.Broadcast()
+
+	// Assert
+	<-done
+	if mutex.IsLocked() {
+		t.Error("expected mutex to be unlocked after the waiter finished")
+	}
+}
@@ -0,0 +1,54 @@
+package mutex
+
+import "context"
+
+// Hooks attaches cleanup and compensation logic to a single hold of a
+// mutex through LockWithHooks. Every hook is optional.
+type Hooks struct {
+	// OnAcquired runs once the lock is acquired, before LockWithHooks
+	// returns.
+	OnAcquired func()
+
+	// OnCancelled runs with the returned error if the lock attempt is
+	// abandoned, before LockWithHooks returns.
+	OnCancelled func(err error)
+
+	// OnReleased runs once the hold is released, after Unlock has handed
+	// the lock on, in the goroutine that called Unlock.
+	OnReleased func()
+}
+
+// acquired runs the OnAcquired hook, if any.
+func (h Hooks) acquired() {
+	if h.OnAcquired != nil {
+		h.OnAcquired()
+	}
+}
+
+// cancelled runs the OnCancelled hook, if any, and returns err.
+func (h Hooks) cancelled(err error) error {
+	if h.OnCancelled != nil {
+		h.OnCancelled(err)
+	}
+	return err
+}
+
+// LockWithHooks acquires the lock like Lock, running hooks.OnAcquired once
+// it is held or hooks.OnCancelled if the attempt fails. hooks.OnReleased
+// runs when the hold is later released by Unlock.
+func (cm *cancellableMutex) LockWithHooks(ctx context.Context, hooks Hooks) error {
+	if err := cm.Lock(ctx); err != nil {
+		return hooks.cancelled(err)
+	}
+	cm.hookRelease(hooks.OnReleased)
+	hooks.acquired()
+	return nil
+}
+
+// hookRelease arranges for released to run when the current hold is
+// released. The caller must hold the lock.
+func (cm *cancellableMutex) hookRelease(released func()) {
+	if released != nil {
+		cm.onReleased.Store(&released)
+	}
+}
@@ -0,0 +1,107 @@
+package mutex
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestCancellableMutex_LockWithHooksAcquiredAndReleased(t *testing.T) {
+	// Arrange
+	mutex := NewCancellableMutex("hooks")
+	var events []string
+	hooks := Hooks{
+		OnAcquired:  func() { events = append(events, "acquired") },
+		OnCancelled: func(error) { events = append(events, "cancelled") },
+		OnReleased:  func() { events = append(events, "released") },
+	}
+
+	// Act
+	err := mutex.LockWithHooks(context.Background(), hooks)
+	locked := len(events)
+	mutex.Unlock()
+	mutex.Unlock() // A second Unlock must not rerun OnReleased
+
+	// Assert
+	if err != nil {
+		t.Fatalf("expected lock to succeed, got %v", err)
+	}
+	if locked != 1 {
+		t.Errorf("expected only OnAcquired while held, got %v", events)
+	}
+	if len(events) != 2 || events[0] != "acquired" || events[1] != "released" {
+		t.Errorf("expected [acquired released], got %v", events)
+	}
+}
+
+func TestCancellableMutex_LockWithHooksCancelled(t *testing.T) {
+	// Arrange
+	mutex := NewCancellableMutex("hooks-cancelled")
+	_ = mutex.Lock(context.Background())
+	defer mutex.Unlock()
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	var cancelled error
+	acquired := false
+
+	// Act
+	err := mutex.LockWithHooks(ctx, Hooks{
+		OnAcquired:  func() { acquired = true },
+		OnCancelled: func(err error) { cancelled = err },
+	})
+
+	// Assert
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected context.DeadlineExceeded, got %v", err)
+	}
+	if cancelled != err {
+		t.Errorf("expected OnCancelled to receive %v, got %v", err, cancelled)
+	}
+	if acquired {
+		t.Error("expected OnAcquired not to run for an abandoned attempt")
+	}
+}
+
+func TestCancellableMutex_LockWithHooksReleasedAfterHandoff(t *testing.T) {
+	// Arrange
+	mutex := NewCancellableMutex("hooks-handoff")
+	released := make(chan bool, 1)
+	_ = mutex.LockWithHooks(context.Background(), Hooks{
+		OnReleased: func() { released <- mutex.IsLocked() },
+	})
+	done := make(chan error)
+	go func() { done <- mutex.Lock(context.Background()) }()
+	waitForWaiters(t, mutex, 1)
+
+	// Act
+	mutex.Unlock()
+	err := <-done
+	lockedByWaiter := <-released
+	mutex.Unlock()
+
+	// Assert
+	if err != nil {
+		t.Fatalf("expected waiter to acquire the lock, got %v", err)
+	}
+	if !lockedByWaiter {
+		t.Error("expected OnReleased to run after the lock was handed to the waiter")
+	}
+}
+
+func TestCancellableMutex_LockWithHooksOnlyForItsHold(t *testing.T) {
+	// Arrange
+	mutex := NewCancellableMutex("hooks-once")
+	calls := 0
+	_ = mutex.LockWithHooks(context.Background(), Hooks{OnReleased: func() { calls++ }})
+	mutex.Unlock()
+
+	// Act
+	_ = mutex.Lock(context.Background())
+	mutex.Unlock()
+
+	// Assert
+	if calls != 1 {
+		t.Errorf("expected OnReleased to run once, got %d", calls)
+	}
+}
@@ -2,6 +2,14 @@ package mutex
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/zodimo/go-zbase-std/clock"
+	"github.com/zodimo/go-zbase-std/optional"
 )
 
 // CancellableMutex defines an interface for a mutex that supports cancellation through context.
@@ -10,32 +18,186 @@ type CancellableMutex interface {
 	// or the provided context is canceled. Returns an error if the context is canceled.
 	Lock(context.Context) error
 
+	// LockWithPriority behaves like Lock, but waiters with a higher priority
+	// are granted the lock before waiters with a lower one. Waiters of equal
+	// priority are served in arrival order.
+	LockWithPriority(ctx context.Context, priority int) error
+
+	// LockPreempting behaves like Lock, but additionally asks the current
+	// holder to yield by closing the channel returned by its Preempted call.
+	// Preemption is cooperative: the holder decides when to Unlock.
+	LockPreempting(ctx context.Context) error
+
+	// TryLockUntil attempts to acquire the lock, waiting no later than t.
+	// It reports whether the lock was acquired; an error is returned only
+	// if the attempt was rejected for another reason, such as ClosedError.
+	TryLockUntil(t time.Time) (bool, error)
+
+	// LockWithRetry acquires the lock by repeatedly trying it without
+	// blocking, pausing between attempts for the delays produced by
+	// backoff. It gives up with RetriesExhaustedError once backoff has no
+	// further delays, or when ctx is canceled.
+	LockWithRetry(ctx context.Context, backoff Strategy) error
+
+	// LockWithHooks behaves like Lock, additionally running the hooks of
+	// the hold: OnAcquired once the lock is acquired, OnCancelled if the
+	// attempt is abandoned, and OnReleased once the hold is unlocked.
+	LockWithHooks(ctx context.Context, hooks Hooks) error
+
+	// Preempted returns a channel that is closed when a waiter requests
+	// preemption of the current hold. Holders should call it after Lock
+	// succeeds. It returns nil when the mutex is not locked.
+	Preempted() <-chan struct{}
+
 	// Unlock releases the lock, allowing it to be acquired by another operation.
 	Unlock()
 
+	// Locker returns a sync.Locker that acquires the mutex with ctx and
+	// panics if ctx is canceled first, for APIs such as sync.Cond that
+	// require a sync.Locker.
+	Locker(ctx context.Context) sync.Locker
+
 	// GetKey returns the unique key associated with this mutex.
 	GetKey() string
 
 	// IsLocked returns whether the mutex is currently locked.
 	IsLocked() bool
+
+	// Holder returns information about the current holder of the lock, or
+	// an empty optional if the mutex is not locked.
+	Holder() optional.Option[HolderInfo]
+
+	// FairnessStats returns statistics about how long Lock calls had to
+	// wait for the mutex.
+	FairnessStats() FairnessStats
 }
 
+// DefaultPriority is the priority used by Lock. Waiters with a higher
+// priority are granted the lock before waiters with a lower one.
+const DefaultPriority = 0
+
+// State bits of a cancellableMutex.
+const (
+	// mutexLocked is set while the mutex is held.
+	mutexLocked int32 = 1 << iota
+
+	// mutexWaiters is set while the wait queue may be non-empty. It forces
+	// Unlock onto the slow path so queued waiters are not missed.
+	mutexWaiters
+
+	// mutexClosed is set once the mutex has been shut down by its registry.
+	// It forces Lock onto the slow path, where the attempt is rejected.
+	mutexClosed
+)
+
 // cancellableMutex is an implementation of the CancellableMutex interface.
-// It uses a channel to manage lock state and supports context-based cancellation.
+// Uncontended Lock and Unlock calls are a single compare-and-swap on state.
+// Under contention, waiters are parked in a priority-ordered queue and the
+// lock is handed directly to the next waiter on Unlock, which supports
+// context-based cancellation without losing ordering.
 type cancellableMutex struct {
 	// key is the unique identifier for this mutex.
 	key string
 
-	// lockChannel is a channel used to manage the lock state of the mutex.
-	lockChannel chan struct{}
+	// state holds the mutexLocked, mutexWaiters and mutexClosed bits.
+	state atomic.Int32
+
+	// mu guards the wait queue and slow-path transitions of state.
+	mu sync.Mutex
+
+	// queue holds the Lock calls currently blocked on the mutex.
+	queue waitQueue
+
+	// seq is the arrival counter assigned to the next waiter.
+	seq uint64
+
+	// drained is closed once the queue empties, if someone is waiting for
+	// that to happen; see waitIdle.
+	drained chan struct{}
+
+	// clock is the source of time for timestamps and timeouts.
+	clock Clock
+
+	// createdAt records when the mutex was created.
+	createdAt time.Time
+
+	// acquiredAt records, in Unix nanoseconds, when the lock was acquired.
+	acquiredAt atomic.Int64
+
+	// record identifies the current holder, or is nil if the holder
+	// supplied no label and no stack was captured.
+	record atomic.Pointer[holderRecord]
 
-	// locked indicates whether the mutex is currently locked.
-	locked bool
+	// preempt signals preemption of the current hold. It is created on
+	// demand and cleared whenever the lock changes hands.
+	preempt atomic.Pointer[preemptSignal]
+
+	// lockTimeout bounds Lock calls whose context has no deadline.
+	lockTimeout time.Duration
+
+	// holdWarningAfter is the hold duration after which holdWarning runs.
+	holdWarningAfter time.Duration
+
+	// holdWarning is called when a hold exceeds holdWarningAfter.
+	holdWarning HoldWarningFunc
+
+	// holds counts acquisitions, identifying the current hold.
+	holds atomic.Uint64
+
+	// holdTimer fires holdWarning for the current hold, or is nil.
+	holdTimer atomic.Pointer[Timer]
+
+	// onReleased is the OnReleased hook of the current hold, or nil.
+	onReleased atomic.Pointer[func()]
+
+	// captureStacks enables recording the holder's stack trace.
+	captureStacks bool
+
+	// spinIterations and spinFor bound spinning before Lock parks.
+	spinIterations int
+	spinFor        time.Duration
+
+	// starvationAfter is the wait after which a waiter counts as starved.
+	starvationAfter time.Duration
+
+	// starvation is called for waiters queued longer than starvationAfter.
+	starvation StarvationFunc
+
+	// waits, totalWait and maxWait accumulate FairnessStats; the durations
+	// are in nanoseconds.
+	waits     atomic.Int64
+	totalWait atomic.Int64
+	maxWait   atomic.Int64
+
+	// starved counts completed waits longer than starvationAfter.
+	starved atomic.Int64
+}
+
+// preemptSignal is a channel closed at most once to request preemption.
+type preemptSignal struct {
+	ch   chan struct{}
+	once sync.Once
 }
 
 // IsLocked returns whether the mutex is currently in a locked state.
 func (cm *cancellableMutex) IsLocked() bool {
-	return cm.locked
+	return cm.state.Load()&mutexLocked != 0
+}
+
+// Holder returns information about the current holder of the lock, or an
+// empty optional if the mutex is not locked.
+func (cm *cancellableMutex) Holder() optional.Option[HolderInfo] {
+	if !cm.IsLocked() {
+		return optional.None[HolderInfo]()
+	}
+	info := HolderInfo{
+		AcquiredAt: time.Unix(0, cm.acquiredAt.Load()),
+	}
+	if record := cm.record.Load(); record != nil {
+		info.Label = record.label
+		info.Stack = record.stack
+	}
+	return optional.Some(info)
 }
 
 // GetKey returns the unique key associated with this mutex.
@@ -45,45 +207,269 @@ func (cm *cancellableMutex) GetKey() string {
 
 // GetOrNewCancellableMutex retrieves an existing CancellableMutex with the given key
 // from the mutex registry, or creates a new one if it doesn't exist.
+//
+// Once the registry is closed, the returned mutex rejects every Lock attempt
+// with ClosedError. It panics if an OnRegister hook rejects the key, since an
+// unregistered mutex would not exclude other callers; use
+// GetMutexRegistry().GetOrNew to handle rejections as errors.
 func GetOrNewCancellableMutex(key string) CancellableMutex {
-	optionalRegistry := GetMutexRegistry().GetMutex(key)
-	maybeMutex, some := optionalRegistry.Value()
-	if some {
-		return maybeMutex.(CancellableMutex)
+	mutex, err := GetMutexRegistry().GetOrNew(key)
+	switch {
+	case errors.Is(err, ClosedError):
+		closed := NewCancellableMutex(key).(*cancellableMutex)
+		closed.shutdown()
+		return closed
+	case err != nil:
+		panic(fmt.Errorf("mutex: GetOrNewCancellableMutex(%q): %w", key, err))
 	}
-	mutex := NewCancellableMutex(key)
-	_ = GetMutexRegistry().Register(mutex)
 	return mutex
 }
 
-// NewCancellableMutex creates and returns a new CancellableMutex with the given key.
-// The mutex uses a buffered channel to manage its lock state.
-func NewCancellableMutex(key string) CancellableMutex {
-	return &cancellableMutex{
-		lockChannel: make(chan struct{}, 1),
-		key:         key,
+// NewCancellableMutex creates and returns a new CancellableMutex with the given key,
+// configured by the given options.
+func NewCancellableMutex(key string, opts ...MutexOption) CancellableMutex {
+	cm := &cancellableMutex{
+		key:   key,
+		clock: clock.System(),
+	}
+	for _, opt := range opts {
+		opt(cm)
 	}
+	cm.createdAt = cm.clock.Now()
+	return cm
 }
 
 // Lock attempts to acquire the lock. If the lock is acquired successfully, the method
 // returns nil. If the provided context is canceled or times out before the lock
-// is acquired, the method returns an error.
+// is acquired, the method returns a *LockCancelledError wrapping the context
+// error. A label attached to ctx through
+// WithHolderLabel is recorded in the HolderInfo returned by Holder.
 func (cm *cancellableMutex) Lock(ctx context.Context) error {
+	return cm.lock(ctx, lockRequest{priority: DefaultPriority})
+}
+
+// LockWithPriority attempts to acquire the lock, queueing behind waiters of
+// a higher or equal priority. It returns an error if the provided context is
+// canceled or times out before the lock is handed to the caller.
+func (cm *cancellableMutex) LockWithPriority(ctx context.Context, priority int) error {
+	return cm.lock(ctx, lockRequest{priority: priority})
+}
+
+// LockPreempting attempts to acquire the lock and, if it is held, signals the
+// current holder through its Preempted channel that a waiter wants it.
+func (cm *cancellableMutex) LockPreempting(ctx context.Context) error {
+	return cm.lock(ctx, lockRequest{priority: DefaultPriority, preempt: true})
+}
+
+// Preempted returns a channel that is closed when a waiter requests
+// preemption of the current hold, or nil when the mutex is not locked.
+func (cm *cancellableMutex) Preempted() <-chan struct{} {
+	if !cm.IsLocked() {
+		return nil
+	}
+	return cm.preemptSignal().ch
+}
+
+// preemptSignal returns the preemption signal of the current hold, creating
+// it if needed.
+func (cm *cancellableMutex) preemptSignal() *preemptSignal {
+	if sig := cm.preempt.Load(); sig != nil {
+		return sig
+	}
+	sig := &preemptSignal{ch: make(chan struct{})}
+	if cm.preempt.CompareAndSwap(nil, sig) {
+		return sig
+	}
+	return cm.preempt.Load()
+}
+
+// lockRequest describes how a Lock variant wants to acquire the mutex.
+type lockRequest struct {
+	// priority orders the caller among other waiters.
+	priority int
+
+	// preempt asks the current holder to yield before queueing.
+	preempt bool
+
+	// deadline, if non-zero, bounds the wait using the mutex's clock.
+	deadline time.Time
+}
+
+// lock implements the Lock variants.
+func (cm *cancellableMutex) lock(ctx context.Context, req lockRequest) error {
+	holder := cm.newHolderRecord(ctx)
+	if cm.state.CompareAndSwap(0, mutexLocked) {
+		cm.acquired(holder)
+		return nil // Lock acquired without contention
+	}
+	if cm.spinIterations > 0 && cm.spin(ctx, holder) {
+		return nil // Lock acquired while spinning
+	}
+	return cm.lockSlow(ctx, req, holder)
+}
+
+// lockSlow acquires the lock under contention by parking in the wait queue
+// until Unlock hands the lock over or the context is canceled.
+func (cm *cancellableMutex) lockSlow(ctx context.Context, req lockRequest, holder holderRecord) error {
+	start := cm.clock.Now()
+	cm.mu.Lock()
+	for {
+		s := cm.state.Load()
+		if s&mutexClosed != 0 {
+			cm.mu.Unlock()
+			return &LockCancelledError{Key: cm.key, Cause: ClosedError}
+		}
+		if s&mutexLocked == 0 {
+			if cm.state.CompareAndSwap(s, s|mutexLocked) {
+				cm.mu.Unlock()
+				cm.acquired(holder)
+				return nil // Lock released while taking the slow path
+			}
+			continue
+		}
+		if s&mutexWaiters != 0 || cm.state.CompareAndSwap(s, s|mutexWaiters) {
+			break
+		}
+	}
+	w := &waiter{
+		priority: req.priority,
+		seq:      cm.seq,
+		holder:   holder,
+		ready:    make(chan struct{}),
+	}
+	cm.seq++
+	cm.queue.enqueue(w)
+	if req.preempt {
+		sig := cm.preemptSignal()
+		sig.once.Do(func() { close(sig.ch) })
+	}
+	cm.mu.Unlock()
+
+	var expired <-chan time.Time
+	if !req.deadline.IsZero() {
+		expired = cm.clock.After(req.deadline.Sub(start))
+	} else if _, ok := ctx.Deadline(); !ok && cm.lockTimeout > 0 {
+		expired = cm.clock.After(cm.lockTimeout)
+	}
+	if starving := cm.watchStarvation(); starving != nil {
+		defer starving.Stop()
+	}
+	var err error
 	select {
-	case cm.lockChannel <- struct{}{}:
-		cm.locked = true
-		return nil // Lock acquired
+	case <-w.ready:
+		if w.aborted {
+			err = ClosedError // Aborted by shutdown
+			break
+		}
+		cm.recordWait(cm.clock.Now().Sub(start))
+		return nil // Lock handed over by Unlock
 	case <-ctx.Done():
-		return ctx.Err() // Context cancelled or timeout
+		err = ctx.Err() // Context cancelled or timeout
+	case <-expired:
+		err = context.DeadlineExceeded // Lock deadline or default timeout
+	}
+
+	cm.mu.Lock()
+	defer cm.mu.Unlock()
+	select {
+	case <-w.ready:
+		// Unless it was aborted, the lock was handed over while the context
+		// was being cancelled; pass it on so it is not leaked.
+		if !w.aborted {
+			cm.release()
+		}
+	default:
+		cm.queue.remove(w)
+		cm.dequeued()
+	}
+	waited := cm.clock.Now().Sub(start)
+	cm.recordWait(waited)
+	lockErr := &LockCancelledError{
+		Key:    cm.key,
+		Waited: waited,
+		Cause:  err,
+	}
+	if record := cm.record.Load(); record != nil {
+		lockErr.HolderStack = record.stack
 	}
+	return lockErr
 }
 
 // Unlock releases the lock, allowing it to be acquired by another operation.
 // It is safe to call Unlock only if the lock is currently held.
+// The OnReleased hook of the hold, if any, runs once the lock is released.
 func (cm *cancellableMutex) Unlock() {
-	if cm.locked {
-		<-cm.lockChannel // Release the lock
-		cm.locked = false
+	if !cm.IsLocked() {
+		return
+	}
+	if released := cm.onReleased.Swap(nil); released != nil {
+		defer (*released)()
+	}
+	cm.record.Store(nil)
+	cm.preempt.Store(nil)
+	cm.stopHoldTimer()
+	if cm.state.CompareAndSwap(mutexLocked, 0) {
+		return // Released without waiters
+	}
+	cm.mu.Lock()
+	defer cm.mu.Unlock()
+	if cm.IsLocked() {
+		cm.release()
+	}
+}
+
+// acquired records the holder of a freshly acquired lock.
+func (cm *cancellableMutex) acquired(holder holderRecord) {
+	cm.acquiredAt.Store(cm.clock.Now().UnixNano())
+	if holder != (holderRecord{}) {
+		copied := holder // Copied so only identified holds allocate.
+		cm.record.Store(&copied)
+	}
+	hold := cm.holds.Add(1)
+	if cm.holdWarning != nil && cm.holdWarningAfter > 0 {
+		timer := cm.clock.AfterFunc(cm.holdWarningAfter, func() {
+			if cm.holds.Load() == hold && cm.IsLocked() {
+				cm.holdWarning(cm, cm.holdWarningAfter)
+			}
+		})
+		cm.holdTimer.Store(&timer)
+	}
+}
+
+// stopHoldTimer cancels the hold warning of the current hold, if any.
+func (cm *cancellableMutex) stopHoldTimer() {
+	if timer := cm.holdTimer.Swap(nil); timer != nil {
+		(*timer).Stop()
+	}
+}
+
+// release hands the lock to the next queued waiter, or unlocks the mutex if
+// nobody is waiting. cm.mu must be held and the mutex must be locked.
+func (cm *cancellableMutex) release() {
+	cm.record.Store(nil)
+	cm.preempt.Store(nil)
+	cm.stopHoldTimer()
+	if cm.queue.Len() == 0 {
+		cm.state.And(mutexClosed)
+		return
+	}
+	next := cm.queue.dequeue()
+	cm.dequeued()
+	cm.acquired(next.holder)
+	close(next.ready)
+}
+
+// dequeued updates the state after a waiter has left the queue, clearing
+// mutexWaiters and waking waitIdle once the queue is empty. cm.mu must be
+// held.
+func (cm *cancellableMutex) dequeued() {
+	if cm.queue.Len() > 0 {
+		return
+	}
+	cm.state.And(^mutexWaiters)
+	if cm.drained != nil {
+		close(cm.drained)
+		cm.drained = nil
 	}
 }
 
@@ -169,3 +169,244 @@ func TestCancellableMutex_IsLocked(t *testing.T) {
 		t.Error("expected mutex to be unlocked after calling Unlock")
 	}
 }
+
+// waitForWaiters polls until the mutex has n queued waiters.
+func waitForWaiters(t *testing.T, mutex CancellableMutex, n int) {
+	t.Helper()
+	cm := mutex.(*cancellableMutex)
+	for deadline := time.Now().Add(time.Second); time.Now().Before(deadline); {
+		if cm.waiterCount() == n {
+			return
+		}
+		time.Sleep(time.Millisecond)
+	}
+	t.Fatalf("timed out waiting for %d waiters, have %d", n, cm.waiterCount())
+}
+
+func TestCancellableMutex_LockWithPriority(t *testing.T) {
+	// Arrange
+	mutex := NewCancellableMutex("test-priority")
+	ctx := context.Background()
+	if err := mutex.Lock(ctx); err != nil {
+		t.Fatalf("failed to lock mutex: %v", err)
+	}
+	order := make(chan string, 3)
+	lockAs := func(name string, priority int) {
+		if err := mutex.LockWithPriority(ctx, priority); err != nil {
+			t.Errorf("unexpected error for %s: %v", name, err)
+			return
+		}
+		order <- name
+		mutex.Unlock()
+	}
+
+	// Act
+	go lockAs("background", 0)
+	waitForWaiters(t, mutex, 1)
+	go lockAs("request", 10)
+	waitForWaiters(t, mutex, 2)
+	go lockAs("background-2", 0)
+	waitForWaiters(t, mutex, 3)
+	mutex.Unlock()
+
+	// Assert
+	want := []string{"request", "background", "background-2"}
+	for _, name := range want {
+		if got := <-order; got != name {
+			t.Errorf("expected %s to acquire next, got %s", name, got)
+		}
+	}
+}
+
+func TestCancellableMutex_CancelledWaiterLeavesQueue(t *testing.T) {
+	// Arrange
+	mutex := NewCancellableMutex("test-cancel-queue")
+	_ = mutex.Lock(context.Background())
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() { done <- mutex.LockWithPriority(ctx, 100) }()
+	waitForWaiters(t, mutex, 1)
+
+	// Act
+	cancel()
+	err := <-done
+	mutex.Unlock()
+
+	// Assert
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context.Canceled, got %v", err)
+	}
+	if mutex.IsLocked() {
+		t.Error("expected mutex to be unlocked after the cancelled waiter left the queue")
+	}
+}
+
+func TestCancellableMutex_PreemptedUnlocked(t *testing.T) {
+	// Arrange
+	mutex := NewCancellableMutex("test-preempt")
+
+	// Act
+	ch := mutex.Preempted()
+
+	// Assert
+	if ch != nil {
+		t.Error("expected nil preemption channel for an unlocked mutex")
+	}
+}
+
+func TestCancellableMutex_LockPreempting(t *testing.T) {
+	// Arrange
+	mutex := NewCancellableMutex("test-preempt")
+	ctx := context.Background()
+	if err := mutex.Lock(ctx); err != nil {
+		t.Fatalf("failed to lock mutex: %v", err)
+	}
+	preempted := mutex.Preempted()
+	acquired := make(chan error)
+
+	// Act
+	go func() { acquired <- mutex.LockPreempting(ctx) }()
+
+	// Assert: the holder is asked to yield
+	select {
+	case <-preempted:
+	case <-time.After(time.Second):
+		t.Fatal("expected holder to observe preemption request")
+	}
+
+	// Act: the holder yields
+	mutex.Unlock()
+
+	// Assert: the preempting waiter gets the lock with a fresh channel
+	if err := <-acquired; err != nil {
+		t.Fatalf("expected preempting waiter to acquire the lock, got %v", err)
+	}
+	select {
+	case <-mutex.Preempted():
+		t.Error("expected new hold not to be preempted")
+	default:
+	}
+	mutex.Unlock()
+}
+
+func TestCancellableMutex_LockDoesNotPreempt(t *testing.T) {
+	// Arrange
+	mutex := NewCancellableMutex("test-no-preempt")
+	_ = mutex.Lock(context.Background())
+	preempted := mutex.Preempted()
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	// Act
+	_ = mutex.Lock(ctx)
+
+	// Assert
+	select {
+	case <-preempted:
+		t.Error("expected plain Lock not to request preemption")
+	default:
+	}
+	mutex.Unlock()
+}
+
+// chanMutex is the original buffered-channel design, kept as a benchmark
+// baseline for the cancellableMutex implementation.
+type chanMutex chan struct{}
+
+func (c chanMutex) Lock(ctx context.Context) error {
+	select {
+	case c <- struct{}{}:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (c chanMutex) Unlock() { <-c }
+
+func BenchmarkCancellableMutex_Uncontended(b *testing.B) {
+	mutex := NewCancellableMutex("bench")
+	ctx := context.Background()
+	for b.Loop() {
+		_ = mutex.Lock(ctx)
+		mutex.Unlock()
+	}
+}
+
+func BenchmarkCancellableMutex_Contended(b *testing.B) {
+	mutex := NewCancellableMutex("bench")
+	ctx := context.Background()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			_ = mutex.Lock(ctx)
+			mutex.Unlock()
+		}
+	})
+}
+
+func BenchmarkChannelMutex_Uncontended(b *testing.B) {
+	mutex := make(chanMutex, 1)
+	ctx := context.Background()
+	for b.Loop() {
+		_ = mutex.Lock(ctx)
+		mutex.Unlock()
+	}
+}
+
+func BenchmarkChannelMutex_Contended(b *testing.B) {
+	mutex := make(chanMutex, 1)
+	ctx := context.Background()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			_ = mutex.Lock(ctx)
+			mutex.Unlock()
+		}
+	})
+}
+
+func TestCancellableMutex_ContendedMutualExclusion(t *testing.T) {
+	// Arrange
+	mutex := NewCancellableMutex("test-contended")
+	const goroutines, iterations = 8, 500
+	var counter, inside int
+	done := make(chan struct{})
+
+	// Act: half the lockers use short timeouts so cancellation races handoff
+	for g := 0; g < goroutines; g++ {
+		go func(g int) {
+			defer func() { done <- struct{}{} }()
+			for i := 0; i < iterations; i++ {
+				ctx, cancel := context.Background(), context.CancelFunc(func() {})
+				if g%2 == 1 {
+					ctx, cancel = context.WithTimeout(ctx, time.Microsecond)
+				}
+				if err := mutex.Lock(ctx); err != nil {
+					cancel()
+					continue
+				}
+				inside++
+				if inside != 1 {
+					t.Errorf("expected exclusive access, %d holders inside", inside)
+				}
+				counter++
+				inside--
+				mutex.Unlock()
+				cancel()
+			}
+		}(g)
+	}
+	for g := 0; g < goroutines; g++ {
+		<-done
+	}
+
+	// Assert
+	if counter < goroutines/2*iterations {
+		t.Errorf("expected at least %d uncancelled acquisitions, got %d", goroutines/2*iterations, counter)
+	}
+	if mutex.IsLocked() {
+		t.Error("expected mutex to be unlocked after all goroutines finished")
+	}
+	if n := mutex.(*cancellableMutex).waiterCount(); n != 0 {
+		t.Errorf("expected empty wait queue, got %d waiters", n)
+	}
+}
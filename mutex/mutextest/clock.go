@@ -0,0 +1,17 @@
+package mutextest
+
+import (
+	"time"
+
+	"github.com/zodimo/go-zbase-std/clock"
+)
+
+// FakeClock is a mutex.Clock whose time only moves when Advance or Set is
+// called. It is an alias of clock.FakeClock, kept so existing tests can use
+// the mutextest package alone.
+type FakeClock = clock.FakeClock
+
+// NewFakeClock creates a FakeClock set to the given time.
+func NewFakeClock(now time.Time) *FakeClock {
+	return clock.NewFakeClock(now)
+}
@@ -0,0 +1,124 @@
+package mutextest
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/zodimo/go-zbase-std/mutex"
+)
+
+// Compile-time check that the fake satisfies the interface.
+var _ mutex.Clock = (*FakeClock)(nil)
+
+func TestFakeClock_Advance(t *testing.T) {
+	// Arrange
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	clock := NewFakeClock(start)
+	ch := clock.After(time.Minute)
+
+	// Act
+	clock.Advance(30 * time.Second)
+
+	// Assert
+	select {
+	case <-ch:
+		t.Fatal("expected After channel not to fire before its deadline")
+	default:
+	}
+	clock.Advance(30 * time.Second)
+	select {
+	case got := <-ch:
+		if !got.Equal(start.Add(time.Minute)) {
+			t.Errorf("expected fired time %v, got %v", start.Add(time.Minute), got)
+		}
+	default:
+		t.Fatal("expected After channel to fire at its deadline")
+	}
+	if clock.Pending() != 0 {
+		t.Errorf("expected no pending waiters, got %d", clock.Pending())
+	}
+}
+
+func TestFakeClock_InjectedIntoMutex(t *testing.T) {
+	// Arrange
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	clock := NewFakeClock(start)
+	m := mutex.NewCancellableMutex("clocked", mutex.WithClock(clock))
+	clock.Advance(time.Hour)
+
+	// Act
+	_ = m.Lock(context.Background())
+	holder := m.Holder()
+
+	// Assert
+	info, _ := holder.Value()
+	if !info.AcquiredAt.Equal(start.Add(time.Hour)) {
+		t.Errorf("expected acquisition time from fake clock, got %v", info.AcquiredAt)
+	}
+}
+
+func TestFakeClock_AfterFuncStop(t *testing.T) {
+	// Arrange
+	clock := NewFakeClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	fired := make(chan struct{}, 1)
+	timer := clock.AfterFunc(time.Second, func() { fired <- struct{}{} })
+
+	// Act
+	stopped := timer.Stop()
+	clock.Advance(time.Minute)
+
+	// Assert
+	if !stopped {
+		t.Error("expected Stop to report the pending call was cancelled")
+	}
+	select {
+	case <-fired:
+		t.Error("expected stopped AfterFunc not to fire")
+	case <-time.After(10 * time.Millisecond):
+	}
+}
+
+func TestFakeClock_DrivesLockTimeout(t *testing.T) {
+	// Arrange
+	clock := NewFakeClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	m := mutex.NewCancellableMutex("timed", mutex.WithClock(clock), mutex.WithLockTimeout(time.Minute))
+	_ = m.Lock(context.Background())
+	done := make(chan error)
+	go func() { done <- m.Lock(context.Background()) }()
+	for clock.Pending() == 0 {
+		time.Sleep(time.Millisecond)
+	}
+
+	// Act
+	clock.Advance(time.Minute)
+
+	// Assert
+	if err := <-done; !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected fake clock to expire the lock timeout, got %v", err)
+	}
+}
+
+func TestFakeClock_DrivesHoldWarning(t *testing.T) {
+	// Arrange
+	clock := NewFakeClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	warned := make(chan time.Duration, 1)
+	m := mutex.NewCancellableMutex("held", mutex.WithClock(clock), mutex.WithHoldWarning(time.Hour, func(_ mutex.CancellableMutex, heldFor time.Duration) {
+		warned <- heldFor
+	}))
+	_ = m.Lock(context.Background())
+
+	// Act
+	clock.Advance(time.Hour)
+
+	// Assert
+	select {
+	case heldFor := <-warned:
+		if heldFor != time.Hour {
+			t.Errorf("expected warning after 1h, got %v", heldFor)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("expected fake clock to fire the hold warning")
+	}
+}
@@ -0,0 +1,200 @@
+// Package mutextest provides test doubles for the mutex package: a
+// FakeCancellableMutex whose acquisitions can be held back and released
+// deterministically, and a FakeClock that only moves when told to.
+package mutextest
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/zodimo/go-zbase-std/mutex"
+)
+
+// FakeCancellableMutex is a CancellableMutex whose Lock calls can be parked
+// at a gate controlled by the test. While the gate is closed (after Block),
+// every Lock variant waits until Release is called or its context is
+// canceled. Once past the gate, locking behaves like a real
+// CancellableMutex, which is embedded to provide the remaining methods.
+type FakeCancellableMutex struct {
+	mutex.CancellableMutex
+
+	mu       sync.Mutex
+	gate     chan struct{} // closed while acquisitions are allowed
+	parked   int           // Lock calls waiting at the gate
+	attempts int           // Lock calls made in total
+	changed  chan struct{} // closed and replaced when parked changes
+}
+
+// NewFakeCancellableMutex creates a FakeCancellableMutex with the given key
+// whose gate is initially open.
+func NewFakeCancellableMutex(key string) *FakeCancellableMutex {
+	gate := make(chan struct{})
+	close(gate)
+	return &FakeCancellableMutex{
+		CancellableMutex: mutex.NewCancellableMutex(key),
+		gate:             gate,
+		changed:          make(chan struct{}),
+	}
+}
+
+// Block closes the gate so subsequent Lock calls park until Release.
+func (f *FakeCancellableMutex) Block() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	select {
+	case <-f.gate:
+		f.gate = make(chan struct{})
+	default:
+	}
+}
+
+// Release opens the gate, letting every parked Lock call proceed.
+func (f *FakeCancellableMutex) Release() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	select {
+	case <-f.gate:
+	default:
+		close(f.gate)
+	}
+}
+
+// Parked returns the number of Lock calls currently waiting at the gate.
+func (f *FakeCancellableMutex) Parked() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.parked
+}
+
+// Attempts returns the number of Lock calls made on the mutex.
+func (f *FakeCancellableMutex) Attempts() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.attempts
+}
+
+// WaitParked blocks until at least n Lock calls are parked at the gate or
+// the context is canceled. It lets tests synchronize on a goroutine having
+// reached Lock without sleeping.
+func (f *FakeCancellableMutex) WaitParked(ctx context.Context, n int) error {
+	for {
+		f.mu.Lock()
+		parked, changed := f.parked, f.changed
+		f.mu.Unlock()
+		if parked >= n {
+			return nil
+		}
+		select {
+		case <-changed:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// Lock waits at the gate, then acquires the underlying mutex.
+func (f *FakeCancellableMutex) Lock(ctx context.Context) error {
+	if err := f.pass(ctx); err != nil {
+		return err
+	}
+	return f.CancellableMutex.Lock(ctx)
+}
+
+// LockWithPriority waits at the gate, then acquires the underlying mutex
+// with the given priority.
+func (f *FakeCancellableMutex) LockWithPriority(ctx context.Context, priority int) error {
+	if err := f.pass(ctx); err != nil {
+		return err
+	}
+	return f.CancellableMutex.LockWithPriority(ctx, priority)
+}
+
+// LockPreempting waits at the gate, then acquires the underlying mutex
+// while requesting preemption of the current holder.
+func (f *FakeCancellableMutex) LockPreempting(ctx context.Context) error {
+	if err := f.pass(ctx); err != nil {
+		return err
+	}
+	return f.CancellableMutex.LockPreempting(ctx)
+}
+
+// TryLockUntil waits at the gate no later than t, then tries to acquire
+// the underlying mutex until t.
+func (f *FakeCancellableMutex) TryLockUntil(t time.Time) (bool, error) {
+	ctx, cancel := context.WithDeadline(context.Background(), t)
+	defer cancel()
+	if err := f.pass(ctx); err != nil {
+		return false, nil
+	}
+	return f.CancellableMutex.TryLockUntil(t)
+}
+
+// LockWithRetry waits at the gate, then acquires the underlying mutex with
+// the given backoff strategy.
+func (f *FakeCancellableMutex) LockWithRetry(ctx context.Context, backoff mutex.Strategy) error {
+	if err := f.pass(ctx); err != nil {
+		return err
+	}
+	return f.CancellableMutex.LockWithRetry(ctx, backoff)
+}
+
+// LockWithHooks waits at the gate, then acquires the underlying mutex with
+// the given hooks. hooks.OnCancelled also runs if the wait at the gate is
+// abandoned.
+func (f *FakeCancellableMutex) LockWithHooks(ctx context.Context, hooks mutex.Hooks) error {
+	if err := f.pass(ctx); err != nil {
+		if hooks.OnCancelled != nil {
+			hooks.OnCancelled(err)
+		}
+		return err
+	}
+	return f.CancellableMutex.LockWithHooks(ctx, hooks)
+}
+
+// Locker returns a sync.Locker that acquires the fake, gate included, with
+// ctx.
+func (f *FakeCancellableMutex) Locker(ctx context.Context) sync.Locker {
+	return mutex.NewLocker(ctx, f)
+}
+
+// pass records a Lock attempt and waits for the gate to open. Like a real
+// mutex, it reports cancellation with a *mutex.LockCancelledError.
+func (f *FakeCancellableMutex) pass(ctx context.Context) error {
+	start := time.Now()
+	f.mu.Lock()
+	f.attempts++
+	gate := f.gate
+	select {
+	case <-gate:
+		f.mu.Unlock()
+		return nil
+	default:
+	}
+	f.setParked(f.parked + 1)
+	f.mu.Unlock()
+
+	defer func() {
+		f.mu.Lock()
+		f.setParked(f.parked - 1)
+		f.mu.Unlock()
+	}()
+	select {
+	case <-gate:
+		return nil
+	case <-ctx.Done():
+		return &mutex.LockCancelledError{
+			Key:    f.GetKey(),
+			Waited: time.Since(start),
+			Cause:  ctx.Err(),
+		}
+	}
+}
+
+// setParked updates the parked count and wakes WaitParked callers.
+// f.mu must be held.
+func (f *FakeCancellableMutex) setParked(n int) {
+	f.parked = n
+	close(f.changed)
+	f.changed = make(chan struct{})
+}
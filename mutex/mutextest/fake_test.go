@@ -0,0 +1,98 @@
+package mutextest
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/zodimo/go-zbase-std/mutex"
+)
+
+// Compile-time check that the fake satisfies the interface.
+var _ mutex.CancellableMutex = (*FakeCancellableMutex)(nil)
+
+func TestFakeCancellableMutex_OpenGate(t *testing.T) {
+	// Arrange
+	fake := NewFakeCancellableMutex("fake")
+
+	// Act
+	err := fake.Lock(context.Background())
+
+	// Assert
+	if err != nil {
+		t.Fatalf("expected Lock to succeed with an open gate, got %v", err)
+	}
+	if !fake.IsLocked() {
+		t.Error("expected fake to be locked")
+	}
+	if fake.Attempts() != 1 {
+		t.Errorf("expected 1 attempt, got %d", fake.Attempts())
+	}
+	fake.Unlock()
+}
+
+func TestFakeCancellableMutex_BlockAndRelease(t *testing.T) {
+	// Arrange
+	fake := NewFakeCancellableMutex("fake")
+	fake.Block()
+	done := make(chan error)
+	go func() { done <- fake.Lock(context.Background()) }()
+
+	// Act
+	if err := fake.WaitParked(context.Background(), 1); err != nil {
+		t.Fatalf("unexpected error waiting for parked Lock: %v", err)
+	}
+	if fake.IsLocked() {
+		t.Fatal("expected parked Lock not to have acquired the mutex")
+	}
+	fake.Release()
+
+	// Assert
+	if err := <-done; err != nil {
+		t.Fatalf("expected released Lock to succeed, got %v", err)
+	}
+	if fake.Parked() != 0 {
+		t.Errorf("expected no parked calls after Release, got %d", fake.Parked())
+	}
+	fake.Unlock()
+}
+
+func TestFakeCancellableMutex_BlockedCancel(t *testing.T) {
+	// Arrange
+	fake := NewFakeCancellableMutex("fake")
+	fake.Block()
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() { done <- fake.Lock(ctx) }()
+	_ = fake.WaitParked(context.Background(), 1)
+
+	// Act
+	cancel()
+
+	// Assert
+	if err := <-done; !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context.Canceled from parked Lock, got %v", err)
+	}
+}
+
+func TestFakeCancellableMutex_LockWithHooksCancelledAtGate(t *testing.T) {
+	// Arrange
+	fake := NewFakeCancellableMutex("fake")
+	fake.Block()
+	ctx, cancel := context.WithCancel(context.Background())
+	cancelled := make(chan error, 1)
+	done := make(chan error)
+	go func() {
+		done <- fake.LockWithHooks(ctx, mutex.Hooks{OnCancelled: func(err error) { cancelled <- err }})
+	}()
+	_ = fake.WaitParked(context.Background(), 1)
+
+	// Act
+	cancel()
+	err := <-done
+
+	// Assert
+	if got := <-cancelled; got != err {
+		t.Errorf("expected OnCancelled to receive %v, got %v", err, got)
+	}
+}
@@ -0,0 +1,127 @@
+package mutex
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/zodimo/go-zbase-std/errcode"
+	"github.com/zodimo/go-zbase-std/optional"
+)
+
+// NamespaceSeparator joins the segments of a namespaced registry key: the
+// mutex "c" registered through Namespace("a").Namespace("b") is stored
+// under "a/b/c". Keys used through a namespace must not contain it, so
+// every (namespace, key) pair maps to a distinct registry key.
+const NamespaceSeparator = "/"
+
+// InvalidKeyError is returned by Register and GetOrNew on a namespaced
+// registry when the key contains NamespaceSeparator.
+var InvalidKeyError = errcode.New(errcode.Invalid, "mutex key contains the namespace separator")
+
+// namespacedRegistry is a view of a mutexRegistry that prefixes every key
+// it is given. Mutexes registered through the view keep their own,
+// unprefixed key; only the registry key is prefixed.
+type namespacedRegistry struct {
+	root   *mutexRegistry // The registry that stores the mutexes.
+	prefix string         // Namespace segments, each followed by NamespaceSeparator.
+}
+
+// newNamespace returns the view nested under prefix for the given name.
+// It panics if name is empty or contains NamespaceSeparator other than as
+// a trailing character, which is ignored.
+func newNamespace(root *mutexRegistry, prefix, name string) *namespacedRegistry {
+	name = strings.TrimSuffix(name, NamespaceSeparator)
+	if name == "" || strings.Contains(name, NamespaceSeparator) {
+		panic(fmt.Sprintf("mutex: invalid namespace %q", name))
+	}
+	return &namespacedRegistry{root: root, prefix: prefix + name + NamespaceSeparator}
+}
+
+// registryKey returns the registry key for key, or false if key cannot be
+// used in a namespace.
+func (n *namespacedRegistry) registryKey(key string) (string, bool) {
+	if strings.Contains(key, NamespaceSeparator) {
+		return "", false
+	}
+	return n.prefix + key, true
+}
+
+// HasMutex checks whether a mutex with the given key exists in the namespace.
+func (n *namespacedRegistry) HasMutex(key string) bool {
+	registryKey, ok := n.registryKey(key)
+	return ok && n.root.HasMutex(registryKey)
+}
+
+// GetMutex retrieves the mutex with the given key from the namespace.
+func (n *namespacedRegistry) GetMutex(key string) optional.Option[CancellableMutex] {
+	registryKey, ok := n.registryKey(key)
+	if !ok {
+		return optional.None[CancellableMutex]()
+	}
+	return n.root.GetMutex(registryKey)
+}
+
+// Register adds the mutex to the namespace under its own key. It returns
+// InvalidKeyError if the key contains NamespaceSeparator.
+func (n *namespacedRegistry) Register(mutex CancellableMutex) error {
+	registryKey, ok := n.registryKey(mutex.GetKey())
+	if !ok {
+		return fmt.Errorf("%w: %q", InvalidKeyError, mutex.GetKey())
+	}
+	return n.root.register(registryKey, mutex)
+}
+
+// GetOrNew retrieves the mutex with the given key from the namespace, or
+// creates and registers a new one. It returns InvalidKeyError if the key
+// contains NamespaceSeparator.
+func (n *namespacedRegistry) GetOrNew(key string) (CancellableMutex, error) {
+	registryKey, ok := n.registryKey(key)
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", InvalidKeyError, key)
+	}
+	return n.root.getOrNew(registryKey, key)
+}
+
+// Unregister removes the mutex with the given key from the namespace.
+func (n *namespacedRegistry) Unregister(key string) bool {
+	registryKey, ok := n.registryKey(key)
+	return ok && n.root.Unregister(registryKey)
+}
+
+// OnRegister adds a hook that is run before every registration in the
+// namespace.
+func (n *namespacedRegistry) OnRegister(hook RegisterHook) {
+	n.root.addRegisterHook(n.prefix, hook)
+}
+
+// OnUnregister adds a hook that is run after a mutex is unregistered from
+// the namespace.
+func (n *namespacedRegistry) OnUnregister(hook LifecycleHook) {
+	n.root.addUnregisterHook(n.prefix, hook)
+}
+
+// OnEvict adds a hook that is run after a mutex is evicted from the
+// namespace.
+func (n *namespacedRegistry) OnEvict(hook LifecycleHook) {
+	n.root.addEvictHook(n.prefix, hook)
+}
+
+// Snapshot returns a point-in-time view of the mutexes in the namespace,
+// including nested namespaces, keyed without the namespace prefix.
+func (n *namespacedRegistry) Snapshot() RegistrySnapshot {
+	snap := n.root.Snapshot()
+	own := snap.Mutexes[:0]
+	for _, m := range snap.Mutexes {
+		if key, ok := strings.CutPrefix(m.Key, n.prefix); ok {
+			m.Key = key
+			own = append(own, m)
+		}
+	}
+	snap.Mutexes = own
+	return snap
+}
+
+// Namespace returns a view nested inside this namespace.
+func (n *namespacedRegistry) Namespace(name string) MutexRegistry {
+	return newNamespace(n.root, n.prefix, name)
+}
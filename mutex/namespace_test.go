@@ -0,0 +1,177 @@
+package mutex
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestNamespace_NoCollisions(t *testing.T) {
+	// Arrange
+	resetRegistry()
+	reg := GetMutexRegistry()
+	billing := reg.Namespace("billing/")
+	search := reg.Namespace("search/")
+
+	// Act
+	errBilling := billing.Register(NewCancellableMutex("index"))
+	errSearch := search.Register(NewCancellableMutex("index"))
+
+	// Assert
+	if errBilling != nil || errSearch != nil {
+		t.Fatalf("expected same key in distinct namespaces to register, got %v and %v", errBilling, errSearch)
+	}
+	if !reg.HasMutex("billing/index") || !reg.HasMutex("search/index") {
+		t.Error("expected root registry to see both prefixed keys")
+	}
+	if reg.HasMutex("index") {
+		t.Error("expected unprefixed key not to be registered at the root")
+	}
+	if err := billing.Register(NewCancellableMutex("index")); !errors.Is(err, AlreadyRegisteredError) {
+		t.Errorf("expected duplicate in namespace to fail, got %v", err)
+	}
+}
+
+func TestNamespace_GetAndUnregister(t *testing.T) {
+	// Arrange
+	resetRegistry()
+	ns := GetMutexRegistry().Namespace("jobs/")
+	_ = ns.Register(NewCancellableMutex("nightly"))
+
+	// Act
+	opt := ns.GetMutex("nightly")
+
+	// Assert
+	mutex, some := opt.Value()
+	if !some {
+		t.Fatal("expected GetMutex through the namespace to find the mutex")
+	}
+	if mutex.GetKey() != "nightly" {
+		t.Errorf("expected mutex to keep its own key, got %q", mutex.GetKey())
+	}
+	if !ns.Unregister("nightly") || ns.HasMutex("nightly") {
+		t.Error("expected Unregister through the namespace to remove the mutex")
+	}
+}
+
+func TestNamespace_SnapshotOnlyOwnKeys(t *testing.T) {
+	// Arrange
+	resetRegistry()
+	reg := GetMutexRegistry()
+	ns := reg.Namespace("a/")
+	_ = ns.Register(NewCancellableMutex("one"))
+	_ = reg.Register(NewCancellableMutex("other"))
+
+	// Act
+	snap := ns.Snapshot()
+
+	// Assert
+	if len(snap.Mutexes) != 1 || snap.Mutexes[0].Key != "one" {
+		t.Errorf("expected namespace snapshot with only key one, got %+v", snap.Mutexes)
+	}
+	if len(reg.Snapshot().Mutexes) != 2 {
+		t.Error("expected root snapshot to include every namespace")
+	}
+}
+
+func TestNamespace_HooksScoped(t *testing.T) {
+	// Arrange
+	resetRegistry()
+	reg := GetMutexRegistry()
+	ns := reg.Namespace("scoped/")
+	var nsSeen, rootSeen int
+	ns.OnRegister(func(CancellableMutex) error { nsSeen++; return nil })
+	reg.OnRegister(func(CancellableMutex) error { rootSeen++; return nil })
+
+	// Act
+	_ = ns.Register(NewCancellableMutex("in"))
+	_ = reg.Register(NewCancellableMutex("out"))
+
+	// Assert
+	if nsSeen != 1 {
+		t.Errorf("expected namespace hook to see 1 registration, saw %d", nsSeen)
+	}
+	if rootSeen != 2 {
+		t.Errorf("expected root hook to see 2 registrations, saw %d", rootSeen)
+	}
+}
+
+func TestNamespace_Nested(t *testing.T) {
+	// Arrange
+	resetRegistry()
+	reg := GetMutexRegistry()
+
+	// Act
+	_ = reg.Namespace("a/").Namespace("b/").Register(NewCancellableMutex("c"))
+
+	// Assert
+	if !reg.HasMutex("a/b/c") {
+		t.Error("expected nested namespaces to combine their prefixes")
+	}
+}
+
+func TestNamespace_SiblingPrefixesAreSeparate(t *testing.T) {
+	// Arrange
+	reg := NewMutexRegistry()
+	user := reg.Namespace("user")
+	users := reg.Namespace("users")
+	var userHooks int
+	user.OnUnregister(func(CancellableMutex) { userHooks++ })
+	_ = user.Register(NewCancellableMutex("a"))
+	_ = users.Register(NewCancellableMutex("b"))
+	_ = reg.Register(NewCancellableMutex("userX"))
+
+	// Act
+	snap := user.Snapshot()
+	users.Unregister("b")
+	reg.Unregister("userX")
+	err := user.Close(context.Background())
+
+	// Assert
+	if len(snap.Mutexes) != 1 || snap.Mutexes[0].Key != "a" {
+		t.Errorf("expected user snapshot with only key a, got %+v", snap.Mutexes)
+	}
+	if err != nil {
+		t.Fatalf("expected namespace to close cleanly, got %v", err)
+	}
+	if userHooks != 1 {
+		t.Errorf("expected user hooks to fire only for its own key, fired %d times", userHooks)
+	}
+}
+
+func TestNamespace_KeysCannotCollideAcrossNamespaces(t *testing.T) {
+	// Arrange
+	reg := NewMutexRegistry()
+
+	// Act
+	errAB := reg.Namespace("ab").Register(NewCancellableMutex("c"))
+	errA := reg.Namespace("a").Register(NewCancellableMutex("bc"))
+	_, errSep := reg.Namespace("a").GetOrNew("b/c")
+
+	// Assert
+	if errAB != nil || errA != nil {
+		t.Fatalf("expected both registrations to succeed, got %v and %v", errAB, errA)
+	}
+	if !reg.HasMutex("ab/c") || !reg.HasMutex("a/bc") {
+		t.Error("expected distinct registry keys for ab+c and a+bc")
+	}
+	if !errors.Is(errSep, InvalidKeyError) {
+		t.Errorf("expected InvalidKeyError for a key containing the separator, got %v", errSep)
+	}
+	if reg.Namespace("a").HasMutex("b/c") {
+		t.Error("expected HasMutex to reject a key containing the separator")
+	}
+}
+
+func TestNamespace_PanicsOnInvalidName(t *testing.T) {
+	for _, name := range []string{"", "/", "a/b"} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("expected a panic for namespace %q", name)
+				}
+			}()
+			NewMutexRegistry().Namespace(name)
+		}()
+	}
+}
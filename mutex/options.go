@@ -0,0 +1,73 @@
+package mutex
+
+import (
+	"time"
+)
+
+// MutexOption configures a mutex created by NewCancellableMutex.
+type MutexOption func(*cancellableMutex)
+
+// HoldWarningFunc is called when a mutex has been held for longer than the
+// configured maximum. It runs in its own goroutine while the lock is still
+// held.
+type HoldWarningFunc func(mutex CancellableMutex, heldFor time.Duration)
+
+// WithClock makes the mutex read time from the given clock instead of the
+// system clock.
+func WithClock(clock Clock) MutexOption {
+	return func(cm *cancellableMutex) {
+		cm.clock = clock
+	}
+}
+
+// WithLockTimeout bounds how long Lock waits when the caller's context has
+// no deadline of its own. When the timeout expires, Lock returns
+// context.DeadlineExceeded. A zero duration disables the timeout.
+func WithLockTimeout(d time.Duration) MutexOption {
+	return func(cm *cancellableMutex) {
+		cm.lockTimeout = d
+	}
+}
+
+// WithHoldWarning calls warn whenever a single hold of the mutex lasts
+// longer than d. A zero duration disables the warning.
+func WithHoldWarning(d time.Duration, warn HoldWarningFunc) MutexOption {
+	return func(cm *cancellableMutex) {
+		cm.holdWarningAfter = d
+		cm.holdWarning = warn
+	}
+}
+
+// RegistryOption configures a registry created by NewMutexRegistry.
+type RegistryOption func(*mutexRegistry)
+
+// WithMutexOptions applies the given options to every mutex the registry
+// creates through GetOrNew.
+func WithMutexOptions(opts ...MutexOption) RegistryOption {
+	return func(mr *mutexRegistry) {
+		mr.mutexOptions = append(mr.mutexOptions, opts...)
+	}
+}
+
+// WithRegistryClock makes the registry read time from the given clock
+// instead of the system clock, both for its snapshots and for the mutexes
+// it creates through GetOrNew. See WithClock.
+func WithRegistryClock(clock Clock) RegistryOption {
+	return func(mr *mutexRegistry) {
+		mr.clock = clock
+		mr.mutexOptions = append(mr.mutexOptions, WithClock(clock))
+	}
+}
+
+// WithDefaultLockTimeout gives every mutex the registry creates a Lock
+// timeout, so Lock calls with background contexts still have a safety net.
+// See WithLockTimeout.
+func WithDefaultLockTimeout(d time.Duration) RegistryOption {
+	return WithMutexOptions(WithLockTimeout(d))
+}
+
+// WithMaxHoldWarning makes every mutex the registry creates call warn when
+// a hold lasts longer than d. See WithHoldWarning.
+func WithMaxHoldWarning(d time.Duration, warn HoldWarningFunc) RegistryOption {
+	return WithMutexOptions(WithHoldWarning(d, warn))
+}
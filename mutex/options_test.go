@@ -0,0 +1,119 @@
+package mutex
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestWithLockTimeout(t *testing.T) {
+	// Arrange
+	mutex := NewCancellableMutex("test-timeout", WithLockTimeout(10*time.Millisecond))
+	_ = mutex.Lock(context.Background())
+	defer mutex.Unlock()
+
+	// Act
+	err := mutex.Lock(context.Background())
+
+	// Assert
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected default timeout to expire with DeadlineExceeded, got %v", err)
+	}
+	if n := mutex.(*cancellableMutex).waiterCount(); n != 0 {
+		t.Errorf("expected timed-out waiter to leave the queue, have %d waiters", n)
+	}
+}
+
+func TestWithLockTimeout_ContextDeadlineWins(t *testing.T) {
+	// Arrange
+	mutex := NewCancellableMutex("test-timeout", WithLockTimeout(time.Millisecond))
+	_ = mutex.Lock(context.Background())
+	defer mutex.Unlock()
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+
+	// Act
+	_ = mutex.Lock(ctx)
+
+	// Assert
+	if waited := time.Since(start); waited < 25*time.Millisecond {
+		t.Errorf("expected the caller's deadline to take precedence, returned after %v", waited)
+	}
+}
+
+func TestWithHoldWarning(t *testing.T) {
+	// Arrange
+	warned := make(chan string, 1)
+	mutex := NewCancellableMutex("test-hold", WithHoldWarning(5*time.Millisecond, func(m CancellableMutex, heldFor time.Duration) {
+		warned <- m.GetKey()
+	}))
+
+	// Act
+	_ = mutex.Lock(context.Background())
+
+	// Assert
+	select {
+	case key := <-warned:
+		if key != "test-hold" {
+			t.Errorf("expected warning for test-hold, got %q", key)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("expected hold warning to fire")
+	}
+	mutex.Unlock()
+}
+
+func TestWithHoldWarning_NotFiredForShortHold(t *testing.T) {
+	// Arrange
+	warned := make(chan struct{}, 1)
+	mutex := NewCancellableMutex("test-hold", WithHoldWarning(20*time.Millisecond, func(CancellableMutex, time.Duration) {
+		warned <- struct{}{}
+	}))
+
+	// Act
+	_ = mutex.Lock(context.Background())
+	mutex.Unlock()
+
+	// Assert
+	select {
+	case <-warned:
+		t.Error("expected no warning for a hold released before the threshold")
+	case <-time.After(40 * time.Millisecond):
+	}
+}
+
+func TestNewMutexRegistry_DefaultLockTimeout(t *testing.T) {
+	// Arrange
+	reg := NewMutexRegistry(WithDefaultLockTimeout(10 * time.Millisecond))
+	mutex := mustGetOrNew(t, reg, "configured")
+	_ = mutex.Lock(context.Background())
+	defer mutex.Unlock()
+
+	// Act
+	err := mustGetOrNew(t, reg, "configured").Lock(context.Background())
+
+	// Assert
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected registry default timeout to apply, got %v", err)
+	}
+}
+
+func TestSetMutexRegistry(t *testing.T) {
+	// Arrange
+	defer resetRegistry()
+	reg := NewMutexRegistry()
+
+	// Act
+	SetMutexRegistry(reg)
+	mutex := GetOrNewCancellableMutex("global")
+
+	// Assert
+	if GetMutexRegistry() != reg {
+		t.Error("expected GetMutexRegistry to return the installed registry")
+	}
+	if !reg.HasMutex("global") || mutex.GetKey() != "global" {
+		t.Error("expected GetOrNewCancellableMutex to use the installed registry")
+	}
+}
@@ -4,30 +4,57 @@
 package mutex
 
 import (
+	"context"
 	"errors"
+	"strings"
 	"sync"
-	"sync/atomic"
 
+	"github.com/zodimo/go-zbase-std/clock"
+	"github.com/zodimo/go-zbase-std/errcode"
 	"github.com/zodimo/go-zbase-std/optional"
+	"github.com/zodimo/go-zbase-std/syncx"
 )
 
 // AlreadyRegisteredError is returned when attempting to register a mutex
 // that is already present in the MutexRegistry.
-var AlreadyRegisteredError = errors.New("mutex already registered")
+var AlreadyRegisteredError = errcode.New(errcode.Conflict, "mutex already registered")
 
-// registry holds the atomic reference to the global mutex registry.
-var registry = newAtomicRegistry()
+// registry holds the global mutex registry, which SetMutexRegistry can
+// replace while it is in use.
+var registry = syncx.NewReplaceable[MutexRegistry](newMutexRegistry())
+
+// RegisterHook is invoked before a mutex is added to a MutexRegistry.
+// Returning a non-nil error rejects the registration, which allows
+// applications to enforce naming policies on lock keys centrally.
+type RegisterHook func(mutex CancellableMutex) error
+
+// LifecycleHook is invoked after a mutex has been removed from a
+// MutexRegistry, either explicitly through Unregister or by eviction.
+type LifecycleHook func(mutex CancellableMutex)
 
 // mutexRegistry implements the MutexRegistry interface and provides
 // thread-safe operations on a map of cancellable mutexes.
 type mutexRegistry struct {
-	mutexMap sync.Map // Synchronizes access to the registered mutexes.
+	mutexMap syncx.Map[string, CancellableMutex] // Synchronizes access to the registered mutexes.
+
+	hooksMu         sync.RWMutex                // Guards the hook slices below.
+	registerHooks   []scopedHook[RegisterHook]  // Run before a mutex is registered.
+	unregisterHooks []scopedHook[LifecycleHook] // Run after a mutex is unregistered.
+	evictHooks      []scopedHook[LifecycleHook] // Run after an incomplete mutex is evicted.
+
+	mutexOptions []MutexOption // Applied to mutexes created by GetOrNew.
+
+	closedMu       sync.RWMutex // Guards closedPrefixes.
+	closedPrefixes []string     // Key prefixes closed by Close; "" closes every key.
+
+	clock Clock // Source of time for snapshots; set by WithRegistryClock.
 }
 
-// mutexRegistryHolder wraps a MutexRegistry for atomic operations,
-// allowing concurrent access and replacement of the underlying registry.
-type mutexRegistryHolder struct {
-	rh MutexRegistry // The wrapped MutexRegistry implementation.
+// scopedHook is a hook that only applies to keys starting with prefix.
+// Hooks added on the root registry have an empty prefix.
+type scopedHook[H any] struct {
+	prefix string
+	hook   H
 }
 
 // MutexRegistry defines the interface for managing cancellable mutexes.
@@ -65,33 +92,101 @@ type MutexRegistry interface {
 	//
 	// Returns:
 	//   - error: AlreadyRegisteredError if a mutex with the same key exists;
-	//     nil otherwise.
+	//     ClosedError if the registry has been closed; nil otherwise.
 	Register(mutex CancellableMutex) error
+
+	// GetOrNew retrieves the mutex with the given key, or creates and
+	// registers a new one configured with the registry's options.
+	//
+	// Parameters:
+	//   - key: The unique key identifying the mutex.
+	//
+	// Returns:
+	//   - CancellableMutex: The registered mutex for the key, or nil on error.
+	//   - error: ClosedError if the registry has been closed, or the error of
+	//     an OnRegister hook that rejected the new mutex.
+	GetOrNew(key string) (CancellableMutex, error)
+
+	// Unregister removes the mutex with the given key from the registry.
+	//
+	// Parameters:
+	//   - key: The unique key identifying the mutex.
+	//
+	// Returns:
+	//   - bool: True if a mutex was removed; false if none was registered.
+	Unregister(key string) bool
+
+	// OnRegister adds a hook that is run before every registration. If any
+	// hook returns an error, Register returns that error and the mutex is
+	// not added to the registry.
+	OnRegister(hook RegisterHook)
+
+	// OnUnregister adds a hook that is run after a mutex has been removed
+	// through Unregister.
+	OnUnregister(hook LifecycleHook)
+
+	// OnEvict adds a hook that is run after the registry has dropped a
+	// mutex on its own, for example because it was found to be incomplete.
+	OnEvict(hook LifecycleHook)
+
+	// Snapshot returns a point-in-time, JSON-serializable view of all
+	// registered mutexes, for diagnostics.
+	//
+	// Returns:
+	//   - RegistrySnapshot: The state of every registered mutex.
+	Snapshot() RegistrySnapshot
+
+	// Namespace returns a view of the registry whose keys are stored under
+	// name followed by NamespaceSeparator, so the key "job" of
+	// Namespace("worker") is the registry key "worker/job". Keys used
+	// through the view must not contain NamespaceSeparator. The view only
+	// sees, snapshots, hooks and closes its own keys, so subsystems sharing
+	// a registry cannot collide.
+	//
+	// Parameters:
+	//   - name: The namespace name. It panics if name is empty or contains
+	//     NamespaceSeparator; a single trailing separator is ignored.
+	//
+	// Returns:
+	//   - MutexRegistry: The namespaced view.
+	Namespace(name string) MutexRegistry
+
+	// Close shuts the registry down: new registrations and Lock attempts are
+	// rejected with ClosedError, and Close waits for current waiters until
+	// ctx is done, after which they are aborted.
+	//
+	// Parameters:
+	//   - ctx: Bounds how long Close waits for current waiters.
+	//
+	// Returns:
+	//   - error: The context error if waiters had to be aborted; nil otherwise.
+	Close(ctx context.Context) error
 }
 
 // resetRegistry resets the global mutex registry to its initial state.
 // This is useful for testing or reinitialization purposes.
 func resetRegistry() {
-	registry.Store(mutexRegistryHolder{
-		rh: &mutexRegistry{
-			mutexMap: sync.Map{},
-		},
-	})
+	registry.Replace(newMutexRegistry())
 }
 
-// newAtomicRegistry creates and initializes a new atomic registry holder.
-// It ensures thread-safe access to the registry across multiple goroutines.
+// NewMutexRegistry creates an empty MutexRegistry configured by the given
+// options. Install it as the global registry with SetMutexRegistry.
 //
-// Returns:
-//   - *atomic.Value: A pointer to the registry's atomic storage.
-func newAtomicRegistry() *atomic.Value {
-	v := &atomic.Value{}
-	v.Store(mutexRegistryHolder{
-		rh: &mutexRegistry{
-			mutexMap: sync.Map{},
-		},
-	})
-	return v
+// Example:
+//
+//	reg := mutex.NewMutexRegistry(mutex.WithDefaultLockTimeout(30 * time.Second))
+//	mutex.SetMutexRegistry(reg)
+func NewMutexRegistry(opts ...RegistryOption) MutexRegistry {
+	return newMutexRegistry(opts...)
+}
+
+// newMutexRegistry creates an empty mutexRegistry without any hooks.
+func newMutexRegistry(opts ...RegistryOption) *mutexRegistry {
+	mr := &mutexRegistry{clock: clock.System()}
+	for _, opt := range opts {
+		opt(mr)
+	}
+	return mr
 }
 
 // GetMutexRegistry retrieves the current global mutex registry.
@@ -100,7 +195,16 @@ func newAtomicRegistry() *atomic.Value {
 // Returns:
 //   - MutexRegistry: The current MutexRegistry instance.
 func GetMutexRegistry() MutexRegistry {
-	return registry.Load().(mutexRegistryHolder).rh
+	return registry.Load()
+}
+
+// SetMutexRegistry replaces the global mutex registry used by
+// GetMutexRegistry and GetOrNewCancellableMutex.
+//
+// Parameters:
+//   - reg: The MutexRegistry to install.
+func SetMutexRegistry(reg MutexRegistry) {
+	registry.Replace(reg)
 }
 
 // HasMutex checks if a mutex with the given key exists in the registry.
@@ -111,10 +215,9 @@ func GetMutexRegistry() MutexRegistry {
 // Returns:
 //   - bool: True if a mutex with the key is found; false otherwise.
 func (mr *mutexRegistry) HasMutex(key string) bool {
-	if _, ok := mr.mutexMap.Load(key); ok {
-		return true
-	}
-	return false
+	mutex := mr.mutexMap.Load(key)
+	_, ok := mutex.Value()
+	return ok
 }
 
 // GetMutex retrieves the mutex associated with the given key from the
@@ -128,15 +231,18 @@ func (mr *mutexRegistry) HasMutex(key string) bool {
 //   - optional.Option[CancellableMutex]: The mutex wrapped in an optional
 //     if it exists and is complete; otherwise, an empty optional.
 func (mr *mutexRegistry) GetMutex(key string) optional.Option[CancellableMutex] {
-	if mutex, ok := mr.mutexMap.Load(key); ok {
-		cm, ok := mutex.(*cancellableMutex)
-		if ok {
-			option, err := optional.SomeComplete[CancellableMutex](cm)
-			if err == nil {
-				return option
-			}
+	loaded := mr.mutexMap.Load(key)
+	if mutex, ok := loaded.Value(); ok {
+		option, err := optional.SomeComplete(mutex)
+		if err == nil {
+			return option
+		}
+		if mr.mutexMap.CompareAndDelete(key, mutex) {
+			mr.hooksMu.RLock()
+			hooks := mr.evictHooks
+			mr.hooksMu.RUnlock()
+			mr.runLifecycleHooks(hooks, key, mutex)
 		}
-		mr.mutexMap.Delete(key)
 	}
 	return optional.None[CancellableMutex]()
 }
@@ -149,11 +255,154 @@ func (mr *mutexRegistry) GetMutex(key string) optional.Option[CancellableMutex]
 //
 // Returns:
 //   - error: AlreadyRegisteredError if the mutex is already registered;
-//     nil otherwise.
+//     ClosedError if the registry has been closed; nil otherwise.
 func (mr *mutexRegistry) Register(mutex CancellableMutex) error {
-	if mr.HasMutex(mutex.GetKey()) {
+	return mr.register(mutex.GetKey(), mutex)
+}
+
+// register stores mutex under the given registry key after running the
+// register hooks that apply to the key.
+func (mr *mutexRegistry) register(key string, mutex CancellableMutex) error {
+	if mr.isClosed(key) {
+		return ClosedError
+	}
+	if mr.HasMutex(key) {
+		return AlreadyRegisteredError
+	}
+	mr.hooksMu.RLock()
+	hooks := mr.registerHooks
+	mr.hooksMu.RUnlock()
+	for _, h := range hooks {
+		if !strings.HasPrefix(key, h.prefix) {
+			continue
+		}
+		if err := h.hook(mutex); err != nil {
+			return err
+		}
+	}
+	if _, loaded := mr.mutexMap.LoadOrStore(key, mutex); loaded {
 		return AlreadyRegisteredError
 	}
-	mr.mutexMap.Store(mutex.GetKey(), mutex)
+	// A Close that began after the check above may have ranged over the
+	// mutexes before this one was stored; undo the registration so the
+	// closed registry is not left holding a live mutex.
+	if mr.isClosed(key) {
+		if s, ok := mutex.(shutdowner); ok {
+			s.shutdown()
+		}
+		mr.mutexMap.CompareAndDelete(key, mutex)
+		return ClosedError
+	}
 	return nil
 }
+
+// GetOrNew retrieves the mutex with the given key, or creates and registers
+// a new one configured with the registry's mutex options. No mutex is
+// returned unless it is registered, so every caller for a key shares the
+// same mutex.
+//
+// Parameters:
+//   - key: The unique key identifying the mutex.
+//
+// Returns:
+//   - CancellableMutex: The registered mutex for the key, or nil on error.
+//   - error: ClosedError if the registry has been closed, or the error of
+//     an OnRegister hook that rejected the new mutex.
+func (mr *mutexRegistry) GetOrNew(key string) (CancellableMutex, error) {
+	return mr.getOrNew(key, key)
+}
+
+// getOrNew retrieves the mutex stored under the registry key, or creates a
+// mutex with the given mutex key and stores it there. If a concurrent caller
+// wins the registration, its mutex is returned.
+func (mr *mutexRegistry) getOrNew(registryKey, mutexKey string) (CancellableMutex, error) {
+	for {
+		existing := mr.GetMutex(registryKey)
+		if mutex, some := existing.Value(); some {
+			return mutex, nil
+		}
+		mutex := NewCancellableMutex(mutexKey, mr.mutexOptions...)
+		err := mr.register(registryKey, mutex)
+		if err == nil {
+			return mutex, nil
+		}
+		if !errors.Is(err, AlreadyRegisteredError) {
+			return nil, err
+		}
+		// A concurrent caller won the registration; load its mutex, or retry
+		// if it was evicted in the meantime.
+	}
+}
+
+// Unregister removes the mutex with the given key from the registry and
+// runs the OnUnregister hooks with the removed mutex.
+//
+// Parameters:
+//   - key: The unique key identifying the mutex.
+//
+// Returns:
+//   - bool: True if a mutex was removed; false if none was registered.
+func (mr *mutexRegistry) Unregister(key string) bool {
+	removed := mr.mutexMap.LoadAndDelete(key)
+	mutex, ok := removed.Value()
+	if !ok {
+		return false
+	}
+	mr.hooksMu.RLock()
+	hooks := mr.unregisterHooks
+	mr.hooksMu.RUnlock()
+	mr.runLifecycleHooks(hooks, key, mutex)
+	return true
+}
+
+// OnRegister adds a hook that is run before every registration.
+func (mr *mutexRegistry) OnRegister(hook RegisterHook) {
+	mr.addRegisterHook("", hook)
+}
+
+// OnUnregister adds a hook that is run after a mutex is unregistered.
+func (mr *mutexRegistry) OnUnregister(hook LifecycleHook) {
+	mr.addUnregisterHook("", hook)
+}
+
+// OnEvict adds a hook that is run after a mutex is evicted.
+func (mr *mutexRegistry) OnEvict(hook LifecycleHook) {
+	mr.addEvictHook("", hook)
+}
+
+// addRegisterHook adds a register hook scoped to keys starting with prefix.
+func (mr *mutexRegistry) addRegisterHook(prefix string, hook RegisterHook) {
+	mr.hooksMu.Lock()
+	defer mr.hooksMu.Unlock()
+	mr.registerHooks = append(mr.registerHooks, scopedHook[RegisterHook]{prefix: prefix, hook: hook})
+}
+
+// addUnregisterHook adds an unregister hook scoped to keys starting with prefix.
+func (mr *mutexRegistry) addUnregisterHook(prefix string, hook LifecycleHook) {
+	mr.hooksMu.Lock()
+	defer mr.hooksMu.Unlock()
+	mr.unregisterHooks = append(mr.unregisterHooks, scopedHook[LifecycleHook]{prefix: prefix, hook: hook})
+}
+
+// addEvictHook adds an evict hook scoped to keys starting with prefix.
+func (mr *mutexRegistry) addEvictHook(prefix string, hook LifecycleHook) {
+	mr.hooksMu.Lock()
+	defer mr.hooksMu.Unlock()
+	mr.evictHooks = append(mr.evictHooks, scopedHook[LifecycleHook]{prefix: prefix, hook: hook})
+}
+
+// runLifecycleHooks calls, in registration order, each hook whose scope
+// covers key with the given mutex.
+func (mr *mutexRegistry) runLifecycleHooks(hooks []scopedHook[LifecycleHook], key string, mutex CancellableMutex) {
+	for _, h := range hooks {
+		if strings.HasPrefix(key, h.prefix) {
+			h.hook(mutex)
+		}
+	}
+}
+
+// Namespace returns a view of the registry whose keys are stored under
+// name followed by NamespaceSeparator.
+func (mr *mutexRegistry) Namespace(name string) MutexRegistry {
+	return newNamespace(mr, "", name)
+}
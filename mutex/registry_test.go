@@ -5,6 +5,16 @@ import (
 	"testing"
 )
 
+// mustGetOrNew calls reg.GetOrNew and fails the test on error.
+func mustGetOrNew(tb testing.TB, reg MutexRegistry, key string) CancellableMutex {
+	tb.Helper()
+	mutex, err := reg.GetOrNew(key)
+	if err != nil {
+		tb.Fatalf("expected GetOrNew(%q) to succeed, got %v", key, err)
+	}
+	return mutex
+}
+
 func TestGetMutexRegistry(t *testing.T) {
 	// Arrange: Ensure global initialization
 	resetRegistry()
@@ -117,7 +127,8 @@ func TestMutexRegistry_GetMutex_IncompleteMutex(t *testing.T) {
 	}
 
 	// Assert: The incomplete mutex should have been deleted from the registry
-	if _, ok := mr.mutexMap.Load(incompleteKey); ok {
+	remaining := mr.mutexMap.Load(incompleteKey)
+	if _, ok := remaining.Value(); ok {
 		t.Error("expected incomplete mutex to be deleted from registry after GetMutex call")
 	}
 }
@@ -157,3 +168,144 @@ func TestMutexRegistry_RegisterAndRetrieveMultipleKeys(t *testing.T) {
 		}
 	}
 }
+
+func TestMutexRegistry_Unregister(t *testing.T) {
+	// Arrange
+	resetRegistry()
+	reg := GetMutexRegistry()
+	key := "test-unregister"
+	if err := reg.Register(NewCancellableMutex(key)); err != nil {
+		t.Fatalf("unexpected error during registration: %v", err)
+	}
+
+	// Act
+	removed := reg.Unregister(key)
+
+	// Assert
+	if !removed {
+		t.Error("expected Unregister to report removal of a registered mutex")
+	}
+	if reg.HasMutex(key) {
+		t.Errorf("expected registry not to have mutex with key %q after Unregister", key)
+	}
+	if reg.Unregister(key) {
+		t.Error("expected second Unregister to report nothing removed")
+	}
+}
+
+func TestMutexRegistry_OnRegisterHook(t *testing.T) {
+	// Arrange
+	resetRegistry()
+	reg := GetMutexRegistry()
+	var seen []string
+	reg.OnRegister(func(mutex CancellableMutex) error {
+		seen = append(seen, mutex.GetKey())
+		return nil
+	})
+
+	// Act
+	err := reg.Register(NewCancellableMutex("hooked"))
+
+	// Assert
+	if err != nil {
+		t.Fatalf("unexpected error during registration: %v", err)
+	}
+	if len(seen) != 1 || seen[0] != "hooked" {
+		t.Errorf("expected register hook to see [hooked], got %v", seen)
+	}
+}
+
+func TestMutexRegistry_OnRegisterHookRejects(t *testing.T) {
+	// Arrange
+	resetRegistry()
+	reg := GetMutexRegistry()
+	policyErr := errors.New("keys must be prefixed")
+	reg.OnRegister(func(mutex CancellableMutex) error {
+		return policyErr
+	})
+
+	// Act
+	err := reg.Register(NewCancellableMutex("unprefixed"))
+
+	// Assert
+	if !errors.Is(err, policyErr) {
+		t.Errorf("expected policy error from Register, got %v", err)
+	}
+	if reg.HasMutex("unprefixed") {
+		t.Error("expected rejected mutex not to be registered")
+	}
+}
+
+func TestMutexRegistry_OnUnregisterHook(t *testing.T) {
+	// Arrange
+	resetRegistry()
+	reg := GetMutexRegistry()
+	var seen []string
+	reg.OnUnregister(func(mutex CancellableMutex) {
+		seen = append(seen, mutex.GetKey())
+	})
+	_ = reg.Register(NewCancellableMutex("gone"))
+
+	// Act
+	reg.Unregister("gone")
+	reg.Unregister("never-registered")
+
+	// Assert
+	if len(seen) != 1 || seen[0] != "gone" {
+		t.Errorf("expected unregister hook to see [gone], got %v", seen)
+	}
+}
+
+func TestMutexRegistry_OnEvictHook(t *testing.T) {
+	// Arrange
+	resetRegistry()
+	reg := GetMutexRegistry()
+	mr := reg.(*mutexRegistry)
+	evicted := 0
+	reg.OnEvict(func(mutex CancellableMutex) {
+		evicted++
+	})
+	mr.mutexMap.Store("", NewCancellableMutex(""))
+
+	// Act
+	_ = reg.GetMutex("")
+
+	// Assert
+	if evicted != 1 {
+		t.Errorf("expected evict hook to run once, ran %d times", evicted)
+	}
+}
+
+func TestMutexRegistry_GetOrNewReturnsHookRejection(t *testing.T) {
+	// Arrange
+	reg := NewMutexRegistry()
+	rejected := errors.New("key policy violated")
+	reg.OnRegister(func(CancellableMutex) error { return rejected })
+
+	// Act
+	mutex, err := reg.GetOrNew("forbidden")
+
+	// Assert
+	if !errors.Is(err, rejected) {
+		t.Errorf("expected the hook's error, got %v", err)
+	}
+	if mutex != nil {
+		t.Error("expected no unregistered mutex to be returned")
+	}
+}
+
+func TestGetOrNewCancellableMutex_PanicsOnHookRejection(t *testing.T) {
+	// Arrange
+	reg := NewMutexRegistry()
+	reg.OnRegister(func(CancellableMutex) error { return errors.New("key policy violated") })
+	SetMutexRegistry(reg)
+	defer resetRegistry()
+
+	// Act & Assert
+	defer func() {
+		if recover() == nil {
+			t.Error("expected a panic when the registry rejects the key")
+		}
+	}()
+	GetOrNewCancellableMutex("forbidden")
+}
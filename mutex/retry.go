@@ -0,0 +1,61 @@
+package mutex
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/zodimo/go-zbase-std/errcode"
+)
+
+// RetriesExhaustedError is returned, wrapped in a *LockCancelledError, by
+// LockWithRetry when its Strategy runs out of delays before the lock is
+// acquired.
+var RetriesExhaustedError = errcode.New(errcode.Unavailable, "lock retries exhausted")
+
+// Strategy produces the delays between lock acquisition attempts. The
+// backoff package provides common implementations.
+type Strategy interface {
+	// Next returns the delay before the next attempt, or false if no
+	// further attempts should be made.
+	Next() (time.Duration, bool)
+}
+
+// TryLockUntil attempts to acquire the lock, waiting in the queue no later
+// than t as measured by the mutex's clock.
+func (cm *cancellableMutex) TryLockUntil(t time.Time) (bool, error) {
+	err := cm.lock(context.Background(), lockRequest{priority: DefaultPriority, deadline: t})
+	if err == nil {
+		return true, nil
+	}
+	if errors.Is(err, context.DeadlineExceeded) {
+		return false, nil
+	}
+	return false, err
+}
+
+// LockWithRetry acquires the lock by repeatedly attempting it without
+// queueing, pausing for the delays produced by backoff between attempts.
+func (cm *cancellableMutex) LockWithRetry(ctx context.Context, backoff Strategy) error {
+	start := cm.clock.Now()
+	holder := cm.newHolderRecord(ctx)
+	for {
+		s := cm.state.Load()
+		if s&mutexClosed != 0 {
+			return &LockCancelledError{Key: cm.key, Waited: cm.clock.Now().Sub(start), Cause: ClosedError}
+		}
+		if cm.state.CompareAndSwap(0, mutexLocked) {
+			cm.acquired(holder)
+			return nil
+		}
+		delay, ok := backoff.Next()
+		if !ok {
+			return &LockCancelledError{Key: cm.key, Waited: cm.clock.Now().Sub(start), Cause: RetriesExhaustedError}
+		}
+		select {
+		case <-cm.clock.After(delay):
+		case <-ctx.Done():
+			return &LockCancelledError{Key: cm.key, Waited: cm.clock.Now().Sub(start), Cause: ctx.Err()}
+		}
+	}
+}
@@ -0,0 +1,122 @@
+package mutex
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// fixedStrategy yields the same delay a limited number of times.
+type fixedStrategy struct {
+	delay     time.Duration
+	remaining int
+}
+
+func (s *fixedStrategy) Next() (time.Duration, bool) {
+	if s.remaining == 0 {
+		return 0, false
+	}
+	s.remaining--
+	return s.delay, true
+}
+
+func TestCancellableMutex_TryLockUntilAcquires(t *testing.T) {
+	// Arrange
+	mutex := NewCancellableMutex("try-until")
+
+	// Act
+	ok, err := mutex.TryLockUntil(time.Now().Add(time.Second))
+
+	// Assert
+	if !ok || err != nil {
+		t.Fatalf("expected free mutex to be acquired, got %v, %v", ok, err)
+	}
+	mutex.Unlock()
+}
+
+func TestCancellableMutex_TryLockUntilDeadline(t *testing.T) {
+	// Arrange
+	mutex := NewCancellableMutex("try-until")
+	_ = mutex.Lock(context.Background())
+	defer mutex.Unlock()
+
+	// Act
+	ok, err := mutex.TryLockUntil(time.Now().Add(10 * time.Millisecond))
+
+	// Assert
+	if ok || err != nil {
+		t.Errorf("expected (false, nil) once the deadline passed, got %v, %v", ok, err)
+	}
+}
+
+func TestCancellableMutex_TryLockUntilClosed(t *testing.T) {
+	// Arrange
+	reg := NewMutexRegistry()
+	mutex := mustGetOrNew(t, reg, "try-closed")
+	_ = reg.Close(context.Background())
+
+	// Act
+	ok, err := mutex.TryLockUntil(time.Now().Add(time.Second))
+
+	// Assert
+	if ok || !errors.Is(err, ClosedError) {
+		t.Errorf("expected (false, ClosedError), got %v, %v", ok, err)
+	}
+}
+
+func TestCancellableMutex_LockWithRetry(t *testing.T) {
+	// Arrange
+	mutex := NewCancellableMutex("retry")
+	_ = mutex.Lock(context.Background())
+	go func() {
+		time.Sleep(5 * time.Millisecond)
+		mutex.Unlock()
+	}()
+
+	// Act
+	err := mutex.LockWithRetry(context.Background(), &fixedStrategy{delay: time.Millisecond, remaining: 1000})
+
+	// Assert
+	if err != nil {
+		t.Fatalf("expected retry to acquire the lock once released, got %v", err)
+	}
+	mutex.Unlock()
+}
+
+func TestCancellableMutex_LockWithRetryExhausted(t *testing.T) {
+	// Arrange
+	mutex := NewCancellableMutex("retry")
+	_ = mutex.Lock(context.Background())
+	defer mutex.Unlock()
+	strategy := &fixedStrategy{delay: time.Millisecond, remaining: 3}
+
+	// Act
+	err := mutex.LockWithRetry(context.Background(), strategy)
+
+	// Assert
+	if !errors.Is(err, RetriesExhaustedError) {
+		t.Errorf("expected RetriesExhaustedError, got %v", err)
+	}
+	if strategy.remaining != 0 {
+		t.Errorf("expected all attempts to be used, %d left", strategy.remaining)
+	}
+}
+
+func TestCancellableMutex_LockWithRetryCancelled(t *testing.T) {
+	// Arrange
+	mutex := NewCancellableMutex("retry")
+	_ = mutex.Lock(context.Background())
+	defer mutex.Unlock()
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	// Act
+	err := mutex.LockWithRetry(ctx, &fixedStrategy{delay: time.Hour, remaining: 1})
+
+	// Assert
+	var lockErr *LockCancelledError
+	if !errors.As(err, &lockErr) || !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected LockCancelledError wrapping DeadlineExceeded, got %v", err)
+	}
+}
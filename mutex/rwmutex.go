@@ -0,0 +1,315 @@
+package mutex
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/zodimo/go-zbase-std/clock"
+	"github.com/zodimo/go-zbase-std/errcode"
+)
+
+// NotHeldError is returned by Upgrade when the upgradable read lock is not
+// held, or another Upgrade of it is already in progress.
+var NotHeldError = errcode.New(errcode.Conflict, "mutex lock not held")
+
+// CancellableRWMutex defines an interface for a reader/writer mutex whose
+// acquisitions can be cancelled through a context.
+type CancellableRWMutex interface {
+	// Lock acquires the write lock, blocking until no readers or writers
+	// hold the mutex or the context is canceled.
+	Lock(context.Context) error
+
+	// Unlock releases the write lock.
+	Unlock()
+
+	// RLock acquires a read lock, blocking while a writer holds the mutex,
+	// or waits for it as far as the mutex's RWPolicy gives writers
+	// precedence, or until the context is canceled.
+	RLock(context.Context) error
+
+	// RUnlock releases a read lock acquired with RLock.
+	RUnlock()
+
+	// UpgradableRLock acquires a read lock that can later be converted into
+	// the write lock with Upgrade. Only one upgradable reader may hold the
+	// mutex at a time; it coexists with plain readers but excludes writers.
+	UpgradableRLock(context.Context) error
+
+	// UpgradableRUnlock releases an upgradable read lock that was not
+	// upgraded.
+	UpgradableRUnlock()
+
+	// Upgrade converts the caller's upgradable read lock into the write lock
+	// once all plain readers have left, without releasing it in between.
+	// If the context is canceled first, the caller keeps the upgradable read
+	// lock and an error is returned. If the upgradable read lock is not
+	// held, NotHeldError is returned without waiting. After a successful
+	// Upgrade the caller releases the mutex with Unlock.
+	Upgrade(context.Context) error
+
+	// GetKey returns the unique key associated with this mutex.
+	GetKey() string
+}
+
+// RWPolicy decides whether waiting readers or waiting writers of a
+// CancellableRWMutex are admitted first, trading the starvation of one for
+// the other.
+type RWPolicy int
+
+const (
+	// PreferWriters holds back new readers while a writer waits, so writers
+	// never starve but a steady stream of writers can starve readers. It is
+	// the default.
+	PreferWriters RWPolicy = iota
+
+	// PreferReaders admits readers whenever no writer holds the mutex,
+	// maximising read throughput for read-mostly data at the risk of
+	// starving writers.
+	PreferReaders
+
+	// PhaseFair alternates between read and write phases: new readers are
+	// held back while a writer waits, but the readers that waited through a
+	// write phase are admitted before the next writer, so neither side
+	// starves.
+	PhaseFair
+)
+
+// RWMutexOption configures a mutex created by NewCancellableRWMutex.
+type RWMutexOption func(*cancellableRWMutex)
+
+// WithRWPolicy sets the reader/writer preference policy of the mutex.
+func WithRWPolicy(policy RWPolicy) RWMutexOption {
+	return func(rw *cancellableRWMutex) {
+		rw.policy = policy
+	}
+}
+
+// WithRWClock makes the mutex measure wait times with the given clock
+// instead of the system clock.
+func WithRWClock(c Clock) RWMutexOption {
+	return func(rw *cancellableRWMutex) {
+		rw.clock = c
+	}
+}
+
+// cancellableRWMutex is an implementation of the CancellableRWMutex
+// interface. Waiters block on a broadcast channel that is replaced every
+// time the lock state changes, which lets them also select on a context.
+type cancellableRWMutex struct {
+	// key is the unique identifier for this mutex.
+	key string
+
+	// clock is the source of time for wait measurements.
+	clock Clock
+
+	// mu guards all of the fields below.
+	mu sync.Mutex
+
+	// changed is closed and replaced whenever the lock state changes.
+	changed chan struct{}
+
+	// readers is the number of plain read locks held.
+	readers int
+
+	// writer indicates whether the write lock is held.
+	writer bool
+
+	// upgradable indicates whether the upgradable read lock is held.
+	upgradable bool
+
+	// upgrading indicates whether an Upgrade of the upgradable read lock is
+	// waiting for readers to leave.
+	upgrading bool
+
+	// policy decides whether readers or writers are admitted first.
+	policy RWPolicy
+
+	// writersWaiting counts writers and upgrades waiting for the mutex.
+	// Unless the policy prefers readers, new readers are held back while it
+	// is non-zero so writers do not starve.
+	writersWaiting int
+
+	// readersWaiting counts plain readers waiting for the mutex.
+	readersWaiting int
+
+	// readPhase is the number of readers still to be admitted ahead of
+	// waiting writers after a write phase ended under PhaseFair.
+	readPhase int
+}
+
+// NewCancellableRWMutex creates and returns a new CancellableRWMutex with
+// the given key, configured by opts. Without WithRWPolicy the mutex
+// prefers writers.
+func NewCancellableRWMutex(key string, opts ...RWMutexOption) CancellableRWMutex {
+	rw := &cancellableRWMutex{
+		key:     key,
+		clock:   clock.System(),
+		changed: make(chan struct{}),
+	}
+	for _, opt := range opts {
+		opt(rw)
+	}
+	return rw
+}
+
+// GetKey returns the unique key associated with this mutex.
+func (rw *cancellableRWMutex) GetKey() string {
+	return rw.key
+}
+
+// Lock acquires the write lock or returns an error if the context is
+// canceled first.
+func (rw *cancellableRWMutex) Lock(ctx context.Context) error {
+	rw.mu.Lock()
+	rw.writersWaiting++
+	rw.mu.Unlock()
+	return rw.await(ctx,
+		func() bool { return !rw.writer && !rw.upgradable && rw.readers == 0 && rw.readPhase == 0 },
+		func() { rw.writersWaiting--; rw.writer = true },
+		func() { rw.writersWaiting--; rw.notify() },
+	)
+}
+
+// Unlock releases the write lock.
+func (rw *cancellableRWMutex) Unlock() {
+	rw.mu.Lock()
+	defer rw.mu.Unlock()
+	if rw.writer {
+		rw.writer = false
+		if rw.policy == PhaseFair {
+			rw.readPhase = rw.readersWaiting
+		}
+		rw.notify()
+	}
+}
+
+// RLock acquires a read lock or returns an error if the context is
+// canceled first.
+func (rw *cancellableRWMutex) RLock(ctx context.Context) error {
+	rw.mu.Lock()
+	rw.readersWaiting++
+	rw.mu.Unlock()
+	return rw.await(ctx,
+		rw.admitsReaders,
+		func() {
+			rw.readersWaiting--
+			rw.readers++
+			if rw.readPhase > 0 {
+				rw.readPhase--
+			}
+		},
+		func() {
+			rw.readersWaiting--
+			if rw.readPhase > rw.readersWaiting {
+				rw.readPhase = rw.readersWaiting
+				rw.notify()
+			}
+		},
+	)
+}
+
+// RUnlock releases a read lock.
+func (rw *cancellableRWMutex) RUnlock() {
+	rw.mu.Lock()
+	defer rw.mu.Unlock()
+	if rw.readers > 0 {
+		rw.readers--
+		rw.notify()
+	}
+}
+
+// UpgradableRLock acquires the upgradable read lock or returns an error if
+// the context is canceled first.
+func (rw *cancellableRWMutex) UpgradableRLock(ctx context.Context) error {
+	return rw.await(ctx,
+		func() bool { return !rw.upgradable && rw.admitsReaders() },
+		func() { rw.upgradable = true },
+		nil,
+	)
+}
+
+// UpgradableRUnlock releases the upgradable read lock. It has no effect
+// while an Upgrade is in progress, so the lock cannot be handed to another
+// upgradable reader that could then upgrade alongside it.
+func (rw *cancellableRWMutex) UpgradableRUnlock() {
+	rw.mu.Lock()
+	defer rw.mu.Unlock()
+	if rw.upgradable && !rw.upgrading {
+		rw.upgradable = false
+		rw.notify()
+	}
+}
+
+// Upgrade converts the upgradable read lock into the write lock, waiting
+// for plain readers to leave. Unless the mutex prefers readers, new
+// readers are held back while it waits. It returns NotHeldError if the
+// upgradable read lock is not held or is already being upgraded.
+func (rw *cancellableRWMutex) Upgrade(ctx context.Context) error {
+	rw.mu.Lock()
+	if !rw.upgradable || rw.upgrading {
+		rw.mu.Unlock()
+		return fmt.Errorf("%w: %q", NotHeldError, rw.key)
+	}
+	rw.upgrading = true
+	rw.writersWaiting++
+	rw.mu.Unlock()
+	return rw.await(ctx,
+		func() bool { return rw.readers == 0 && rw.readPhase == 0 },
+		func() { rw.writersWaiting--; rw.upgrading = false; rw.upgradable = false; rw.writer = true },
+		func() { rw.writersWaiting--; rw.upgrading = false; rw.notify() },
+	)
+}
+
+// await blocks until ready reports true, then runs acquire. If the context
+// is canceled first, cancel (when non-nil) is run instead and a
+// *LockCancelledError wrapping the context error is returned. ready,
+// acquire and cancel are all called with rw.mu held.
+func (rw *cancellableRWMutex) await(ctx context.Context, ready func() bool, acquire func(), cancel func()) error {
+	start := rw.clock.Now()
+	rw.mu.Lock()
+	defer rw.mu.Unlock()
+	for !ready() {
+		changed := rw.changed
+		rw.mu.Unlock()
+		select {
+		case <-changed:
+			rw.mu.Lock()
+		case <-ctx.Done():
+			rw.mu.Lock()
+			if cancel != nil {
+				cancel()
+			}
+			return &LockCancelledError{
+				Key:    rw.key,
+				Waited: rw.clock.Now().Sub(start),
+				Cause:  ctx.Err(),
+			}
+		}
+	}
+	acquire()
+	return nil
+}
+
+// admitsReaders reports whether a new reader may acquire the mutex under
+// its policy. rw.mu must be held.
+func (rw *cancellableRWMutex) admitsReaders() bool {
+	if rw.writer {
+		return false
+	}
+	switch rw.policy {
+	case PreferReaders:
+		return true
+	case PhaseFair:
+		return rw.writersWaiting == 0 || rw.readPhase > 0
+	default:
+		return rw.writersWaiting == 0
+	}
+}
+
+// notify wakes every waiter so it can re-evaluate the lock state.
+// rw.mu must be held.
+func (rw *cancellableRWMutex) notify() {
+	close(rw.changed)
+	rw.changed = make(chan struct{})
+}
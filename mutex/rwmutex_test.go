@@ -0,0 +1,281 @@
+package mutex
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestCancellableRWMutex_ReadersShare(t *testing.T) {
+	// Arrange
+	rw := NewCancellableRWMutex("test-rw")
+	ctx := context.Background()
+
+	// Act
+	err1 := rw.RLock(ctx)
+	err2 := rw.RLock(ctx)
+
+	// Assert
+	if err1 != nil || err2 != nil {
+		t.Fatalf("expected concurrent read locks, got %v and %v", err1, err2)
+	}
+	rw.RUnlock()
+	rw.RUnlock()
+}
+
+func TestCancellableRWMutex_WriterExcludesReaders(t *testing.T) {
+	// Arrange
+	rw := NewCancellableRWMutex("test-rw")
+	if err := rw.Lock(context.Background()); err != nil {
+		t.Fatalf("failed to acquire write lock: %v", err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	// Act
+	err := rw.RLock(ctx)
+
+	// Assert
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected read lock to time out while write-locked, got %v", err)
+	}
+	rw.Unlock()
+	if err := rw.RLock(context.Background()); err != nil {
+		t.Errorf("expected read lock after Unlock, got %v", err)
+	}
+}
+
+func TestCancellableRWMutex_CancelledWriterReleasesReaders(t *testing.T) {
+	// Arrange
+	rw := NewCancellableRWMutex("test-rw")
+	_ = rw.RLock(context.Background())
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	// Act
+	err := rw.Lock(ctx)
+
+	// Assert
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected write lock to time out while read-locked, got %v", err)
+	}
+	readCtx, readCancel := context.WithTimeout(context.Background(), time.Second)
+	defer readCancel()
+	if err := rw.RLock(readCtx); err != nil {
+		t.Errorf("expected readers to be admitted after the writer gave up, got %v", err)
+	}
+}
+
+func TestCancellableRWMutex_Upgrade(t *testing.T) {
+	// Arrange
+	rw := NewCancellableRWMutex("test-upgrade")
+	ctx := context.Background()
+	if err := rw.UpgradableRLock(ctx); err != nil {
+		t.Fatalf("failed to acquire upgradable read lock: %v", err)
+	}
+	if err := rw.RLock(ctx); err != nil {
+		t.Fatalf("expected plain reader to coexist with upgradable reader, got %v", err)
+	}
+	upgraded := make(chan error)
+
+	// Act
+	go func() { upgraded <- rw.Upgrade(ctx) }()
+
+	// Assert: upgrade waits for the plain reader
+	select {
+	case err := <-upgraded:
+		t.Fatalf("expected Upgrade to wait for readers, returned %v", err)
+	case <-time.After(10 * time.Millisecond):
+	}
+	rw.RUnlock()
+	if err := <-upgraded; err != nil {
+		t.Fatalf("expected Upgrade to succeed, got %v", err)
+	}
+
+	// Assert: the upgraded lock is exclusive
+	readCtx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer cancel()
+	if err := rw.RLock(readCtx); err == nil {
+		t.Error("expected read lock to fail while upgraded to writer")
+	}
+	rw.Unlock()
+}
+
+func TestCancellableRWMutex_SingleUpgradableReader(t *testing.T) {
+	// Arrange
+	rw := NewCancellableRWMutex("test-upgrade")
+	_ = rw.UpgradableRLock(context.Background())
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	// Act
+	err := rw.UpgradableRLock(ctx)
+
+	// Assert
+	if err == nil {
+		t.Error("expected second upgradable reader to be rejected")
+	}
+	rw.UpgradableRUnlock()
+	if err := rw.UpgradableRLock(context.Background()); err != nil {
+		t.Errorf("expected upgradable read lock after release, got %v", err)
+	}
+}
+
+func TestCancellableRWMutex_UpgradeCancelledKeepsReadLock(t *testing.T) {
+	// Arrange
+	rw := NewCancellableRWMutex("test-upgrade")
+	_ = rw.UpgradableRLock(context.Background())
+	_ = rw.RLock(context.Background())
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	// Act
+	err := rw.Upgrade(ctx)
+
+	// Assert
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected upgrade to time out, got %v", err)
+	}
+	writeCtx, writeCancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer writeCancel()
+	rw.RUnlock()
+	if err := rw.Lock(writeCtx); err == nil {
+		t.Error("expected writer to be excluded while the upgradable read lock is still held")
+	}
+}
+
+func TestCancellableRWMutex_UpgradeWithoutUpgradableLock(t *testing.T) {
+	// Arrange
+	rw := NewCancellableRWMutex("test-upgrade")
+	ctx := context.Background()
+	_ = rw.RLock(ctx)
+
+	// Act
+	err := rw.Upgrade(ctx)
+
+	// Assert
+	if !errors.Is(err, NotHeldError) {
+		t.Fatalf("expected NotHeldError, got %v", err)
+	}
+	rw.RUnlock()
+	if err := rw.Lock(ctx); err != nil {
+		t.Errorf("expected the failed Upgrade to leave the mutex free, got %v", err)
+	}
+	rw.Unlock()
+}
+
+func TestCancellableRWMutex_ConcurrentUpgradesYieldOneWriter(t *testing.T) {
+	// Arrange
+	rw := NewCancellableRWMutex("test-upgrade")
+	ctx := context.Background()
+	_ = rw.UpgradableRLock(ctx)
+	_ = rw.RLock(ctx)
+	first := make(chan error, 1)
+	go func() { first <- rw.Upgrade(ctx) }()
+	waitForRWWaiters(t, rw, 0, 1)
+
+	// Act
+	second := rw.Upgrade(ctx)
+	rw.UpgradableRUnlock()
+	rw.RUnlock()
+
+	// Assert
+	if !errors.Is(second, NotHeldError) {
+		t.Errorf("expected NotHeldError for the second Upgrade, got %v", second)
+	}
+	if err := <-first; err != nil {
+		t.Fatalf("expected the first Upgrade to succeed, got %v", err)
+	}
+	readCtx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer cancel()
+	if err := rw.UpgradableRLock(readCtx); err == nil {
+		t.Error("expected the upgraded writer to exclude another upgradable reader")
+	}
+	rw.Unlock()
+}
+
+// waitForRWWaiters blocks until the mutex has the given numbers of waiting
+// readers and writers.
+func waitForRWWaiters(t *testing.T, mutex CancellableRWMutex, readers, writers int) {
+	t.Helper()
+	rw := mutex.(*cancellableRWMutex)
+	for deadline := time.Now().Add(time.Second); time.Now().Before(deadline); {
+		rw.mu.Lock()
+		r, w := rw.readersWaiting, rw.writersWaiting
+		rw.mu.Unlock()
+		if r == readers && w == writers {
+			return
+		}
+		time.Sleep(time.Millisecond)
+	}
+	t.Fatalf("timed out waiting for %d readers and %d writers", readers, writers)
+}
+
+func TestCancellableRWMutex_PolicyAdmitsReaderBehindWaitingWriter(t *testing.T) {
+	tests := []struct {
+		name     string
+		policy   RWPolicy
+		admitted bool
+	}{
+		{"PreferWriters", PreferWriters, false},
+		{"PreferReaders", PreferReaders, true},
+		{"PhaseFair", PhaseFair, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			rw := NewCancellableRWMutex("test-policy", WithRWPolicy(tt.policy))
+			_ = rw.RLock(context.Background())
+			writerCtx, cancelWriter := context.WithCancel(context.Background())
+			defer cancelWriter()
+			go func() { _ = rw.Lock(writerCtx) }()
+			waitForRWWaiters(t, rw, 0, 1)
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+			defer cancel()
+
+			// Act
+			err := rw.RLock(ctx)
+
+			// Assert
+			if admitted := err == nil; admitted != tt.admitted {
+				t.Errorf("expected reader admitted %v behind a waiting writer, got %v", tt.admitted, err)
+			}
+		})
+	}
+}
+
+func TestCancellableRWMutex_PhaseFairAlternates(t *testing.T) {
+	// Arrange
+	rw := NewCancellableRWMutex("test-phase-fair", WithRWPolicy(PhaseFair))
+	ctx := context.Background()
+	_ = rw.Lock(ctx)
+	readerDone := make(chan error)
+	go func() { readerDone <- rw.RLock(ctx) }()
+	waitForRWWaiters(t, rw, 1, 0)
+	writerDone := make(chan error)
+	go func() { writerDone <- rw.Lock(ctx) }()
+	waitForRWWaiters(t, rw, 1, 1)
+
+	// Act: ending the write phase admits the reader that waited through it
+	rw.Unlock()
+
+	// Assert
+	if err := <-readerDone; err != nil {
+		t.Fatalf("expected waiting reader to be admitted first, got %v", err)
+	}
+	lateCtx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer cancel()
+	if err := rw.RLock(lateCtx); err == nil {
+		t.Error("expected a late reader to wait for the pending writer")
+	}
+
+	// Act: ending the read phase admits the writer
+	rw.RUnlock()
+
+	// Assert
+	if err := <-writerDone; err != nil {
+		t.Fatalf("expected waiting writer to be admitted next, got %v", err)
+	}
+	rw.Unlock()
+}
@@ -0,0 +1,120 @@
+package mutex
+
+import (
+	"encoding/json"
+	"net/http"
+	"sort"
+	"time"
+)
+
+// RegistrySnapshot is a point-in-time, JSON-serializable view of every
+// mutex held by a MutexRegistry. It is intended for diagnostics, such as
+// finding out which keys are locked when a service appears to be stuck.
+type RegistrySnapshot struct {
+	// TakenAt is the time at which the snapshot was taken.
+	TakenAt time.Time `json:"taken_at"`
+
+	// Mutexes describes each registered mutex, ordered by key.
+	Mutexes []MutexSnapshot `json:"mutexes"`
+}
+
+// MutexSnapshot describes the state of a single mutex at the time a
+// RegistrySnapshot was taken.
+type MutexSnapshot struct {
+	// Key is the key under which the mutex is registered.
+	Key string `json:"key"`
+
+	// Locked reports whether the mutex was held.
+	Locked bool `json:"locked"`
+
+	// Waiters is the number of Lock calls blocked on the mutex.
+	Waiters int `json:"waiters"`
+
+	// Age is the time elapsed since the mutex was created.
+	Age time.Duration `json:"age"`
+
+	// HeldFor is the time elapsed since the lock was acquired. It is zero
+	// when the mutex is not locked.
+	HeldFor time.Duration `json:"held_for,omitempty"`
+
+	// Holder describes the holder of the lock, or is nil when unlocked.
+	Holder *HolderInfo `json:"holder,omitempty"`
+}
+
+// snapshotter is implemented by mutexes able to describe their own state
+// beyond what the CancellableMutex interface exposes.
+type snapshotter interface {
+	snapshot() MutexSnapshot
+}
+
+// snapshot describes the current state of the mutex, measuring ages with
+// the mutex's clock.
+func (cm *cancellableMutex) snapshot() MutexSnapshot {
+	now := cm.clock.Now()
+	s := MutexSnapshot{
+		Key:     cm.key,
+		Locked:  cm.IsLocked(),
+		Waiters: cm.waiterCount(),
+		Age:     now.Sub(cm.createdAt),
+	}
+	holder := cm.Holder()
+	if info, ok := holder.Value(); ok {
+		s.HeldFor = now.Sub(info.AcquiredAt)
+		s.Holder = &info
+	}
+	return s
+}
+
+// waiterCount returns the number of Lock calls blocked on the mutex.
+func (cm *cancellableMutex) waiterCount() int {
+	cm.mu.Lock()
+	defer cm.mu.Unlock()
+	return cm.queue.Len()
+}
+
+// Snapshot returns a point-in-time view of all registered mutexes.
+//
+// Returns:
+//   - RegistrySnapshot: The state of every registered mutex, ordered by key.
+func (mr *mutexRegistry) Snapshot() RegistrySnapshot {
+	snap := RegistrySnapshot{
+		TakenAt: mr.clock.Now(),
+		Mutexes: []MutexSnapshot{},
+	}
+	mr.mutexMap.Range(func(key string, value CancellableMutex) bool {
+		var s MutexSnapshot
+		if m, ok := value.(snapshotter); ok {
+			s = m.snapshot()
+		} else {
+			s = MutexSnapshot{Locked: value.IsLocked()}
+		}
+		s.Key = key
+		snap.Mutexes = append(snap.Mutexes, s)
+		return true
+	})
+	sort.Slice(snap.Mutexes, func(i, j int) bool {
+		return snap.Mutexes[i].Key < snap.Mutexes[j].Key
+	})
+	return snap
+}
+
+// SnapshotHandler returns an http.Handler that responds with the JSON
+// encoding of the given registry's Snapshot.
+//
+// Parameters:
+//   - reg: The MutexRegistry to describe.
+//
+// Returns:
+//   - http.Handler: A handler serving the registry snapshot as JSON.
+//
+// Example:
+//
+//	http.Handle("/debug/mutexes", mutex.SnapshotHandler(mutex.GetMutexRegistry()))
+func SnapshotHandler(reg MutexRegistry) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(reg.Snapshot()); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+	})
+}
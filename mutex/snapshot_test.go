@@ -0,0 +1,121 @@
+package mutex
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/zodimo/go-zbase-std/clock"
+)
+
+func TestMutexRegistry_Snapshot(t *testing.T) {
+	// Arrange
+	resetRegistry()
+	reg := GetMutexRegistry()
+	locked := NewCancellableMutex("b-locked")
+	_ = reg.Register(locked)
+	_ = reg.Register(NewCancellableMutex("a-free"))
+	if err := locked.Lock(context.Background()); err != nil {
+		t.Fatalf("failed to lock mutex: %v", err)
+	}
+	defer locked.Unlock()
+
+	// Act
+	snap := reg.Snapshot()
+
+	// Assert
+	if len(snap.Mutexes) != 2 {
+		t.Fatalf("expected 2 mutexes in snapshot, got %d", len(snap.Mutexes))
+	}
+	if snap.Mutexes[0].Key != "a-free" || snap.Mutexes[1].Key != "b-locked" {
+		t.Errorf("expected snapshot ordered by key, got %q, %q", snap.Mutexes[0].Key, snap.Mutexes[1].Key)
+	}
+	if snap.Mutexes[0].Locked {
+		t.Error("expected a-free to be reported unlocked")
+	}
+	if !snap.Mutexes[1].Locked {
+		t.Error("expected b-locked to be reported locked")
+	}
+}
+
+func TestMutexRegistry_SnapshotUsesRegistryClock(t *testing.T) {
+	// Arrange
+	c := clock.NewFakeClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	reg := NewMutexRegistry(WithRegistryClock(c))
+	mutex := mustGetOrNew(t, reg, "held")
+	_ = mutex.Lock(context.Background())
+	defer mutex.Unlock()
+	c.Advance(time.Minute)
+
+	// Act
+	snap := reg.Snapshot()
+
+	// Assert
+	if !snap.TakenAt.Equal(c.Now()) {
+		t.Errorf("expected TakenAt %v, got %v", c.Now(), snap.TakenAt)
+	}
+	if len(snap.Mutexes) != 1 || snap.Mutexes[0].HeldFor != time.Minute {
+		t.Errorf("expected held for 1m on the registry clock, got %+v", snap.Mutexes)
+	}
+}
+
+func TestMutexRegistry_SnapshotWaiters(t *testing.T) {
+	// Arrange
+	resetRegistry()
+	reg := GetMutexRegistry()
+	mutex := NewCancellableMutex("contended")
+	_ = reg.Register(mutex)
+	_ = mutex.Lock(context.Background())
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		_ = mutex.Lock(ctx)
+	}()
+
+	// Act
+	var waiters int
+	for deadline := time.Now().Add(time.Second); time.Now().Before(deadline); {
+		if waiters = reg.Snapshot().Mutexes[0].Waiters; waiters == 1 {
+			break
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	// Assert
+	if waiters != 1 {
+		t.Errorf("expected 1 waiter in snapshot, got %d", waiters)
+	}
+
+	// Cleanup
+	cancel()
+	<-done
+	mutex.Unlock()
+}
+
+func TestSnapshotHandler(t *testing.T) {
+	// Arrange
+	resetRegistry()
+	reg := GetMutexRegistry()
+	_ = reg.Register(NewCancellableMutex("served"))
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/debug/mutexes", nil)
+
+	// Act
+	SnapshotHandler(reg).ServeHTTP(rec, req)
+
+	// Assert
+	if ct := rec.Header().Get("Content-Type"); ct != "application/json" {
+		t.Errorf("expected JSON content type, got %q", ct)
+	}
+	var snap RegistrySnapshot
+	if err := json.Unmarshal(rec.Body.Bytes(), &snap); err != nil {
+		t.Fatalf("expected valid JSON body, got error %v", err)
+	}
+	if len(snap.Mutexes) != 1 || snap.Mutexes[0].Key != "served" {
+		t.Errorf("expected snapshot with key served, got %+v", snap.Mutexes)
+	}
+}
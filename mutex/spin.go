@@ -0,0 +1,45 @@
+package mutex
+
+import (
+	"context"
+	"runtime"
+	"time"
+)
+
+// WithSpin makes Lock retry a contended mutex up to iterations times,
+// yielding the processor between attempts, before parking in the wait
+// queue. If d is positive, spinning also stops once d has elapsed. Spinning
+// pays off for very short critical sections, where the holder is likely to
+// release the mutex sooner than a park and wake-up would take; for longer
+// ones it only burns CPU. Spinning stops as soon as other callers are
+// queued, so it never overtakes waiters. Zero iterations disable spinning.
+func WithSpin(iterations int, d time.Duration) MutexOption {
+	return func(cm *cancellableMutex) {
+		cm.spinIterations = iterations
+		cm.spinFor = d
+	}
+}
+
+// spin retries the fast path while the mutex is held without waiters. It
+// reports whether the lock was acquired.
+func (cm *cancellableMutex) spin(ctx context.Context, holder holderRecord) bool {
+	var deadline time.Time
+	if cm.spinFor > 0 {
+		deadline = cm.clock.Now().Add(cm.spinFor)
+	}
+	for i := 0; i < cm.spinIterations; i++ {
+		runtime.Gosched()
+		s := cm.state.Load()
+		if s&(mutexWaiters|mutexClosed) != 0 || ctx.Err() != nil {
+			return false // Leave it to lockSlow to queue or fail
+		}
+		if s == 0 && cm.state.CompareAndSwap(0, mutexLocked) {
+			cm.acquired(holder)
+			return true
+		}
+		if !deadline.IsZero() && !cm.clock.Now().Before(deadline) {
+			return false
+		}
+	}
+	return false
+}
@@ -0,0 +1,101 @@
+package mutex
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestCancellableMutex_SpinAcquiresWithoutQueueing(t *testing.T) {
+	// Arrange
+	mutex := NewCancellableMutex("test-spin", WithSpin(1_000_000, time.Second))
+	_ = mutex.Lock(context.Background())
+	go func() {
+		time.Sleep(time.Millisecond)
+		mutex.Unlock()
+	}()
+
+	// Act
+	err := mutex.Lock(context.Background())
+
+	// Assert
+	if err != nil {
+		t.Fatalf("expected spinning Lock to succeed, got %v", err)
+	}
+	if waits := mutex.FairnessStats().Waits; waits != 0 {
+		t.Errorf("expected the lock to be acquired while spinning, got %d queued waits", waits)
+	}
+	mutex.Unlock()
+}
+
+func TestCancellableMutex_SpinThenPark(t *testing.T) {
+	// Arrange
+	mutex := NewCancellableMutex("test-spin-park", WithSpin(10, 0))
+	_ = mutex.Lock(context.Background())
+	defer mutex.Unlock()
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	// Act
+	err := mutex.Lock(ctx)
+
+	// Assert
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected context.DeadlineExceeded, got %v", err)
+	}
+	if waits := mutex.FairnessStats().Waits; waits != 1 {
+		t.Errorf("expected the spinner to park once spinning was exhausted, got %d waits", waits)
+	}
+}
+
+func TestCancellableMutex_SpinBoundedByDuration(t *testing.T) {
+	// Arrange
+	mutex := NewCancellableMutex("test-spin-duration", WithSpin(1<<30, 5*time.Millisecond))
+	_ = mutex.Lock(context.Background())
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// Act
+	go func() { _ = mutex.Lock(ctx) }()
+
+	// Assert: the spinner parks long before it runs out of iterations
+	waitForWaiters(t, mutex, 1)
+	mutex.Unlock()
+}
+
+func TestCancellableMutex_SpinDoesNotOvertakeWaiters(t *testing.T) {
+	// Arrange
+	mutex := NewCancellableMutex("test-spin-order", WithSpin(1_000_000, time.Second))
+	_ = mutex.Lock(context.Background())
+	order := make(chan string, 2)
+	lockAs := func(name string) {
+		_ = mutex.Lock(context.Background())
+		order <- name
+		mutex.Unlock()
+	}
+	go lockAs("queued")
+	waitForWaiters(t, mutex, 1)
+	go lockAs("spinner")
+	waitForWaiters(t, mutex, 2)
+
+	// Act
+	mutex.Unlock()
+
+	// Assert
+	if first := <-order; first != "queued" {
+		t.Errorf("expected the queued waiter first, got %s", first)
+	}
+	<-order
+}
+
+func BenchmarkCancellableMutex_ContendedSpin(b *testing.B) {
+	mutex := NewCancellableMutex("bench", WithSpin(4, 0))
+	ctx := context.Background()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			_ = mutex.Lock(ctx)
+			mutex.Unlock()
+		}
+	})
+}
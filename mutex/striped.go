@@ -0,0 +1,68 @@
+package mutex
+
+import (
+	"context"
+	"hash/maphash"
+	"strconv"
+)
+
+// UnlockFunc releases a lock acquired through a helper such as
+// Striped.LockFor. It must be called exactly once.
+type UnlockFunc func()
+
+// Striped is a fixed pool of cancellable mutexes. Keys are hashed to one of
+// the stripes, bounding memory for unbounded key spaces where registering a
+// mutex per key is overkill. Distinct keys may share a stripe and therefore
+// contend with each other.
+type Striped struct {
+	seed    maphash.Seed
+	stripes []CancellableMutex
+}
+
+// NewStriped creates a Striped lock with n stripes. It panics if n is not
+// positive.
+//
+// Example:
+//
+//	locks := mutex.NewStriped(64)
+//	unlock, err := locks.LockFor(ctx, userID)
+//	if err != nil {
+//		return err
+//	}
+//	defer unlock()
+func NewStriped(n int) *Striped {
+	if n <= 0 {
+		panic("mutex: NewStriped requires a positive number of stripes")
+	}
+	stripes := make([]CancellableMutex, n)
+	for i := range stripes {
+		stripes[i] = NewCancellableMutex("stripe-" + strconv.Itoa(i))
+	}
+	return &Striped{
+		seed:    maphash.MakeSeed(),
+		stripes: stripes,
+	}
+}
+
+// Len returns the number of stripes.
+func (s *Striped) Len() int {
+	return len(s.stripes)
+}
+
+// For returns the stripe that key hashes to. The key must be comparable;
+// passing a non-comparable value such as a slice panics.
+func (s *Striped) For(key any) CancellableMutex {
+	h := maphash.Comparable(s.seed, key)
+	return s.stripes[h%uint64(len(s.stripes))]
+}
+
+// LockFor locks the stripe that key hashes to and returns a function that
+// unlocks it. If the context is canceled before the stripe is acquired, the
+// error from Lock is returned and the returned UnlockFunc is nil.
+func (s *Striped) LockFor(ctx context.Context, key any) (UnlockFunc, error) {
+	m := s.For(key)
+	if err := m.Lock(ctx); err != nil {
+		return nil, err
+	}
+	return m.Unlock, nil
+}
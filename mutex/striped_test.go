@@ -0,0 +1,60 @@
+package mutex
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestNewStriped_InvalidSize(t *testing.T) {
+	// Arrange
+	defer func() {
+		if recover() == nil {
+			t.Error("expected NewStriped(0) to panic")
+		}
+	}()
+
+	// Act
+	NewStriped(0)
+}
+
+func TestStriped_SameKeySameStripe(t *testing.T) {
+	// Arrange
+	s := NewStriped(16)
+
+	// Act
+	a := s.For("tenant-1")
+	b := s.For("tenant-1")
+
+	// Assert
+	if a != b {
+		t.Error("expected the same key to map to the same stripe")
+	}
+	if s.Len() != 16 {
+		t.Errorf("expected 16 stripes, got %d", s.Len())
+	}
+}
+
+func TestStriped_LockFor(t *testing.T) {
+	// Arrange
+	s := NewStriped(4)
+	ctx := context.Background()
+
+	// Act
+	unlock, err := s.LockFor(ctx, 42)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("expected no error locking stripe, got %v", err)
+	}
+	timeoutCtx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer cancel()
+	if _, err := s.LockFor(timeoutCtx, 42); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected second LockFor on the same key to time out, got %v", err)
+	}
+	unlock()
+	if s.For(42).IsLocked() {
+		t.Error("expected stripe to be unlocked after calling UnlockFunc")
+	}
+}
@@ -0,0 +1,86 @@
+package mutex
+
+import (
+	"container/heap"
+)
+
+// waiter represents a single Lock call parked on a cancellableMutex.
+type waiter struct {
+	// priority orders waiters; higher priorities are granted the lock first.
+	priority int
+
+	// seq is the arrival order, used to keep equal priorities FIFO.
+	seq uint64
+
+	// holder is recorded as the lock's holder once the lock is granted.
+	holder holderRecord
+
+	// ready is closed when the lock has been handed to this waiter, or when
+	// the wait has been aborted.
+	ready chan struct{}
+
+	// aborted is set before ready is closed if the waiter was rejected
+	// because the mutex was shut down rather than handed the lock.
+	aborted bool
+
+	// index is the position of the waiter in its waitQueue.
+	index int
+}
+
+// waitQueue is a heap of waiters ordered by descending priority and then
+// by ascending arrival order. It implements heap.Interface and is not safe
+// for concurrent use.
+type waitQueue []*waiter
+
+// Len implements heap.Interface.
+func (q waitQueue) Len() int { return len(q) }
+
+// Less implements heap.Interface.
+func (q waitQueue) Less(i, j int) bool {
+	if q[i].priority != q[j].priority {
+		return q[i].priority > q[j].priority
+	}
+	return q[i].seq < q[j].seq
+}
+
+// Swap implements heap.Interface.
+func (q waitQueue) Swap(i, j int) {
+	q[i], q[j] = q[j], q[i]
+	q[i].index = i
+	q[j].index = j
+}
+
+// Push implements heap.Interface.
+func (q *waitQueue) Push(x any) {
+	w := x.(*waiter)
+	w.index = len(*q)
+	*q = append(*q, w)
+}
+
+// Pop implements heap.Interface.
+func (q *waitQueue) Pop() any {
+	old := *q
+	n := len(old)
+	w := old[n-1]
+	old[n-1] = nil
+	w.index = -1
+	*q = old[:n-1]
+	return w
+}
+
+// enqueue adds w to the queue.
+func (q *waitQueue) enqueue(w *waiter) {
+	heap.Push(q, w)
+}
+
+// dequeue removes and returns the waiter that should be granted the lock next.
+func (q *waitQueue) dequeue() *waiter {
+	return heap.Pop(q).(*waiter)
+}
+
+// remove takes w out of the queue if it is still queued.
+func (q *waitQueue) remove(w *waiter) {
+	if w.index >= 0 {
+		heap.Remove(q, w.index)
+	}
+}
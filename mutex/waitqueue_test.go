@@ -0,0 +1,46 @@
+package mutex
+
+import (
+	"testing"
+)
+
+func TestWaitQueue_PriorityThenArrival(t *testing.T) {
+	// Arrange
+	var q waitQueue
+	q.enqueue(&waiter{priority: 0, seq: 0, holder: holderRecord{label: "low-first"}})
+	q.enqueue(&waiter{priority: 5, seq: 1, holder: holderRecord{label: "high-first"}})
+	q.enqueue(&waiter{priority: 0, seq: 2, holder: holderRecord{label: "low-second"}})
+	q.enqueue(&waiter{priority: 5, seq: 3, holder: holderRecord{label: "high-second"}})
+
+	// Act
+	var got []string
+	for q.Len() > 0 {
+		got = append(got, q.dequeue().holder.label)
+	}
+
+	// Assert
+	want := []string{"high-first", "high-second", "low-first", "low-second"}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("expected dequeue order %v, got %v", want, got)
+		}
+	}
+}
+
+func TestWaitQueue_Remove(t *testing.T) {
+	// Arrange
+	var q waitQueue
+	a := &waiter{seq: 0, holder: holderRecord{label: "a"}}
+	b := &waiter{seq: 1, holder: holderRecord{label: "b"}}
+	q.enqueue(a)
+	q.enqueue(b)
+
+	// Act
+	q.remove(a)
+	q.remove(a) // removing twice is a no-op
+
+	// Assert
+	if q.Len() != 1 || q.dequeue() != b {
+		t.Error("expected only b to remain after removing a")
+	}
+}
@@ -0,0 +1,249 @@
+package mutex
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/zodimo/go-zbase-std/clock"
+	"github.com/zodimo/go-zbase-std/optional"
+)
+
+// tryLocker is implemented by lockers, such as sync.Mutex and sync.RWMutex,
+// that support non-blocking acquisition.
+type tryLocker interface {
+	TryLock() bool
+}
+
+// Polling bounds used when acquiring a wrapped locker through TryLock.
+const (
+	wrapMinPoll = time.Microsecond
+	wrapMaxPoll = time.Millisecond
+)
+
+// wrappedLocker adapts a sync.Locker to CancellableMutex with best-effort
+// cancellation.
+type wrappedLocker struct {
+	key    string
+	locker sync.Locker
+	clock  Clock
+	held   atomic.Pointer[HolderInfo] // Set while acquired through the adapter.
+
+	// onReleased is the OnReleased hook of the current hold, or nil.
+	onReleased atomic.Pointer[func()]
+}
+
+// WrapOption configures a mutex created by WrapLocker.
+type WrapOption func(*wrappedLocker)
+
+// WithWrapClock makes the adapter read time, for holder timestamps and
+// polling delays, from the given clock instead of the system clock.
+func WithWrapClock(c Clock) WrapOption {
+	return func(w *wrappedLocker) {
+		w.clock = c
+	}
+}
+
+// WrapLocker adapts a standard sync.Locker to CancellableMutex so code that
+// holds standard mutexes can migrate to the context-aware API one call site
+// at a time. Cancellation is best effort: lockers with a TryLock method,
+// such as sync.Mutex, are polled until the context is done; other lockers
+// are acquired by a helper goroutine that releases the lock again if the
+// caller has given up by the time it is acquired.
+//
+// The returned mutex does not support priorities or preemption, and only
+// reports IsLocked and Holder for acquisitions made through it. Unlock is a
+// no-op unless the adapter holds the locker.
+//
+// Parameters:
+//   - key: The unique identifier of the mutex, used by WithLock, LockGroup
+//     and registries to tell mutexes apart. It panics if key is empty.
+//   - l: The locker to adapt.
+//   - opts: Options configuring the adapter.
+//
+// Returns:
+//   - CancellableMutex: The adapter.
+//
+// Example:
+//
+//	var legacy sync.Mutex
+//	m := mutex.WrapLocker("legacy-config", &legacy)
+//	err := mutex.WithLock(ctx, m, reload)
+func WrapLocker(key string, l sync.Locker, opts ...WrapOption) CancellableMutex {
+	if key == "" {
+		panic("mutex: WrapLocker requires a non-empty key")
+	}
+	w := &wrappedLocker{key: key, locker: l, clock: clock.System()}
+	for _, opt := range opts {
+		opt(w)
+	}
+	return w
+}
+
+// Lock acquires the wrapped locker or returns a *LockCancelledError if the
+// context is done first.
+func (w *wrappedLocker) Lock(ctx context.Context) error {
+	start := w.clock.Now()
+	var err error
+	if tl, ok := w.locker.(tryLocker); ok {
+		err = w.poll(ctx, tl)
+	} else {
+		err = w.handOff(ctx)
+	}
+	if err != nil {
+		return &LockCancelledError{Key: w.key, Waited: w.clock.Now().Sub(start), Cause: err}
+	}
+	w.acquired(ctx)
+	return nil
+}
+
+// acquired records the holder of an acquisition made through the adapter.
+func (w *wrappedLocker) acquired(ctx context.Context) {
+	w.held.Store(&HolderInfo{Label: holderLabel(ctx), AcquiredAt: w.clock.Now()})
+}
+
+// poll acquires tl with TryLock, backing off exponentially between attempts.
+func (w *wrappedLocker) poll(ctx context.Context, tl tryLocker) error {
+	delay := wrapMinPoll
+	for !tl.TryLock() {
+		timer := w.clock.NewTimer(delay)
+		select {
+		case <-timer.C():
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		}
+		delay = min(2*delay, wrapMaxPoll)
+	}
+	return nil
+}
+
+// handOff acquires the locker in a helper goroutine, releasing it again if
+// the context is done before it is acquired.
+func (w *wrappedLocker) handOff(ctx context.Context) error {
+	acquired := make(chan struct{})
+	go func() {
+		w.locker.Lock()
+		close(acquired)
+	}()
+	select {
+	case <-acquired:
+		return nil
+	case <-ctx.Done():
+		go func() {
+			<-acquired
+			w.locker.Unlock()
+		}()
+		return ctx.Err()
+	}
+}
+
+// LockWithPriority acquires the wrapped locker; priorities are ignored.
+func (w *wrappedLocker) LockWithPriority(ctx context.Context, _ int) error {
+	return w.Lock(ctx)
+}
+
+// LockPreempting acquires the wrapped locker; preemption is not supported.
+func (w *wrappedLocker) LockPreempting(ctx context.Context) error {
+	return w.Lock(ctx)
+}
+
+// TryLockUntil attempts to acquire the wrapped locker until t, as measured
+// by the adapter's clock.
+func (w *wrappedLocker) TryLockUntil(t time.Time) (bool, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), t.Sub(w.clock.Now()))
+	defer cancel()
+	err := w.Lock(ctx)
+	if errors.Is(err, context.DeadlineExceeded) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+// LockWithRetry acquires the wrapped locker, pausing for the delays produced
+// by backoff between attempts. Lockers without TryLock are acquired with
+// Lock instead.
+func (w *wrappedLocker) LockWithRetry(ctx context.Context, backoff Strategy) error {
+	tl, ok := w.locker.(tryLocker)
+	if !ok {
+		return w.Lock(ctx)
+	}
+	start := w.clock.Now()
+	for !tl.TryLock() {
+		delay, ok := backoff.Next()
+		if !ok {
+			return &LockCancelledError{Key: w.key, Waited: w.clock.Now().Sub(start), Cause: RetriesExhaustedError}
+		}
+		timer := w.clock.NewTimer(delay)
+		select {
+		case <-timer.C():
+		case <-ctx.Done():
+			timer.Stop()
+			return &LockCancelledError{Key: w.key, Waited: w.clock.Now().Sub(start), Cause: ctx.Err()}
+		}
+	}
+	w.acquired(ctx)
+	return nil
+}
+
+// LockWithHooks acquires the wrapped locker like Lock, running the hooks
+// of the hold as described by CancellableMutex.
+func (w *wrappedLocker) LockWithHooks(ctx context.Context, hooks Hooks) error {
+	if err := w.Lock(ctx); err != nil {
+		return hooks.cancelled(err)
+	}
+	if released := hooks.OnReleased; released != nil {
+		w.onReleased.Store(&released)
+	}
+	hooks.acquired()
+	return nil
+}
+
+// Preempted returns nil; wrapped lockers cannot be preempted.
+func (w *wrappedLocker) Preempted() <-chan struct{} {
+	return nil
+}
+
+// Unlock releases the wrapped locker. It does nothing unless the locker was
+// acquired through the adapter, so a stray Unlock cannot release, or panic
+// on, a locker held by legacy code.
+func (w *wrappedLocker) Unlock() {
+	if w.held.Swap(nil) == nil {
+		return
+	}
+	released := w.onReleased.Swap(nil)
+	w.locker.Unlock()
+	if released != nil {
+		(*released)()
+	}
+}
+
+// Locker returns a sync.Locker that acquires the adapter with ctx.
+func (w *wrappedLocker) Locker(ctx context.Context) sync.Locker {
+	return NewLocker(ctx, w)
+}
+
+// GetKey returns the key the locker was wrapped with.
+func (w *wrappedLocker) GetKey() string {
+	return w.key
+}
+
+// IsLocked reports whether the locker was acquired through the adapter.
+func (w *wrappedLocker) IsLocked() bool {
+	return w.held.Load() != nil
+}
+
+// Holder returns the holder of an acquisition made through the adapter.
+func (w *wrappedLocker) Holder() optional.Option[HolderInfo] {
+	if held := w.held.Load(); held != nil {
+		return optional.Some(*held)
+	}
+	return optional.None[HolderInfo]()
+}
+
+// FairnessStats returns zero statistics; waits are not tracked.
+func (w *wrappedLocker) FairnessStats() FairnessStats {
+	return FairnessStats{}
+}
@@ -0,0 +1,214 @@
+package mutex
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/zodimo/go-zbase-std/clock"
+)
+
+// plainLocker is a sync.Locker without TryLock, forcing the helper
+// goroutine path.
+type plainLocker struct {
+	mu sync.Mutex
+}
+
+func (p *plainLocker) Lock()   { p.mu.Lock() }
+func (p *plainLocker) Unlock() { p.mu.Unlock() }
+
+func TestWrapLocker_TryLockPath(t *testing.T) {
+	// Arrange
+	var legacy sync.Mutex
+	mutex := WrapLocker("legacy", &legacy)
+
+	// Act
+	err := mutex.Lock(context.Background())
+
+	// Assert
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if legacy.TryLock() {
+		t.Error("expected the wrapped sync.Mutex to be held")
+	}
+	if !mutex.IsLocked() {
+		t.Error("expected adapter to report locked")
+	}
+	mutex.Unlock()
+	if !legacy.TryLock() {
+		t.Error("expected the wrapped sync.Mutex to be released")
+	}
+}
+
+func TestWrapLocker_TryLockPathCancelled(t *testing.T) {
+	// Arrange
+	var legacy sync.Mutex
+	legacy.Lock()
+	defer legacy.Unlock()
+	mutex := WrapLocker("legacy", &legacy)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	// Act
+	err := mutex.Lock(ctx)
+
+	// Assert
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected DeadlineExceeded while legacy code holds the mutex, got %v", err)
+	}
+}
+
+func TestWrapLocker_HelperPathReleasesAbandonedLock(t *testing.T) {
+	// Arrange
+	legacy := &plainLocker{}
+	legacy.Lock()
+	mutex := WrapLocker("legacy", legacy)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	// Act
+	err := mutex.Lock(ctx)
+	legacy.Unlock() // the helper goroutine now acquires and releases it
+
+	// Assert
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected DeadlineExceeded, got %v", err)
+	}
+	acquired := make(chan struct{})
+	go func() {
+		legacy.Lock()
+		close(acquired)
+	}()
+	select {
+	case <-acquired:
+		legacy.Unlock()
+	case <-time.After(time.Second):
+		t.Fatal("expected abandoned acquisition to be released by the helper")
+	}
+}
+
+func TestWrapLocker_TryLockUntil(t *testing.T) {
+	// Arrange
+	var legacy sync.RWMutex
+	legacy.RLock()
+	mutex := WrapLocker("legacy", &legacy)
+
+	// Act
+	ok, err := mutex.TryLockUntil(time.Now().Add(10 * time.Millisecond))
+
+	// Assert
+	if ok || err != nil {
+		t.Errorf("expected (false, nil) while a reader holds the RWMutex, got %v, %v", ok, err)
+	}
+	legacy.RUnlock()
+}
+
+func TestWrapLocker_LockWithHooks(t *testing.T) {
+	// Arrange
+	wrapped := WrapLocker("legacy", &sync.Mutex{})
+	var events []string
+
+	// Act
+	err := wrapped.LockWithHooks(context.Background(), Hooks{
+		OnAcquired: func() { events = append(events, "acquired") },
+		OnReleased: func() { events = append(events, "released") },
+	})
+	wrapped.Unlock()
+
+	// Assert
+	if err != nil {
+		t.Fatalf("expected lock to succeed, got %v", err)
+	}
+	if len(events) != 2 || events[0] != "acquired" || events[1] != "released" {
+		t.Errorf("expected [acquired released], got %v", events)
+	}
+}
+
+func TestWrapLocker_DistinctKeysUnderWithLock(t *testing.T) {
+	// Arrange
+	first := WrapLocker("legacy-a", &sync.Mutex{})
+	second := WrapLocker("legacy-b", &sync.Mutex{})
+	ran := false
+
+	// Act
+	err := WithLock(context.Background(), first, func(ctx context.Context) error {
+		return WithLock(ctx, second, func(context.Context) error {
+			ran = true
+			return nil
+		})
+	})
+
+	// Assert
+	if err != nil {
+		t.Fatalf("expected both wrapped lockers to be held, got %v", err)
+	}
+	if !ran {
+		t.Error("expected the inner function to run")
+	}
+}
+
+func TestWrapLocker_DistinctKeysInLockGroup(t *testing.T) {
+	// Arrange
+	g := NewLockGroup()
+	defer g.UnlockAll()
+
+	// Act
+	errA := g.LockMutex(context.Background(), WrapLocker("legacy-a", &sync.Mutex{}))
+	errB := g.LockMutex(context.Background(), WrapLocker("legacy-b", &sync.Mutex{}))
+
+	// Assert
+	if errA != nil || errB != nil {
+		t.Errorf("expected both locks to succeed, got %v and %v", errA, errB)
+	}
+	if g.Len() != 2 {
+		t.Errorf("expected 2 held mutexes, got %d", g.Len())
+	}
+}
+
+func TestWrapLocker_UnlockWithoutLockIsNoOp(t *testing.T) {
+	// Arrange
+	var legacy sync.Mutex
+	mutex := WrapLocker("legacy", &legacy)
+
+	// Act
+	mutex.Unlock() // would panic if forwarded to the unlocked sync.Mutex
+	legacy.Lock()
+	mutex.Unlock() // must not release legacy code's hold
+
+	// Assert
+	if legacy.TryLock() {
+		t.Error("expected legacy code's hold to survive a stray Unlock")
+	}
+	legacy.Unlock()
+}
+
+func TestWrapLocker_HolderUsesClock(t *testing.T) {
+	// Arrange
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	mutex := WrapLocker("legacy", &sync.Mutex{}, WithWrapClock(clock.NewFakeClock(now)))
+
+	// Act
+	err := mutex.Lock(context.Background())
+	holder := mutex.Holder()
+	mutex.Unlock()
+
+	// Assert
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if info, ok := holder.Value(); !ok || !info.AcquiredAt.Equal(now) {
+		t.Errorf("expected AcquiredAt %v, got %v, %v", now, info.AcquiredAt, ok)
+	}
+}
+
+func TestWrapLocker_PanicsOnEmptyKey(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("expected a panic for an empty key")
+		}
+	}()
+	WrapLocker("", &sync.Mutex{})
+}
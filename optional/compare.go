@@ -0,0 +1,30 @@
+package optional
+
+import "cmp"
+
+// Compare orders two Options: None sorts before any Some, and two Somes
+// compare by their values as cmp.Compare does. It returns -1, 0 or +1 and
+// can be passed to slices.SortFunc.
+//
+// Parameters:
+//   - a: The first Option.
+//   - b: The second Option.
+//
+// Returns:
+//   - int: -1 if a < b, 0 if a == b, +1 if a > b.
+//
+// Example:
+//
+//	slices.SortFunc(deadlines, optional.Compare[time.Duration])
+func Compare[T cmp.Ordered](a, b Option[T]) int {
+	switch {
+	case !a.some && !b.some:
+		return 0
+	case !a.some:
+		return -1
+	case !b.some:
+		return 1
+	default:
+		return cmp.Compare(a.value, b.value)
+	}
+}
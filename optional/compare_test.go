@@ -0,0 +1,23 @@
+package optional
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestCompare(t *testing.T) {
+	// Arrange
+	options := []Option[int]{Some(3), None[int](), Some(1), None[int]()}
+
+	// Act
+	slices.SortFunc(options, Compare[int])
+
+	// Assert
+	want := []Option[int]{None[int](), None[int](), Some(1), Some(3)}
+	if !slices.Equal(options, want) {
+		t.Errorf("expected None values first then ascending values, got %v", options)
+	}
+	if Compare(Some(2), Some(2)) != 0 {
+		t.Error("expected equal values to compare equal")
+	}
+}
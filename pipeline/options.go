@@ -0,0 +1,26 @@
+package pipeline
+
+// StageOption configures a Stage created by NewStage.
+type StageOption func(*stageConfig)
+
+// stageConfig holds the settings of a stage.
+type stageConfig struct {
+	parallelism int
+	buffer      int
+}
+
+// WithParallelism runs n workers for the stage. Outputs of a parallel stage
+// are not ordered like its inputs. Values below 1 are treated as 1.
+func WithParallelism(n int) StageOption {
+	return func(c *stageConfig) {
+		c.parallelism = max(n, 1)
+	}
+}
+
+// WithBuffer buffers up to n outputs of the stage, decoupling it from a
+// slower downstream stage. Values below 0 are treated as 0.
+func WithBuffer(n int) StageOption {
+	return func(c *stageConfig) {
+		c.buffer = max(n, 0)
+	}
+}
@@ -0,0 +1,176 @@
+// Package pipeline composes typed processing stages into cancellable
+// channel pipelines, replacing hand-written goroutine and channel plumbing
+// for ETL-style flows.
+package pipeline
+
+import (
+	"context"
+	"fmt"
+	"iter"
+	"sync"
+
+	"github.com/zodimo/go-zbase-std/multierr"
+)
+
+// Stage transforms values of type In into values of type Out. Stages are
+// created with NewStage and chained with Then; a Stage is a description and
+// can be run any number of times.
+type Stage[In, Out any] struct {
+	start func(ctx context.Context, r *run, in <-chan In) <-chan Out
+}
+
+// run tracks the goroutines and errors of a single pipeline execution.
+type run struct {
+	wg   sync.WaitGroup
+	errs multierr.Error
+}
+
+// NewStage creates a stage applying fn to every input. An input for which
+// fn fails produces no output; the error, prefixed with the stage name, is
+// collected and reported when the pipeline finishes, and processing
+// continues. Without options the stage runs one worker and does not buffer
+// its output.
+//
+// Parameters:
+//   - name: Identifies the stage in errors.
+//   - fn: Transforms a single input.
+//   - opts: Options configuring parallelism and buffering.
+//
+// Returns:
+//   - Stage[In, Out]: The new stage.
+//
+// Example:
+//
+//	parse := pipeline.NewStage("parse", parseRecord, pipeline.WithParallelism(4))
+//	store := pipeline.NewStage("store", saveRecord, pipeline.WithBuffer(64))
+//	ids, err := pipeline.Run(ctx, pipeline.Then(parse, store), slices.Values(lines))
+func NewStage[In, Out any](name string, fn func(context.Context, In) (Out, error), opts ...StageOption) Stage[In, Out] {
+	cfg := stageConfig{parallelism: 1}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	return Stage[In, Out]{
+		start: func(ctx context.Context, r *run, in <-chan In) <-chan Out {
+			out := make(chan Out, cfg.buffer)
+			var workers sync.WaitGroup
+			workers.Add(cfg.parallelism)
+			r.wg.Add(cfg.parallelism + 1)
+			for range cfg.parallelism {
+				go func() {
+					defer r.wg.Done()
+					defer workers.Done()
+					process(ctx, r, name, fn, in, out)
+				}()
+			}
+			go func() {
+				defer r.wg.Done()
+				workers.Wait()
+				close(out)
+			}()
+			return out
+		},
+	}
+}
+
+// Then chains two stages, feeding the outputs of first into second.
+//
+// Parameters:
+//   - first: The upstream stage.
+//   - second: The downstream stage.
+//
+// Returns:
+//   - Stage[A, C]: The combined stage.
+func Then[A, B, C any](first Stage[A, B], second Stage[B, C]) Stage[A, C] {
+	return Stage[A, C]{
+		start: func(ctx context.Context, r *run, in <-chan A) <-chan C {
+			return second.start(ctx, r, first.start(ctx, r, in))
+		},
+	}
+}
+
+// Start runs stage over the values received from in until in is closed or
+// ctx is done. The returned channel is closed once every output has been
+// delivered. The returned wait function must be called after the output
+// channel has been drained, or after ctx is done; it waits for every stage
+// goroutine to exit and returns the collected errors.
+//
+// Parameters:
+//   - ctx: Stops the pipeline when done.
+//   - stage: The stage to run.
+//   - in: The inputs.
+//
+// Returns:
+//   - <-chan Out: The outputs, in no particular order across parallel
+//     workers.
+//   - func() error: Waits for the pipeline and returns a *multierr.Error
+//     holding every stage error, and ctx's error if ctx ended the run; nil
+//     if there were none.
+func Start[In, Out any](ctx context.Context, stage Stage[In, Out], in <-chan In) (<-chan Out, func() error) {
+	r := &run{}
+	out := stage.start(ctx, r, in)
+	return out, func() error {
+		r.wg.Wait()
+		if err := ctx.Err(); err != nil {
+			r.errs.Append(err)
+		}
+		return r.errs.ErrorOrNil()
+	}
+}
+
+// Run runs stage over inputs and collects its outputs. It returns once the
+// inputs are exhausted and every output has been collected, or once ctx is
+// done.
+//
+// Parameters:
+//   - ctx: Stops the pipeline when done.
+//   - stage: The stage to run.
+//   - inputs: The inputs.
+//
+// Returns:
+//   - []Out: The outputs, in no particular order across parallel workers.
+//   - error: As returned by the wait function of Start.
+func Run[In, Out any](ctx context.Context, stage Stage[In, Out], inputs iter.Seq[In]) ([]Out, error) {
+	in := make(chan In)
+	go func() {
+		defer close(in)
+		for v := range inputs {
+			select {
+			case in <- v:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	out, wait := Start(ctx, stage, in)
+	var results []Out
+	for v := range out {
+		results = append(results, v)
+	}
+	return results, wait()
+}
+
+// process is the loop of a single stage worker.
+func process[In, Out any](ctx context.Context, r *run, name string, fn func(context.Context, In) (Out, error), in <-chan In, out chan<- Out) {
+	for {
+		var v In
+		select {
+		case next, ok := <-in:
+			if !ok {
+				return
+			}
+			v = next
+		case <-ctx.Done():
+			return
+		}
+		result, err := fn(ctx, v)
+		if err != nil {
+			r.errs.Append(fmt.Errorf("pipeline: stage %q: %w", name, err))
+			continue
+		}
+		select {
+		case out <- result:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
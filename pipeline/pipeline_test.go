@@ -0,0 +1,115 @@
+package pipeline
+
+import (
+	"context"
+	"errors"
+	"runtime"
+	"slices"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/zodimo/go-zbase-std/multierr"
+)
+
+func TestRun_ChainsStages(t *testing.T) {
+	// Arrange
+	parse := NewStage("parse", func(_ context.Context, s string) (int, error) {
+		return strconv.Atoi(s)
+	}, WithParallelism(3))
+	double := NewStage("double", func(_ context.Context, n int) (int, error) {
+		return 2 * n, nil
+	}, WithBuffer(4))
+
+	// Act
+	got, err := Run(context.Background(), Then(parse, double), slices.Values([]string{"1", "2", "3", "4"}))
+
+	// Assert
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	slices.Sort(got)
+	if !slices.Equal(got, []int{2, 4, 6, 8}) {
+		t.Errorf("expected [2 4 6 8], got %v", got)
+	}
+}
+
+func TestRun_CollectsStageErrors(t *testing.T) {
+	// Arrange
+	parse := NewStage("parse", func(_ context.Context, s string) (int, error) {
+		return strconv.Atoi(s)
+	})
+
+	// Act
+	got, err := Run(context.Background(), parse, slices.Values([]string{"1", "x", "3", "y"}))
+
+	// Assert
+	if len(got) != 2 {
+		t.Errorf("expected the 2 valid inputs to be processed, got %v", got)
+	}
+	var merr *multierr.Error
+	if !errors.As(err, &merr) || merr.Len() != 2 {
+		t.Fatalf("expected a multi-error with 2 errors, got %v", err)
+	}
+	if !errors.Is(err, strconv.ErrSyntax) {
+		t.Errorf("expected the errors to wrap strconv.ErrSyntax, got %v", err)
+	}
+}
+
+func TestRun_Cancelled(t *testing.T) {
+	// Arrange
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	before := runtime.NumGoroutine()
+	slow := NewStage("slow", func(ctx context.Context, n int) (int, error) {
+		select {
+		case <-time.After(time.Millisecond):
+			return n, nil
+		case <-ctx.Done():
+			return 0, ctx.Err()
+		}
+	}, WithParallelism(2))
+	endless := func(yield func(int) bool) {
+		for i := 0; yield(i); i++ {
+		}
+	}
+
+	// Act
+	_, err := Run(ctx, Then(slow, slow), endless)
+
+	// Assert
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected context.DeadlineExceeded, got %v", err)
+	}
+	deadline := time.Now().Add(time.Second)
+	for runtime.NumGoroutine() > before && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	if n := runtime.NumGoroutine(); n > before {
+		t.Errorf("expected goroutines to exit, %d remain above the baseline", n-before)
+	}
+}
+
+func TestStart_Streams(t *testing.T) {
+	// Arrange
+	in := make(chan int)
+	square := NewStage("square", func(_ context.Context, n int) (int, error) { return n * n, nil })
+	out, wait := Start(context.Background(), square, in)
+
+	// Act
+	in <- 3
+	first := <-out
+	close(in)
+	_, open := <-out
+
+	// Assert
+	if first != 9 {
+		t.Errorf("expected 9, got %d", first)
+	}
+	if open {
+		t.Error("expected the output to be closed once the input is closed")
+	}
+	if err := wait(); err != nil {
+		t.Errorf("expected no error, got %v", err)
+	}
+}
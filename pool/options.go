@@ -0,0 +1,59 @@
+package pool
+
+import "context"
+
+// Option configures Workers created by New.
+type Option func(*Workers)
+
+// WithConcurrency runs n workers. Values below 1 are treated as 1.
+func WithConcurrency(n int) Option {
+	return func(w *Workers) {
+		w.concurrency = max(n, 1)
+	}
+}
+
+// WithQueueDepth queues up to n tasks waiting for a free worker before
+// Submit blocks. With a depth of 0, Submit hands tasks directly to idle
+// workers. Values below 0 are treated as 0.
+func WithQueueDepth(n int) Option {
+	return func(w *Workers) {
+		w.queueDepth = max(n, 0)
+	}
+}
+
+// ResourceOption configures a Resource created by NewResource.
+type ResourceOption[T any] func(*Resource[T])
+
+// WithMinSize creates n resources up front so the first acquisitions do not
+// pay for construction. Values below 0 are treated as 0.
+func WithMinSize[T any](n int) ResourceOption[T] {
+	return func(r *Resource[T]) {
+		r.minSize = max(n, 0)
+	}
+}
+
+// WithMaxSize bounds the number of open resources, idle and in use, to n;
+// Acquire waits for a release once the bound is reached. Values below 1
+// leave the pool unbounded.
+func WithMaxSize[T any](n int) ResourceOption[T] {
+	return func(r *Resource[T]) {
+		r.maxSize = n
+	}
+}
+
+// WithHealthCheck checks idle resources with check before handing them
+// out. A resource that fails the check is destroyed and another is used.
+func WithHealthCheck[T any](check func(ctx context.Context, v T) error) ResourceOption[T] {
+	return func(r *Resource[T]) {
+		r.check = check
+	}
+}
+
+// WithDestroy calls destroy on every resource the pool discards: those
+// failing a health check, those released after Close, and the idle ones
+// at Close.
+func WithDestroy[T any](destroy func(v T)) ResourceOption[T] {
+	return func(r *Resource[T]) {
+		r.destroy = destroy
+	}
+}
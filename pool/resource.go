@@ -0,0 +1,248 @@
+package pool
+
+import (
+	"context"
+	"sync"
+
+	"github.com/zodimo/go-zbase-std/errcode"
+)
+
+// ResourceClosedError is returned by Acquire once the Resource has been
+// closed.
+var ResourceClosedError = errcode.New(errcode.Unavailable, "pool: resource pool closed")
+
+// Resource pools values with real lifecycles, such as connections, that
+// sync.Pool is unsuitable for because it drops values silently. Resources
+// are created on demand up to an optional maximum, health-checked before
+// reuse and destroyed explicitly. It is safe for concurrent use.
+type Resource[T any] struct {
+	mu     sync.Mutex
+	idle   []T  // Most recently released last.
+	open   int  // Resources created and not yet destroyed.
+	closed bool // Set by Close.
+
+	slots chan struct{} // Holds a token per acquired resource; nil if unbounded.
+	done  chan struct{} // Closed by Close.
+
+	create  func(ctx context.Context) (T, error)
+	check   func(ctx context.Context, v T) error
+	destroy func(v T)
+	minSize int
+	maxSize int
+}
+
+// NewResource creates a pool that makes resources with create, configured
+// by opts.
+//
+// Parameters:
+//   - ctx: Passed to create for the resources made up front by WithMinSize.
+//   - create: Makes a new resource.
+//   - opts: Options configuring size bounds, health checks and cleanup.
+//
+// Returns:
+//   - *Resource[T]: The new pool. Call Close to release its resources.
+//   - error: The error from create if a resource made up front failed, in
+//     which case the ones already made are destroyed.
+//
+// Example:
+//
+//	conns, err := pool.NewResource(ctx, dial,
+//		pool.WithMaxSize[*Conn](10),
+//		pool.WithHealthCheck(func(ctx context.Context, c *Conn) error { return c.Ping(ctx) }),
+//		pool.WithDestroy(func(c *Conn) { c.Close() }),
+//	)
+//	conn, release, err := conns.Acquire(ctx)
+//	if err != nil {
+//		return err
+//	}
+//	defer release()
+func NewResource[T any](ctx context.Context, create func(ctx context.Context) (T, error), opts ...ResourceOption[T]) (*Resource[T], error) {
+	r := &Resource[T]{
+		done:   make(chan struct{}),
+		create: create,
+	}
+	for _, opt := range opts {
+		opt(r)
+	}
+	if r.maxSize > 0 {
+		r.slots = make(chan struct{}, r.maxSize)
+		r.minSize = min(r.minSize, r.maxSize)
+	}
+	for range r.minSize {
+		v, err := create(ctx)
+		if err != nil {
+			r.Close()
+			return nil, err
+		}
+		r.idle = append(r.idle, v)
+		r.open++
+	}
+	return r, nil
+}
+
+// Acquire returns an idle resource that passes the health check, or
+// creates a new one, waiting for a release if the pool is at its maximum
+// size.
+//
+// Parameters:
+//   - ctx: Bounds the wait and is passed to the health check and create.
+//
+// Returns:
+//   - T: The resource, owned by the caller until release is called.
+//   - func(): Returns the resource to the pool. Calling it more than once
+//     has no further effect.
+//   - error: ResourceClosedError if the pool is closed; ctx's error if ctx
+//     is done first; the error from create if a new resource failed.
+func (r *Resource[T]) Acquire(ctx context.Context) (T, func(), error) {
+	var zero T
+	if err := r.acquireSlot(ctx); err != nil {
+		return zero, nil, err
+	}
+	for {
+		v, ok, err := r.takeIdle()
+		if err != nil {
+			r.releaseSlot()
+			return zero, nil, err
+		}
+		if !ok {
+			break
+		}
+		if r.check == nil || r.check(ctx, v) == nil {
+			return v, r.releaser(v), nil
+		}
+		r.discard(v)
+	}
+	v, err := r.create(ctx)
+	if err != nil {
+		r.releaseSlot()
+		return zero, nil, err
+	}
+	r.mu.Lock()
+	if r.closed {
+		r.mu.Unlock()
+		r.releaseSlot()
+		r.destroyValue(v)
+		return zero, nil, ResourceClosedError
+	}
+	r.open++
+	r.mu.Unlock()
+	return v, r.releaser(v), nil
+}
+
+// Len returns the number of open resources, idle and in use.
+func (r *Resource[T]) Len() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.open
+}
+
+// Idle returns the number of resources waiting to be acquired.
+func (r *Resource[T]) Idle() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return len(r.idle)
+}
+
+// Close destroys the idle resources and makes Acquire fail with
+// ResourceClosedError. Resources in use are destroyed when released.
+// Calling Close more than once has no further effect.
+func (r *Resource[T]) Close() {
+	r.mu.Lock()
+	if r.closed {
+		r.mu.Unlock()
+		return
+	}
+	r.closed = true
+	close(r.done)
+	idle := r.idle
+	r.idle = nil
+	r.open -= len(idle)
+	r.mu.Unlock()
+	for _, v := range idle {
+		r.destroyValue(v)
+	}
+}
+
+// acquireSlot waits for room under the maximum size.
+func (r *Resource[T]) acquireSlot(ctx context.Context) error {
+	select {
+	case <-r.done:
+		return ResourceClosedError
+	default:
+	}
+	if r.slots == nil {
+		return nil
+	}
+	select {
+	case r.slots <- struct{}{}:
+		return nil
+	case <-r.done:
+		return ResourceClosedError
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// releaseSlot frees the room taken by acquireSlot.
+func (r *Resource[T]) releaseSlot() {
+	if r.slots != nil {
+		<-r.slots
+	}
+}
+
+// takeIdle pops the most recently released idle resource, if any.
+func (r *Resource[T]) takeIdle() (T, bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var zero T
+	if r.closed {
+		return zero, false, ResourceClosedError
+	}
+	n := len(r.idle)
+	if n == 0 {
+		return zero, false, nil
+	}
+	v := r.idle[n-1]
+	r.idle[n-1] = zero
+	r.idle = r.idle[:n-1]
+	return v, true, nil
+}
+
+// releaser returns the release function handed out with v.
+func (r *Resource[T]) releaser(v T) func() {
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			r.put(v)
+			r.releaseSlot()
+		})
+	}
+}
+
+// put returns v to the idle resources, or destroys it if the pool is
+// closed.
+func (r *Resource[T]) put(v T) {
+	r.mu.Lock()
+	if !r.closed {
+		r.idle = append(r.idle, v)
+		r.mu.Unlock()
+		return
+	}
+	r.mu.Unlock()
+	r.discard(v)
+}
+
+// discard destroys an open resource.
+func (r *Resource[T]) discard(v T) {
+	r.mu.Lock()
+	r.open--
+	r.mu.Unlock()
+	r.destroyValue(v)
+}
+
+// destroyValue runs the destroy callback, if any.
+func (r *Resource[T]) destroyValue(v T) {
+	if r.destroy != nil {
+		r.destroy(v)
+	}
+}
@@ -0,0 +1,214 @@
+package pool
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+// conn is a fake resource that records its lifecycle.
+type conn struct {
+	id        int
+	healthy   bool
+	destroyed bool
+}
+
+// connFactory creates numbered conns and remembers them.
+type connFactory struct {
+	mu    sync.Mutex
+	conns []*conn
+	err   error
+}
+
+func (f *connFactory) create(context.Context) (*conn, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.err != nil {
+		return nil, f.err
+	}
+	c := &conn{id: len(f.conns) + 1, healthy: true}
+	f.conns = append(f.conns, c)
+	return c, nil
+}
+
+func newConnPool(t *testing.T, f *connFactory, opts ...ResourceOption[*conn]) *Resource[*conn] {
+	t.Helper()
+	opts = append([]ResourceOption[*conn]{
+		WithHealthCheck(func(_ context.Context, c *conn) error {
+			if !c.healthy {
+				return errors.New("unhealthy")
+			}
+			return nil
+		}),
+		WithDestroy(func(c *conn) { c.destroyed = true }),
+	}, opts...)
+	r, err := NewResource(context.Background(), f.create, opts...)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	return r
+}
+
+func TestResource_ReusesReleased(t *testing.T) {
+	// Arrange
+	f := &connFactory{}
+	r := newConnPool(t, f)
+	defer r.Close()
+	first, release, _ := r.Acquire(context.Background())
+	release()
+	release() // second call is a no-op
+
+	// Act
+	second, release, err := r.Acquire(context.Background())
+	defer release()
+
+	// Assert
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if first != second || len(f.conns) != 1 {
+		t.Errorf("expected the released conn to be reused, got %d conns", len(f.conns))
+	}
+}
+
+func TestResource_MinSizeCreatesUpFront(t *testing.T) {
+	// Arrange
+	f := &connFactory{}
+
+	// Act
+	r := newConnPool(t, f, WithMinSize[*conn](3))
+	defer r.Close()
+
+	// Assert
+	if r.Len() != 3 || r.Idle() != 3 {
+		t.Errorf("expected 3 idle conns, got %d open and %d idle", r.Len(), r.Idle())
+	}
+}
+
+func TestResource_MinSizeFailureDestroysCreated(t *testing.T) {
+	// Arrange
+	f := &connFactory{}
+	failure := errors.New("dial failed")
+	calls := 0
+	create := func(ctx context.Context) (*conn, error) {
+		calls++
+		if calls == 2 {
+			return nil, failure
+		}
+		return f.create(ctx)
+	}
+
+	// Act
+	_, err := NewResource(context.Background(), create,
+		WithMinSize[*conn](3),
+		WithDestroy(func(c *conn) { c.destroyed = true }),
+	)
+
+	// Assert
+	if !errors.Is(err, failure) {
+		t.Errorf("expected %v, got %v", failure, err)
+	}
+	if len(f.conns) != 1 || !f.conns[0].destroyed {
+		t.Errorf("expected the conn created before the failure to be destroyed")
+	}
+}
+
+func TestResource_HealthCheckDiscardsUnhealthy(t *testing.T) {
+	// Arrange
+	f := &connFactory{}
+	r := newConnPool(t, f)
+	defer r.Close()
+	c, release, _ := r.Acquire(context.Background())
+	c.healthy = false
+	release()
+
+	// Act
+	replacement, release, err := r.Acquire(context.Background())
+	defer release()
+
+	// Assert
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if replacement == c || !c.destroyed {
+		t.Error("expected the unhealthy conn to be destroyed and replaced")
+	}
+	if r.Len() != 1 {
+		t.Errorf("expected 1 open conn, got %d", r.Len())
+	}
+}
+
+func TestResource_MaxSizeWaitsForRelease(t *testing.T) {
+	// Arrange
+	f := &connFactory{}
+	r := newConnPool(t, f, WithMaxSize[*conn](1))
+	defer r.Close()
+	_, release, _ := r.Acquire(context.Background())
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	// Act
+	_, _, err := r.Acquire(ctx)
+	release()
+	_, releaseAgain, errAgain := r.Acquire(context.Background())
+	defer releaseAgain()
+
+	// Assert
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected Acquire to wait at the maximum size, got %v", err)
+	}
+	if errAgain != nil || len(f.conns) != 1 {
+		t.Errorf("expected the released conn to be acquired, got %v and %d conns", errAgain, len(f.conns))
+	}
+}
+
+func TestResource_CreateErrorFreesSlot(t *testing.T) {
+	// Arrange
+	failure := errors.New("dial failed")
+	f := &connFactory{err: failure}
+	r := newConnPool(t, f, WithMaxSize[*conn](1))
+	defer r.Close()
+
+	// Act
+	_, _, err := r.Acquire(context.Background())
+	f.err = nil
+	_, release, errAgain := r.Acquire(context.Background())
+	defer release()
+
+	// Assert
+	if !errors.Is(err, failure) {
+		t.Errorf("expected %v, got %v", failure, err)
+	}
+	if errAgain != nil {
+		t.Errorf("expected the failed creation to free its slot, got %v", errAgain)
+	}
+}
+
+func TestResource_Close(t *testing.T) {
+	// Arrange
+	f := &connFactory{}
+	r := newConnPool(t, f, WithMaxSize[*conn](1))
+	idle, release, _ := r.Acquire(context.Background())
+	release()
+	inUse, release, _ := r.Acquire(context.Background())
+	waiting := make(chan error)
+	go func() {
+		_, _, err := r.Acquire(context.Background())
+		waiting <- err
+	}()
+
+	// Act
+	r.Close()
+	release()
+	_, _, err := r.Acquire(context.Background())
+
+	// Assert
+	if !errors.Is(<-waiting, ResourceClosedError) || !errors.Is(err, ResourceClosedError) {
+		t.Errorf("expected Acquire to fail with ResourceClosedError, got %v", err)
+	}
+	if idle != inUse || !inUse.destroyed || r.Len() != 0 {
+		t.Errorf("expected the released conn to be destroyed, got %d open", r.Len())
+	}
+}
@@ -0,0 +1,237 @@
+// Package pool provides a bounded worker pool whose tasks report their
+// outcome through futures, and a pool of reusable resources, such as
+// connections, that are created, health-checked and destroyed explicitly.
+package pool
+
+import (
+	"context"
+	"fmt"
+	"runtime"
+	"sync"
+
+	"github.com/zodimo/go-zbase-std/errcode"
+	"github.com/zodimo/go-zbase-std/future"
+)
+
+// ClosedError is returned by Submit once Shutdown has been called, and
+// settles the futures of queued tasks that were never run because a
+// shutdown was forced.
+var ClosedError = errcode.New(errcode.Unavailable, "pool: workers shut down")
+
+// Workers runs submitted tasks on a fixed number of goroutines, queueing up
+// to a bounded number of tasks that are waiting for a free worker. A task
+// that panics settles its future with an error instead of crashing the
+// worker. It is safe for concurrent use.
+type Workers struct {
+	tasks chan task
+	quit  chan struct{} // Closed by Shutdown.
+	wg    sync.WaitGroup
+
+	// mu guards closing quit against Submit calls registering in submitting,
+	// so Shutdown can wait for every enqueue that started before it.
+	mu         sync.Mutex
+	closed     bool
+	submitting sync.WaitGroup
+
+	// ctx is cancelled when a shutdown is forced, cancelling the contexts
+	// of running tasks.
+	ctx    context.Context
+	cancel context.CancelFunc
+
+	concurrency int
+	queueDepth  int
+}
+
+// task is a queued unit of work.
+type task struct {
+	ctx   context.Context
+	run   func(context.Context)
+	abort func(error) // Settles the task's future without running it.
+}
+
+// New starts a pool of workers configured by opts. Without options it runs
+// runtime.GOMAXPROCS(0) workers and queues as many tasks as it has workers.
+//
+// Parameters:
+//   - opts: Options configuring concurrency and queue depth.
+//
+// Returns:
+//   - *Workers: The running pool. Call Shutdown to stop it.
+//
+// Example:
+//
+//	workers := pool.New(pool.WithConcurrency(8), pool.WithQueueDepth(100))
+//	defer workers.Shutdown(context.Background())
+//	done, err := workers.Submit(ctx, func(ctx context.Context) error {
+//		return resize(ctx, image)
+//	})
+func New(opts ...Option) *Workers {
+	w := &Workers{
+		quit:        make(chan struct{}),
+		concurrency: runtime.GOMAXPROCS(0),
+		queueDepth:  -1,
+	}
+	for _, opt := range opts {
+		opt(w)
+	}
+	if w.queueDepth < 0 {
+		w.queueDepth = w.concurrency
+	}
+	w.tasks = make(chan task, w.queueDepth)
+	w.ctx, w.cancel = context.WithCancel(context.Background())
+	w.wg.Add(w.concurrency)
+	for range w.concurrency {
+		go w.work()
+	}
+	return w
+}
+
+// Submit queues fn to run on a worker, waiting for room in the queue if it
+// is full. fn receives a context derived from ctx that is also cancelled if
+// a shutdown is forced.
+//
+// Parameters:
+//   - ctx: Bounds the wait for room in the queue; parent of fn's context.
+//   - fn: The task to run.
+//
+// Returns:
+//   - *future.Future[struct{}]: Settled with fn's error once fn returns.
+//   - error: ClosedError if the pool is shut down; ctx's error if ctx is
+//     done before fn could be queued.
+func (w *Workers) Submit(ctx context.Context, fn func(context.Context) error) (*future.Future[struct{}], error) {
+	return SubmitValue(ctx, w, func(ctx context.Context) (struct{}, error) {
+		return struct{}{}, fn(ctx)
+	})
+}
+
+// SubmitValue queues fn on w like Submit, for tasks that produce a value.
+//
+// Parameters:
+//   - ctx: Bounds the wait for room in the queue; parent of fn's context.
+//   - w: The pool to run fn on.
+//   - fn: The task to run.
+//
+// Returns:
+//   - *future.Future[T]: Settled with fn's outcome once fn returns.
+//   - error: ClosedError if the pool is shut down; ctx's error if ctx is
+//     done before fn could be queued.
+func SubmitValue[T any](ctx context.Context, w *Workers, fn func(context.Context) (T, error)) (*future.Future[T], error) {
+	p, f := future.New[T]()
+	t := task{
+		ctx: ctx,
+		run: func(ctx context.Context) {
+			value, err := call(ctx, fn)
+			if err != nil {
+				p.Reject(err)
+				return
+			}
+			p.Resolve(value)
+		},
+		abort: func(err error) { p.Reject(err) },
+	}
+	w.mu.Lock()
+	if w.closed {
+		w.mu.Unlock()
+		return nil, ClosedError
+	}
+	w.submitting.Add(1)
+	w.mu.Unlock()
+	defer w.submitting.Done()
+	select {
+	case w.tasks <- t:
+		return f, nil
+	case <-w.quit:
+		return nil, ClosedError
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// Shutdown stops accepting tasks and waits for the queued and running tasks
+// to finish. If ctx is done first, the shutdown is forced: the contexts of
+// running tasks are cancelled, queued tasks are settled with ClosedError
+// without running, and Shutdown returns without waiting further.
+//
+// Parameters:
+//   - ctx: Bounds how long Shutdown waits for tasks to finish.
+//
+// Returns:
+//   - error: ctx's error if the shutdown was forced; nil otherwise.
+func (w *Workers) Shutdown(ctx context.Context) error {
+	w.mu.Lock()
+	if !w.closed {
+		w.closed = true
+		close(w.quit)
+	}
+	w.mu.Unlock()
+	idle := make(chan struct{})
+	go func() {
+		w.wg.Wait()
+		// A Submit racing with Shutdown may have queued a task after the
+		// workers exited. Once the submitters in flight have returned, no
+		// more tasks can be queued, so draining then settles every one.
+		w.submitting.Wait()
+		for {
+			select {
+			case t := <-w.tasks:
+				t.abort(ClosedError)
+			default:
+				close(idle)
+				return
+			}
+		}
+	}()
+	select {
+	case <-idle:
+		w.cancel()
+		return nil
+	case <-ctx.Done():
+		w.cancel()
+		return ctx.Err()
+	}
+}
+
+// work runs queued tasks until the pool is shut down and the queue is
+// drained.
+func (w *Workers) work() {
+	defer w.wg.Done()
+	for {
+		select {
+		case t := <-w.tasks:
+			w.run(t)
+		case <-w.quit:
+			for {
+				select {
+				case t := <-w.tasks:
+					w.run(t)
+				default:
+					return
+				}
+			}
+		}
+	}
+}
+
+// run runs t with a context that is cancelled by a forced shutdown, or
+// aborts it if a shutdown has already been forced.
+func (w *Workers) run(t task) {
+	if w.ctx.Err() != nil {
+		t.abort(ClosedError)
+		return
+	}
+	ctx, cancel := context.WithCancel(t.ctx)
+	defer cancel()
+	stop := context.AfterFunc(w.ctx, cancel)
+	defer stop()
+	t.run(ctx)
+}
+
+// call runs fn, converting a panic into an error.
+func call[T any](ctx context.Context, fn func(context.Context) (T, error)) (value T, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("pool: task panicked: %v", r)
+		}
+	}()
+	return fn(ctx)
+}
@@ -0,0 +1,189 @@
+package pool
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/zodimo/go-zbase-std/future"
+)
+
+func TestWorkers_RunsTasks(t *testing.T) {
+	// Arrange
+	w := New(WithConcurrency(2))
+	defer w.Shutdown(context.Background())
+	failure := errors.New("boom")
+
+	// Act
+	ok, okErr := w.Submit(context.Background(), func(context.Context) error { return nil })
+	failed, failedErr := w.Submit(context.Background(), func(context.Context) error { return failure })
+	squared, squaredErr := SubmitValue(context.Background(), w, func(context.Context) (int, error) { return 7 * 7, nil })
+
+	// Assert
+	if okErr != nil || failedErr != nil || squaredErr != nil {
+		t.Fatalf("expected submissions to succeed, got %v, %v, %v", okErr, failedErr, squaredErr)
+	}
+	if _, err := ok.Await(context.Background()); err != nil {
+		t.Errorf("expected no error, got %v", err)
+	}
+	if _, err := failed.Await(context.Background()); !errors.Is(err, failure) {
+		t.Errorf("expected %v, got %v", failure, err)
+	}
+	if v, _ := squared.Await(context.Background()); v != 49 {
+		t.Errorf("expected 49, got %d", v)
+	}
+}
+
+func TestWorkers_BoundsConcurrency(t *testing.T) {
+	// Arrange
+	w := New(WithConcurrency(3), WithQueueDepth(100))
+	var running, peak atomic.Int32
+	task := func(context.Context) error {
+		n := running.Add(1)
+		for {
+			p := peak.Load()
+			if n <= p || peak.CompareAndSwap(p, n) {
+				break
+			}
+		}
+		time.Sleep(5 * time.Millisecond)
+		running.Add(-1)
+		return nil
+	}
+
+	// Act
+	for range 20 {
+		if _, err := w.Submit(context.Background(), task); err != nil {
+			t.Fatalf("expected submission to succeed, got %v", err)
+		}
+	}
+	err := w.Shutdown(context.Background())
+
+	// Assert
+	if err != nil {
+		t.Errorf("expected graceful shutdown, got %v", err)
+	}
+	if peak.Load() > 3 {
+		t.Errorf("expected at most 3 concurrent tasks, got %d", peak.Load())
+	}
+}
+
+func TestWorkers_SubmitWaitsForQueueRoom(t *testing.T) {
+	// Arrange
+	w := New(WithConcurrency(1), WithQueueDepth(0))
+	defer w.Shutdown(context.Background())
+	release := make(chan struct{})
+	_, _ = w.Submit(context.Background(), func(context.Context) error {
+		<-release
+		return nil
+	})
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	// Act
+	_, err := w.Submit(ctx, func(context.Context) error { return nil })
+	close(release)
+
+	// Assert
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected context.DeadlineExceeded while the pool is busy, got %v", err)
+	}
+}
+
+func TestWorkers_RecoversPanics(t *testing.T) {
+	// Arrange
+	w := New(WithConcurrency(1))
+	defer w.Shutdown(context.Background())
+
+	// Act
+	panicked, _ := w.Submit(context.Background(), func(context.Context) error { panic("kaboom") })
+	_, panicErr := panicked.Await(context.Background())
+	after, _ := w.Submit(context.Background(), func(context.Context) error { return nil })
+	_, afterErr := after.Await(context.Background())
+
+	// Assert
+	if panicErr == nil || !strings.Contains(panicErr.Error(), "kaboom") {
+		t.Errorf("expected an error describing the panic, got %v", panicErr)
+	}
+	if afterErr != nil {
+		t.Errorf("expected the worker to survive the panic, got %v", afterErr)
+	}
+}
+
+func TestWorkers_SubmitAfterShutdown(t *testing.T) {
+	// Arrange
+	w := New()
+	_ = w.Shutdown(context.Background())
+
+	// Act
+	f, err := w.Submit(context.Background(), func(context.Context) error { return nil })
+
+	// Assert
+	if f != nil || !errors.Is(err, ClosedError) {
+		t.Errorf("expected ClosedError, got %v, %v", f, err)
+	}
+}
+
+func TestWorkers_ForcedShutdown(t *testing.T) {
+	// Arrange
+	w := New(WithConcurrency(1), WithQueueDepth(1))
+	started := make(chan struct{})
+	running, _ := w.Submit(context.Background(), func(ctx context.Context) error {
+		close(started)
+		<-ctx.Done()
+		return ctx.Err()
+	})
+	<-started
+	queued, _ := w.Submit(context.Background(), func(context.Context) error { return nil })
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	// Act
+	err := w.Shutdown(ctx)
+
+	// Assert
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected context.DeadlineExceeded, got %v", err)
+	}
+	if _, err := running.Await(context.Background()); !errors.Is(err, context.Canceled) {
+		t.Errorf("expected the running task to be cancelled, got %v", err)
+	}
+	if _, err := queued.Await(context.Background()); !errors.Is(err, ClosedError) {
+		t.Errorf("expected the queued task to be aborted with ClosedError, got %v", err)
+	}
+}
+
+func TestWorkers_SubmitRacingShutdownAlwaysSettles(t *testing.T) {
+	for i := 0; i < 200; i++ {
+		// Arrange
+		w := New(WithConcurrency(1), WithQueueDepth(1))
+		futures := make(chan *future.Future[struct{}], 4)
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			for j := 0; j < cap(futures); j++ {
+				if f, err := w.Submit(context.Background(), func(context.Context) error { return nil }); err == nil {
+					futures <- f
+				}
+			}
+		}()
+
+		// Act
+		_ = w.Shutdown(context.Background())
+		<-done
+		close(futures)
+
+		// Assert
+		for f := range futures {
+			ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+			_, err := f.Await(ctx)
+			cancel()
+			if errors.Is(err, context.DeadlineExceeded) {
+				t.Fatalf("iteration %d: expected every accepted task to settle, got %v", i, err)
+			}
+		}
+	}
+}
@@ -0,0 +1,44 @@
+// Package ptr converts between values, pointers and optional.Option for
+// APIs, such as the AWS SDK and protobuf, that use pointers to express
+// optional fields.
+package ptr
+
+import (
+	"github.com/zodimo/go-zbase-std/optional"
+)
+
+// To returns a pointer to a copy of v, so literals and function results
+// can be assigned to pointer fields.
+//
+// Example:
+//
+//	input := &s3.GetObjectInput{Bucket: ptr.To("assets"), Key: ptr.To(key)}
+func To[T any](v T) *T {
+	return &v
+}
+
+// Deref returns the value p points to, or def if p is nil.
+func Deref[T any](p *T, def T) T {
+	if p == nil {
+		return def
+	}
+	return *p
+}
+
+// DerefOption returns the value p points to as Some, or None if p is nil.
+func DerefOption[T any](p *T) optional.Option[T] {
+	if p == nil {
+		return optional.None[T]()
+	}
+	return optional.Some(*p)
+}
+
+// FromOption returns a pointer to a copy of the value held by o, or nil if
+// o is None. It is the inverse of DerefOption.
+func FromOption[T any](o optional.Option[T]) *T {
+	v, ok := o.Value()
+	if !ok {
+		return nil
+	}
+	return &v
+}
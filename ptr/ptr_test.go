@@ -0,0 +1,66 @@
+package ptr
+
+import (
+	"testing"
+
+	"github.com/zodimo/go-zbase-std/optional"
+)
+
+func TestTo(t *testing.T) {
+	// Arrange
+	v := 42
+
+	// Act
+	p := To(v)
+	*p = 7
+
+	// Assert
+	if v != 42 {
+		t.Errorf("expected To to point to a copy, got original %d", v)
+	}
+}
+
+func TestDeref(t *testing.T) {
+	// Arrange
+	var nilPtr *string
+
+	// Act
+	set, unset := Deref(To("x"), "default"), Deref(nilPtr, "default")
+
+	// Assert
+	if set != "x" || unset != "default" {
+		t.Errorf("expected x and default, got %q and %q", set, unset)
+	}
+}
+
+func TestDerefOption(t *testing.T) {
+	// Arrange
+	var nilPtr *int
+
+	// Act
+	some, none := DerefOption(To(3)), DerefOption(nilPtr)
+
+	// Assert
+	if v, ok := some.Value(); !ok || v != 3 {
+		t.Errorf("expected Some(3), got %v, %v", v, ok)
+	}
+	if _, ok := none.Value(); ok {
+		t.Error("expected None for a nil pointer")
+	}
+}
+
+func TestFromOption(t *testing.T) {
+	// Arrange
+	some, none := optional.Some(5), optional.None[int]()
+
+	// Act
+	p, nilPtr := FromOption(some), FromOption(none)
+
+	// Assert
+	if p == nil || *p != 5 {
+		t.Errorf("expected a pointer to 5, got %v", p)
+	}
+	if nilPtr != nil {
+		t.Errorf("expected nil for None, got %v", *nilPtr)
+	}
+}
@@ -0,0 +1,35 @@
+package ratelimit
+
+import (
+	"time"
+
+	"github.com/zodimo/go-zbase-std/clock"
+)
+
+// Option configures a Limiter created by New.
+type Option func(*Limiter)
+
+// WithClock makes the limiter read time from the given clock instead of the
+// system clock.
+func WithClock(c clock.Clock) Option {
+	return func(l *Limiter) {
+		l.clock = c
+	}
+}
+
+// WithSweepInterval sets how often idle keys are collected. Sweeps run
+// during Allow and Wait, so an unused limiter does no background work.
+// Values below 1 disable collection.
+func WithSweepInterval(d time.Duration) Option {
+	return func(l *Limiter) {
+		l.sweepEvery = d
+	}
+}
+
+// WithOnEvict calls fn after an idle key has been collected. It runs in the
+// goroutine that triggered the sweep, outside the limiter's lock.
+func WithOnEvict(fn func(key string)) Option {
+	return func(l *Limiter) {
+		l.onEvict = fn
+	}
+}
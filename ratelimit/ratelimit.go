@@ -0,0 +1,206 @@
+// Package ratelimit provides a per-key token-bucket rate limiter, keyed by
+// strings like the mutex registry so tenants or resources can be throttled
+// independently.
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/zodimo/go-zbase-std/clock"
+)
+
+// DefaultSweepInterval is how often idle keys are collected unless
+// WithSweepInterval says otherwise.
+const DefaultSweepInterval = time.Minute
+
+// Limiter is a set of token buckets, one per key. Each bucket holds up to
+// burst tokens and refills at rate tokens per second; every allowed event
+// takes one token. Buckets are created on first use of a key and collected
+// once they are full again and nobody waits on them, which loses no state
+// because a full bucket behaves exactly like a new one. It is safe for
+// concurrent use.
+type Limiter struct {
+	mu        sync.Mutex
+	buckets   map[string]*bucket
+	nextSweep time.Time
+
+	rate       float64
+	burst      float64
+	clock      clock.Clock
+	sweepEvery time.Duration
+	onEvict    func(key string)
+}
+
+// bucket is the token bucket of a single key.
+type bucket struct {
+	tokens float64   // May be negative while Wait callers hold reservations.
+	last   time.Time // When tokens was last brought up to date.
+	refs   int       // Wait callers sleeping on a reservation.
+}
+
+// New creates a Limiter. It panics if rate is not positive or burst is
+// below 1.
+//
+// Parameters:
+//   - rate: The number of tokens added to each bucket per second.
+//   - burst: The capacity of each bucket, and so the largest burst of
+//     events allowed at once.
+//   - opts: Options configuring the clock and idle-key collection.
+//
+// Returns:
+//   - *Limiter: The new limiter, with every key starting at a full bucket.
+//
+// Example:
+//
+//	perTenant := ratelimit.New(10, 20)
+//	if !perTenant.Allow(tenantID) {
+//		return errTooManyRequests
+//	}
+func New(rate float64, burst int, opts ...Option) *Limiter {
+	if rate <= 0 {
+		panic("ratelimit: rate must be positive")
+	}
+	if burst < 1 {
+		panic("ratelimit: burst must be at least 1")
+	}
+	l := &Limiter{
+		buckets:    make(map[string]*bucket),
+		rate:       rate,
+		burst:      float64(burst),
+		clock:      clock.System(),
+		sweepEvery: DefaultSweepInterval,
+	}
+	for _, opt := range opts {
+		opt(l)
+	}
+	return l
+}
+
+// Allow reports whether an event for key may happen now, taking a token
+// from its bucket if so.
+func (l *Limiter) Allow(key string) bool {
+	var evicted []string
+	defer func() { l.notify(evicted) }()
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	now := l.clock.Now()
+	evicted = l.sweepLocked(now)
+	b := l.bucketLocked(key, now)
+	if b.tokens < 1 {
+		return false
+	}
+	b.tokens--
+	return true
+}
+
+// Wait blocks until an event for key may happen or ctx is done. Callers
+// are served in the order they call Wait: each reserves the next token and
+// sleeps until it has been refilled.
+//
+// Parameters:
+//   - ctx: Ends the wait early when done.
+//   - key: The key whose bucket to take a token from.
+//
+// Returns:
+//   - error: ctx.Err() if ctx is done before the token is available, in
+//     which case the reservation is given back; nil otherwise.
+func (l *Limiter) Wait(ctx context.Context, key string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	var evicted []string
+	l.mu.Lock()
+	now := l.clock.Now()
+	evicted = l.sweepLocked(now)
+	b := l.bucketLocked(key, now)
+	b.tokens--
+	if b.tokens >= 0 {
+		l.mu.Unlock()
+		l.notify(evicted)
+		return nil
+	}
+	delay := time.Duration(-b.tokens / l.rate * float64(time.Second))
+	b.refs++
+	l.mu.Unlock()
+	l.notify(evicted)
+
+	timer := l.clock.NewTimer(delay)
+	select {
+	case <-timer.C():
+		l.mu.Lock()
+		b.refs--
+		l.mu.Unlock()
+		return nil
+	case <-ctx.Done():
+		timer.Stop()
+		l.mu.Lock()
+		b.refs--
+		l.refillLocked(b, l.clock.Now())
+		b.tokens = min(b.tokens+1, l.burst)
+		l.mu.Unlock()
+		return ctx.Err()
+	}
+}
+
+// Len returns the number of keys with a bucket, including idle ones that
+// have not been collected yet.
+func (l *Limiter) Len() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return len(l.buckets)
+}
+
+// bucketLocked returns the up-to-date bucket for key, creating a full one
+// if needed. l.mu must be held.
+func (l *Limiter) bucketLocked(key string, now time.Time) *bucket {
+	b, ok := l.buckets[key]
+	if !ok {
+		b = &bucket{tokens: l.burst, last: now}
+		l.buckets[key] = b
+		return b
+	}
+	l.refillLocked(b, now)
+	return b
+}
+
+// refillLocked adds the tokens earned since the bucket was last updated.
+// l.mu must be held.
+func (l *Limiter) refillLocked(b *bucket, now time.Time) {
+	if elapsed := now.Sub(b.last); elapsed > 0 {
+		b.tokens = min(b.tokens+elapsed.Seconds()*l.rate, l.burst)
+		b.last = now
+	}
+}
+
+// sweepLocked removes idle buckets if a sweep is due and returns their
+// keys. l.mu must be held.
+func (l *Limiter) sweepLocked(now time.Time) []string {
+	if l.sweepEvery < 1 || now.Before(l.nextSweep) {
+		return nil
+	}
+	l.nextSweep = now.Add(l.sweepEvery)
+	var evicted []string
+	for key, b := range l.buckets {
+		if b.refs > 0 {
+			continue
+		}
+		l.refillLocked(b, now)
+		if b.tokens >= l.burst {
+			delete(l.buckets, key)
+			evicted = append(evicted, key)
+		}
+	}
+	return evicted
+}
+
+// notify runs the eviction callback for each collected key.
+func (l *Limiter) notify(evicted []string) {
+	if l.onEvict == nil {
+		return
+	}
+	for _, key := range evicted {
+		l.onEvict(key)
+	}
+}
@@ -0,0 +1,172 @@
+package ratelimit
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/zodimo/go-zbase-std/clock"
+)
+
+func newFakeLimiter(rate float64, burst int, opts ...Option) (*Limiter, *clock.FakeClock) {
+	fake := clock.NewFakeClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	return New(rate, burst, append([]Option{WithClock(fake)}, opts...)...), fake
+}
+
+func TestLimiter_AllowBurstThenRefill(t *testing.T) {
+	// Arrange
+	l, fake := newFakeLimiter(1, 3)
+
+	// Act
+	allowed := 0
+	for range 5 {
+		if l.Allow("tenant-a") {
+			allowed++
+		}
+	}
+	fake.Advance(time.Second)
+
+	// Assert
+	if allowed != 3 {
+		t.Errorf("expected a burst of 3, got %d", allowed)
+	}
+	if !l.Allow("tenant-a") {
+		t.Error("expected a token to be refilled after a second")
+	}
+	if l.Allow("tenant-a") {
+		t.Error("expected only one token to be refilled")
+	}
+}
+
+func TestLimiter_KeysAreIndependent(t *testing.T) {
+	// Arrange
+	l, _ := newFakeLimiter(1, 1)
+	l.Allow("tenant-a")
+
+	// Act
+	allowed := l.Allow("tenant-b")
+
+	// Assert
+	if !allowed {
+		t.Error("expected another key to have its own bucket")
+	}
+	if l.Len() != 2 {
+		t.Errorf("expected 2 keys, got %d", l.Len())
+	}
+}
+
+func TestLimiter_WaitSleepsForToken(t *testing.T) {
+	// Arrange
+	l, fake := newFakeLimiter(2, 1)
+	_ = l.Wait(context.Background(), "tenant-a")
+	done := make(chan error)
+
+	// Act
+	go func() { done <- l.Wait(context.Background(), "tenant-a") }()
+	for fake.Pending() == 0 {
+		time.Sleep(time.Millisecond)
+	}
+	fake.Advance(500 * time.Millisecond)
+
+	// Assert
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Errorf("expected no error, got %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("expected Wait to return once the token was refilled")
+	}
+}
+
+func TestLimiter_WaitCancelledGivesTokenBack(t *testing.T) {
+	// Arrange
+	l, fake := newFakeLimiter(1, 1)
+	l.Allow("tenant-a")
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() { done <- l.Wait(ctx, "tenant-a") }()
+	for fake.Pending() == 0 {
+		time.Sleep(time.Millisecond)
+	}
+
+	// Act
+	cancel()
+	err := <-done
+	fake.Advance(time.Second)
+
+	// Assert
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context.Canceled, got %v", err)
+	}
+	if !l.Allow("tenant-a") {
+		t.Error("expected the cancelled reservation to be given back")
+	}
+}
+
+func TestLimiter_CollectsIdleKeys(t *testing.T) {
+	// Arrange
+	var evicted []string
+	l, fake := newFakeLimiter(1.0/60, 2,
+		WithSweepInterval(time.Minute),
+		WithOnEvict(func(key string) { evicted = append(evicted, key) }),
+	)
+	l.Allow("idle")
+	fake.Advance(30 * time.Second)
+	l.Allow("busy")
+	l.Allow("busy")
+
+	// Act
+	fake.Advance(30 * time.Second)
+	l.Allow("busy")
+
+	// Assert
+	if len(evicted) != 1 || evicted[0] != "idle" {
+		t.Errorf("expected only the refilled key to be collected, got %v", evicted)
+	}
+	if l.Len() != 1 {
+		t.Errorf("expected 1 key left, got %d", l.Len())
+	}
+}
+
+func TestLimiter_KeepsKeysWithWaiters(t *testing.T) {
+	// Arrange
+	l, fake := newFakeLimiter(0.001, 1, WithSweepInterval(time.Second))
+	l.Allow("tenant-a")
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() { _ = l.Wait(ctx, "tenant-a") }()
+	for fake.Pending() == 0 {
+		time.Sleep(time.Millisecond)
+	}
+
+	// Act
+	fake.Advance(time.Hour)
+	l.Allow("tenant-b")
+
+	// Assert
+	if l.Len() != 2 {
+		t.Errorf("expected the key with a waiter to be kept, got %d keys", l.Len())
+	}
+}
+
+func TestNew_PanicsOnInvalidArguments(t *testing.T) {
+	for _, tc := range []struct {
+		name  string
+		rate  float64
+		burst int
+	}{
+		{"zero rate", 0, 1},
+		{"zero burst", 1, 0},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("expected New to panic")
+				}
+			}()
+			New(tc.rate, tc.burst)
+		})
+	}
+}
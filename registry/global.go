@@ -0,0 +1,35 @@
+package registry
+
+import (
+	"github.com/zodimo/go-zbase-std/syncx"
+)
+
+// Global holds a process-wide Registry that can be swapped atomically,
+// like the mutex package's GetMutexRegistry and SetMutexRegistry, so tests
+// can install a fresh registry without racing readers.
+type Global[T any] struct {
+	current *syncx.Replaceable[*Registry[T]]
+}
+
+// NewGlobal creates a Global holding an empty Registry.
+//
+// Example:
+//
+//	var Codecs = registry.NewGlobal[Codec]()
+//
+//	func init() {
+//		must.Do(Codecs.Get().Register("json", jsonCodec{}))
+//	}
+func NewGlobal[T any]() *Global[T] {
+	return &Global[T]{current: syncx.NewReplaceable(New[T]())}
+}
+
+// Get returns the current Registry.
+func (g *Global[T]) Get() *Registry[T] {
+	return g.current.Load()
+}
+
+// Set installs r as the current Registry and returns the one it replaced.
+func (g *Global[T]) Set(r *Registry[T]) *Registry[T] {
+	return g.current.Replace(r)
+}
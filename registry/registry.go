@@ -0,0 +1,96 @@
+// Package registry provides a concurrency-safe registry of values by key,
+// generalizing the mutex package's registry so that subsystems such as
+// codecs, handlers and backends can share the pattern instead of copying
+// it.
+package registry
+
+import (
+	"iter"
+
+	"github.com/zodimo/go-zbase-std/complete"
+	"github.com/zodimo/go-zbase-std/errcode"
+	"github.com/zodimo/go-zbase-std/optional"
+	"github.com/zodimo/go-zbase-std/syncx"
+)
+
+// AlreadyRegisteredError is returned by Register when the key is taken.
+var AlreadyRegisteredError = errcode.New(errcode.Conflict, "registry: key already registered")
+
+// Registry maps string keys to values of type T. Values implementing
+// complete.Complete are checked for completeness when registered. It is
+// safe for concurrent use. The zero value is an empty registry ready to
+// use.
+type Registry[T any] struct {
+	entries syncx.Map[string, T]
+}
+
+// New creates an empty Registry.
+//
+// Example:
+//
+//	codecs := registry.New[Codec]()
+//	if err := codecs.Register("json", jsonCodec{}); err != nil {
+//		return err
+//	}
+func New[T any]() *Registry[T] {
+	return &Registry[T]{}
+}
+
+// Register stores value under key.
+//
+// Parameters:
+//   - key: The unique key identifying the value.
+//   - value: The value to register.
+//
+// Returns:
+//   - error: A *complete.IncompleteTypeError if value implements
+//     complete.Complete but is incomplete; AlreadyRegisteredError if key is
+//     taken; nil otherwise.
+func (r *Registry[T]) Register(key string, value T) error {
+	if c, ok := any(value).(complete.Complete); ok {
+		if err := complete.ValidateCompleteness(c); err != nil {
+			return err
+		}
+	}
+	if _, loaded := r.entries.LoadOrStore(key, value); loaded {
+		return AlreadyRegisteredError
+	}
+	return nil
+}
+
+// Get returns the value registered under key, or None if there is none.
+func (r *Registry[T]) Get(key string) optional.Option[T] {
+	return r.entries.Load(key)
+}
+
+// Has reports whether a value is registered under key.
+func (r *Registry[T]) Has(key string) bool {
+	loaded := r.entries.Load(key)
+	_, ok := loaded.Value()
+	return ok
+}
+
+// Unregister removes the value registered under key, reporting whether
+// there was one.
+func (r *Registry[T]) Unregister(key string) bool {
+	removed := r.entries.LoadAndDelete(key)
+	_, ok := removed.Value()
+	return ok
+}
+
+// Range calls fn for every registered value, in unspecified order, until
+// fn returns false.
+func (r *Registry[T]) Range(fn func(key string, value T) bool) {
+	r.entries.Range(fn)
+}
+
+// All returns a sequence over the registered keys and values, in
+// unspecified order.
+func (r *Registry[T]) All() iter.Seq2[string, T] {
+	return r.entries.All()
+}
+
+// Len returns the number of registered values.
+func (r *Registry[T]) Len() int {
+	return r.entries.Len()
+}
@@ -0,0 +1,122 @@
+package registry
+
+import (
+	"errors"
+	"maps"
+	"testing"
+
+	"github.com/zodimo/go-zbase-std/complete"
+)
+
+type codec struct {
+	name string
+}
+
+func (c codec) Complete() bool { return c.name != "" }
+
+func TestRegistry_RegisterAndGet(t *testing.T) {
+	// Arrange
+	r := New[codec]()
+
+	// Act
+	err := r.Register("json", codec{name: "json"})
+
+	// Assert
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	got := r.Get("json")
+	if c, ok := got.Value(); !ok || c.name != "json" {
+		t.Errorf("expected the json codec, got %v, %v", c, ok)
+	}
+	if !r.Has("json") || r.Has("xml") || r.Len() != 1 {
+		t.Errorf("expected only json to be registered, got %d values", r.Len())
+	}
+}
+
+func TestRegistry_RegisterDuplicate(t *testing.T) {
+	// Arrange
+	r := New[codec]()
+	_ = r.Register("json", codec{name: "json"})
+
+	// Act
+	err := r.Register("json", codec{name: "other"})
+
+	// Assert
+	if !errors.Is(err, AlreadyRegisteredError) {
+		t.Errorf("expected AlreadyRegisteredError, got %v", err)
+	}
+	got := r.Get("json")
+	if c, _ := got.Value(); c.name != "json" {
+		t.Errorf("expected the first value to be kept, got %v", c)
+	}
+}
+
+func TestRegistry_RegisterIncomplete(t *testing.T) {
+	// Arrange
+	r := New[codec]()
+
+	// Act
+	err := r.Register("empty", codec{})
+
+	// Assert
+	var incomplete *complete.IncompleteTypeError
+	if !errors.As(err, &incomplete) {
+		t.Errorf("expected an IncompleteTypeError, got %v", err)
+	}
+	if r.Has("empty") {
+		t.Error("expected the incomplete value not to be registered")
+	}
+}
+
+func TestRegistry_Unregister(t *testing.T) {
+	// Arrange
+	var r Registry[int]
+	_ = r.Register("a", 1)
+
+	// Act
+	removed, removedAgain := r.Unregister("a"), r.Unregister("a")
+
+	// Assert
+	if !removed || removedAgain || r.Len() != 0 {
+		t.Errorf("expected true then false and an empty registry, got %v, %v and %d", removed, removedAgain, r.Len())
+	}
+}
+
+func TestRegistry_RangeAndAll(t *testing.T) {
+	// Arrange
+	var r Registry[int]
+	_ = r.Register("a", 1)
+	_ = r.Register("b", 2)
+	visited := 0
+
+	// Act
+	r.Range(func(string, int) bool { visited++; return false })
+	all := maps.Collect(r.All())
+
+	// Assert
+	if visited != 1 {
+		t.Errorf("expected Range to stop after the first value, got %d", visited)
+	}
+	if want := map[string]int{"a": 1, "b": 2}; !maps.Equal(all, want) {
+		t.Errorf("expected %v, got %v", want, all)
+	}
+}
+
+func TestGlobal(t *testing.T) {
+	// Arrange
+	g := NewGlobal[int]()
+	_ = g.Get().Register("a", 1)
+	fresh := New[int]()
+
+	// Act
+	previous := g.Set(fresh)
+
+	// Assert
+	if !previous.Has("a") {
+		t.Error("expected Set to return the replaced registry")
+	}
+	if g.Get() != fresh || g.Get().Has("a") {
+		t.Error("expected the fresh registry to be installed")
+	}
+}
@@ -0,0 +1,53 @@
+package result
+
+// Collect gathers the values of a slice of Results into a single Result.
+// The first failed Result, in slice order, determines the error.
+//
+// Parameters:
+//   - results: The Results to collect.
+//
+// Returns:
+//   - Result[[]T]: Ok with every value in order, or the first error.
+//
+// Example:
+//
+//	ports := Collect([]Result[int]{parsePort(a), parsePort(b)})
+func Collect[T any](results []Result[T]) Result[[]T] {
+	values := make([]T, 0, len(results))
+	for _, r := range results {
+		if r.err != nil {
+			return Err[[]T](r.err)
+		}
+		values = append(values, r.value)
+	}
+	return Ok(values)
+}
+
+// Partition splits a slice of Results into the values of the successful
+// ones and the errors of the failed ones, each in slice order.
+//
+// Parameters:
+//   - results: The Results to partition.
+//
+// Returns:
+//   - []T: The values of the successful Results.
+//   - []error: The errors of the failed Results.
+//
+// Example:
+//
+//	saved, failures := Partition(outcomes)
+//	if err := errors.Join(failures...); err != nil {
+//		log.Printf("%d of %d saves failed: %v", len(failures), len(outcomes), err)
+//	}
+func Partition[T any](results []Result[T]) ([]T, []error) {
+	var values []T
+	var errs []error
+	for _, r := range results {
+		if r.err != nil {
+			errs = append(errs, r.err)
+			continue
+		}
+		values = append(values, r.value)
+	}
+	return values, errs
+}
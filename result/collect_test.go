@@ -0,0 +1,63 @@
+package result
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestCollect_AllOk(t *testing.T) {
+	// Act
+	res := Collect([]Result[int]{Ok(1), Ok(2), Ok(3)})
+
+	// Assert
+	values, err := res.Value()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if !reflect.DeepEqual(values, []int{1, 2, 3}) {
+		t.Errorf("expected [1 2 3], got %v", values)
+	}
+}
+
+func TestCollect_FirstErrorWins(t *testing.T) {
+	// Arrange
+	first := errors.New("first")
+	second := errors.New("second")
+
+	// Act
+	res := Collect([]Result[int]{Ok(1), Err[int](first), Err[int](second)})
+
+	// Assert
+	if _, err := res.Value(); !errors.Is(err, first) {
+		t.Errorf("expected the first error, got %v", err)
+	}
+}
+
+func TestCollect_Empty(t *testing.T) {
+	// Act
+	res := Collect[int](nil)
+
+	// Assert
+	values, err := res.Value()
+	if err != nil || values == nil || len(values) != 0 {
+		t.Errorf("expected Ok with an empty slice, got %v, %v", values, err)
+	}
+}
+
+func TestPartition(t *testing.T) {
+	// Arrange
+	first := errors.New("first")
+	second := errors.New("second")
+
+	// Act
+	values, errs := Partition([]Result[string]{Ok("a"), Err[string](first), Ok("b"), Err[string](second)})
+
+	// Assert
+	if !reflect.DeepEqual(values, []string{"a", "b"}) {
+		t.Errorf("expected [a b], got %v", values)
+	}
+	if len(errs) != 2 || errs[0] != first || errs[1] != second {
+		t.Errorf("expected [first second], got %v", errs)
+	}
+}
@@ -0,0 +1,86 @@
+package result
+
+// Map applies fn to the value of a successful Result. A failed Result is
+// passed through with its error.
+//
+// Parameters:
+//   - r: The Result to transform.
+//   - fn: The function applied to the contained value.
+//
+// Returns:
+//   - Result[U]: Ok(fn(value)), or the error of r.
+//
+// Example:
+//
+//	length := Map(readName(), func(name string) int { return len(name) })
+func Map[T, U any](r Result[T], fn func(T) U) Result[U] {
+	if r.err != nil {
+		return Err[U](r.err)
+	}
+	return Ok(fn(r.value))
+}
+
+// MapErr applies fn to the error of a failed Result, for example to wrap
+// it with context. A successful Result is passed through unchanged.
+//
+// Parameters:
+//   - r: The Result whose error is transformed.
+//   - fn: The function applied to the contained error.
+//
+// Returns:
+//   - Result[T]: r, or Err(fn(err)).
+//
+// Example:
+//
+//	res = MapErr(res, func(err error) error { return fmt.Errorf("load config: %w", err) })
+func MapErr[T any](r Result[T], fn func(error) error) Result[T] {
+	if r.err == nil {
+		return r
+	}
+	return Err[T](fn(r.err))
+}
+
+// AndThen chains a fallible step onto a successful Result. A failed Result
+// is passed through without calling fn.
+//
+// Parameters:
+//   - r: The Result to continue from.
+//   - fn: The next step, called with the contained value.
+//
+// Returns:
+//   - Result[U]: The result of fn, or the error of r.
+//
+// Example:
+//
+//	port := AndThen(parsePort(s), validatePort)
+func AndThen[T, U any](r Result[T], fn func(T) Result[U]) Result[U] {
+	if r.err != nil {
+		return Err[U](r.err)
+	}
+	return fn(r.value)
+}
+
+// FlatMap is an alias for AndThen.
+func FlatMap[T, U any](r Result[T], fn func(T) Result[U]) Result[U] {
+	return AndThen(r, fn)
+}
+
+// OrElse recovers from a failed Result by calling fn with its error. A
+// successful Result is passed through without calling fn.
+//
+// Parameters:
+//   - r: The Result to recover.
+//   - fn: The recovery step, called with the contained error.
+//
+// Returns:
+//   - Result[T]: r, or the result of fn.
+//
+// Example:
+//
+//	cfg := OrElse(loadConfig(path), func(error) Result[Config] { return Ok(defaultConfig) })
+func OrElse[T any](r Result[T], fn func(error) Result[T]) Result[T] {
+	if r.err == nil {
+		return r
+	}
+	return fn(r.err)
+}
@@ -0,0 +1,114 @@
+package result
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"testing"
+)
+
+func TestMap_Ok(t *testing.T) {
+	// Act
+	res := Map(Ok("hello"), func(s string) int { return len(s) })
+
+	// Assert
+	if value, err := res.Value(); err != nil || value != 5 {
+		t.Errorf("expected Ok(5), got %v, %v", value, err)
+	}
+}
+
+func TestMap_ErrSkipsFn(t *testing.T) {
+	// Arrange
+	cause := errors.New("boom")
+	called := false
+
+	// Act
+	res := Map(Err[string](cause), func(s string) int { called = true; return len(s) })
+
+	// Assert
+	if _, err := res.Value(); !errors.Is(err, cause) {
+		t.Errorf("expected error %v, got %v", cause, err)
+	}
+	if called {
+		t.Error("expected fn not to be called for a failed result")
+	}
+}
+
+func TestMapErr(t *testing.T) {
+	// Arrange
+	cause := errors.New("boom")
+	wrap := func(err error) error { return fmt.Errorf("load: %w", err) }
+
+	// Act
+	failed := MapErr(Err[int](cause), wrap)
+	ok := MapErr(Ok(3), wrap)
+
+	// Assert
+	if _, err := failed.Value(); !errors.Is(err, cause) || err.Error() != "load: boom" {
+		t.Errorf("expected wrapped error, got %v", err)
+	}
+	if value, err := ok.Value(); err != nil || value != 3 {
+		t.Errorf("expected Ok(3) to pass through, got %v, %v", value, err)
+	}
+}
+
+func TestAndThen_Pipeline(t *testing.T) {
+	// Arrange
+	parse := func(s string) Result[int] {
+		n, err := strconv.Atoi(s)
+		if err != nil {
+			return Err[int](err)
+		}
+		return Ok(n)
+	}
+	validate := func(n int) Result[int] {
+		if n <= 0 {
+			return Err[int](errors.New("must be positive"))
+		}
+		return Ok(n)
+	}
+
+	// Act
+	valid := AndThen(parse("8"), validate)
+	invalid := AndThen(parse("-1"), validate)
+	unparsable := AndThen(parse("x"), validate)
+
+	// Assert
+	if value, err := valid.Value(); err != nil || value != 8 {
+		t.Errorf("expected Ok(8), got %v, %v", value, err)
+	}
+	if _, err := invalid.Value(); err == nil || err.Error() != "must be positive" {
+		t.Errorf("expected validation error, got %v", err)
+	}
+	var numErr *strconv.NumError
+	if _, err := unparsable.Value(); !errors.As(err, &numErr) {
+		t.Errorf("expected parse error, got %v", err)
+	}
+}
+
+func TestFlatMap(t *testing.T) {
+	// Act
+	res := FlatMap(Ok(2), func(n int) Result[string] { return Ok(strconv.Itoa(n * 2)) })
+
+	// Assert
+	if value, err := res.Value(); err != nil || value != "4" {
+		t.Errorf("expected Ok(\"4\"), got %v, %v", value, err)
+	}
+}
+
+func TestOrElse(t *testing.T) {
+	// Arrange
+	fallback := func(error) Result[int] { return Ok(-1) }
+
+	// Act
+	recovered := OrElse(Err[int](errors.New("boom")), fallback)
+	ok := OrElse(Ok(1), fallback)
+
+	// Assert
+	if value, err := recovered.Value(); err != nil || value != -1 {
+		t.Errorf("expected recovery to Ok(-1), got %v, %v", value, err)
+	}
+	if value, err := ok.Value(); err != nil || value != 1 {
+		t.Errorf("expected Ok(1) to pass through, got %v, %v", value, err)
+	}
+}
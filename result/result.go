@@ -0,0 +1,125 @@
+// Package result provides a generic Result type holding either a value or
+// an error, for code that would otherwise carry an Option and an error side
+// by side.
+package result
+
+// Result represents the outcome of a fallible operation: either a value of
+// type T or an error.
+type Result[T any] struct {
+	value T     // The value of type T, set when err is nil.
+	err   error // The error, or nil for a successful result.
+}
+
+// Ok initializes a successful Result holding the given value.
+//
+// Example:
+//
+//	res := Ok(42)
+func Ok[T any](value T) Result[T] {
+	return Result[T]{value: value}
+}
+
+// Err initializes a failed Result holding the given error. A nil error
+// yields a successful Result holding the zero value of T.
+//
+// Example:
+//
+//	res := Err[int](errors.New("not found"))
+func Err[T any](err error) Result[T] {
+	return Result[T]{err: err}
+}
+
+// Value retrieves the wrapped value and error from the Result.
+//
+// Returns:
+//   - T: The contained value, or the zero value of T on failure.
+//   - error: The contained error, or nil on success.
+//
+// Example:
+//
+//	value, err := res.Value()
+func (r Result[T]) Value() (T, error) {
+	return r.value, r.err
+}
+
+// IsOk reports whether the Result holds a value rather than an error.
+func (r Result[T]) IsOk() bool {
+	return r.err == nil
+}
+
+// UnwrapOr returns the contained value, or fallback if the Result holds an
+// error.
+//
+// Parameters:
+//   - fallback: The value returned for a failed Result.
+//
+// Returns:
+//   - T: The contained value or fallback.
+//
+// Example:
+//
+//	port := parsePort(s).UnwrapOr(8080)
+func (r Result[T]) UnwrapOr(fallback T) T {
+	if r.err != nil {
+		return fallback
+	}
+	return r.value
+}
+
+// From converts an idiomatic (value, error) pair into a Result, so that
+// standard Go APIs can feed Result pipelines directly.
+//
+// Parameters:
+//   - value: The value returned by the call.
+//   - err: The error returned by the call.
+//
+// Returns:
+//   - Result[T]: Err(err) if err is non-nil, otherwise Ok(value).
+//
+// Example:
+//
+//	port := From(strconv.Atoi(s))
+func From[T any](value T, err error) Result[T] {
+	if err != nil {
+		return Err[T](err)
+	}
+	return Ok(value)
+}
+
+// Tuple converts the Result back into an idiomatic (value, error) pair for
+// returning from functions with a standard Go signature.
+//
+// Returns:
+//   - T: The contained value, or the zero value of T on failure.
+//   - error: The contained error, or nil on success.
+//
+// Example:
+//
+//	return res.Tuple()
+func (r Result[T]) Tuple() (T, error) {
+	if r.err != nil {
+		var zero T
+		return zero, r.err
+	}
+	return r.value, nil
+}
+
+// Must returns the value of a successful Result and panics with the error
+// of a failed one. It is intended for tests and for initialization where a
+// failure is a programming error.
+//
+// Parameters:
+//   - r: The Result to unwrap.
+//
+// Returns:
+//   - T: The contained value.
+//
+// Example:
+//
+//	var defaultURL = Must(From(url.Parse("https://example.com")))
+func Must[T any](r Result[T]) T {
+	if r.err != nil {
+		panic(r.err)
+	}
+	return r.value
+}
@@ -0,0 +1,147 @@
+package result
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestOk(t *testing.T) {
+	// Act
+	res := Ok(42)
+
+	// Assert
+	value, err := res.Value()
+	if err != nil {
+		t.Errorf("expected no error, got %v", err)
+	}
+	if value != 42 {
+		t.Errorf("expected value 42, got %v", value)
+	}
+	if !res.IsOk() {
+		t.Error("expected IsOk to be true for Ok")
+	}
+}
+
+func TestErr(t *testing.T) {
+	// Arrange
+	cause := errors.New("boom")
+
+	// Act
+	res := Err[string](cause)
+
+	// Assert
+	value, err := res.Value()
+	if !errors.Is(err, cause) {
+		t.Errorf("expected error %v, got %v", cause, err)
+	}
+	if value != "" {
+		t.Errorf("expected a zero value, got %q", value)
+	}
+	if res.IsOk() {
+		t.Error("expected IsOk to be false for Err")
+	}
+}
+
+func TestErr_NilError(t *testing.T) {
+	// Act
+	res := Err[int](nil)
+
+	// Assert
+	if !res.IsOk() {
+		t.Error("expected Err(nil) to be a successful result")
+	}
+}
+
+func TestResult_UnwrapOr(t *testing.T) {
+	// Arrange
+	ok := Ok(1)
+	failed := Err[int](errors.New("boom"))
+
+	// Act
+	okValue := ok.UnwrapOr(7)
+	failedValue := failed.UnwrapOr(7)
+
+	// Assert
+	if okValue != 1 {
+		t.Errorf("expected UnwrapOr to return the value 1, got %d", okValue)
+	}
+	if failedValue != 7 {
+		t.Errorf("expected UnwrapOr to return the fallback 7, got %d", failedValue)
+	}
+}
+
+func TestResult_ZeroValueIsOk(t *testing.T) {
+	// Arrange
+	var res Result[int]
+
+	// Act
+	value, err := res.Value()
+
+	// Assert
+	if err != nil || value != 0 {
+		t.Errorf("expected zero Result to be Ok(0), got %v, %v", value, err)
+	}
+}
+
+func TestFrom(t *testing.T) {
+	// Arrange
+	cause := errors.New("boom")
+
+	// Act
+	ok := From(5, nil)
+	failed := From(5, cause)
+
+	// Assert
+	if value, err := ok.Value(); err != nil || value != 5 {
+		t.Errorf("expected Ok(5), got %v, %v", value, err)
+	}
+	value, err := failed.Value()
+	if !errors.Is(err, cause) {
+		t.Errorf("expected error %v, got %v", cause, err)
+	}
+	if value != 0 {
+		t.Errorf("expected the value to be dropped alongside an error, got %v", value)
+	}
+}
+
+func TestResult_Tuple(t *testing.T) {
+	// Arrange
+	cause := errors.New("boom")
+
+	// Act
+	okValue, okErr := Ok("x").Tuple()
+	failedValue, failedErr := Err[string](cause).Tuple()
+
+	// Assert
+	if okValue != "x" || okErr != nil {
+		t.Errorf("expected (x, nil), got (%q, %v)", okValue, okErr)
+	}
+	if failedValue != "" || !errors.Is(failedErr, cause) {
+		t.Errorf("expected (\"\", %v), got (%q, %v)", cause, failedValue, failedErr)
+	}
+}
+
+func TestMust_Ok(t *testing.T) {
+	// Act
+	value := Must(Ok(9))
+
+	// Assert
+	if value != 9 {
+		t.Errorf("expected 9, got %v", value)
+	}
+}
+
+func TestMust_ErrPanics(t *testing.T) {
+	// Arrange
+	cause := errors.New("boom")
+	defer func() {
+		// Assert
+		if r := recover(); r != cause {
+			t.Errorf("expected panic with %v, got %v", cause, r)
+		}
+	}()
+
+	// Act
+	Must(Err[int](cause))
+	t.Error("expected Must to panic")
+}
@@ -0,0 +1,148 @@
+// Package retry runs fallible operations repeatedly according to a Policy,
+// replacing hand-written retry loops.
+package retry
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// Backoff produces the delays between the attempts of a single Do call. It
+// has the same shape as mutex.Strategy, so lock strategies can be reused;
+// the backoff package provides common implementations.
+type Backoff interface {
+	// Next returns the delay before the next attempt, or false if no
+	// further attempts should be made.
+	Next() (time.Duration, bool)
+}
+
+// Policy describes how an operation is retried. The zero Policy retries
+// every error immediately until the context is done.
+type Policy struct {
+	// MaxAttempts bounds the number of attempts, including the first. Zero
+	// means no bound.
+	MaxAttempts int
+
+	// Backoff creates the delays for one Do call. It is called once per
+	// call, as Backoff implementations are stateful. Nil means retrying
+	// without delay.
+	Backoff func() Backoff
+
+	// Retryable reports whether an attempt that failed with err should be
+	// retried. Nil means every error is retried.
+	Retryable func(err error) bool
+
+	// AttemptTimeout bounds each attempt through its context. Zero means
+	// attempts are only bounded by the context passed to Do.
+	AttemptTimeout time.Duration
+}
+
+// ExhaustedError is returned by Do and DoValue when every attempt allowed by
+// the Policy failed. It wraps the error of the last attempt.
+type ExhaustedError struct {
+	// Attempts is the number of attempts made.
+	Attempts int
+
+	// Err is the error of the last attempt.
+	Err error
+}
+
+func (e *ExhaustedError) Error() string {
+	return fmt.Sprintf("retry: %d attempts failed: %v", e.Attempts, e.Err)
+}
+
+// Unwrap returns the error of the last attempt.
+func (e *ExhaustedError) Unwrap() error {
+	return e.Err
+}
+
+// Do calls fn until it succeeds or policy gives up.
+//
+// Parameters:
+//   - ctx: The context bounding all attempts and the delays between them.
+//   - policy: The Policy deciding whether and when to retry.
+//   - fn: The operation, called with the context of the attempt.
+//
+// Returns:
+//   - error: Nil once fn succeeds; the error of fn if it is not retryable;
+//     an *ExhaustedError if the attempts ran out; or ctx's error, wrapping
+//     the last error, if ctx is done first.
+//
+// Example:
+//
+//	err := retry.Do(ctx, retry.Policy{MaxAttempts: 3}, func(ctx context.Context) error {
+//		return client.Ping(ctx)
+//	})
+func Do(ctx context.Context, policy Policy, fn func(context.Context) error) error {
+	_, err := DoValue(ctx, policy, func(ctx context.Context) (struct{}, error) {
+		return struct{}{}, fn(ctx)
+	})
+	return err
+}
+
+// DoValue calls fn until it succeeds or policy gives up, returning the
+// value of the successful attempt. Errors are reported as by Do.
+//
+// Parameters:
+//   - ctx: The context bounding all attempts and the delays between them.
+//   - policy: The Policy deciding whether and when to retry.
+//   - fn: The operation, called with the context of the attempt.
+//
+// Returns:
+//   - T: The value of the successful attempt, or the zero value of T.
+//   - error: As described for Do.
+//
+// Example:
+//
+//	user, err := retry.DoValue(ctx, policy, func(ctx context.Context) (User, error) {
+//		return client.GetUser(ctx, id)
+//	})
+func DoValue[T any](ctx context.Context, policy Policy, fn func(context.Context) (T, error)) (T, error) {
+	var zero T
+	var backoff Backoff
+	if policy.Backoff != nil {
+		backoff = policy.Backoff()
+	}
+	for attempt := 1; ; attempt++ {
+		value, err := runAttempt(ctx, policy.AttemptTimeout, fn)
+		if err == nil {
+			return value, nil
+		}
+		if ctx.Err() != nil {
+			return zero, fmt.Errorf("%w (last attempt: %w)", ctx.Err(), err)
+		}
+		if policy.Retryable != nil && !policy.Retryable(err) {
+			return zero, err
+		}
+		if policy.MaxAttempts > 0 && attempt >= policy.MaxAttempts {
+			return zero, &ExhaustedError{Attempts: attempt, Err: err}
+		}
+		var delay time.Duration
+		if backoff != nil {
+			var ok bool
+			if delay, ok = backoff.Next(); !ok {
+				return zero, &ExhaustedError{Attempts: attempt, Err: err}
+			}
+		}
+		if delay > 0 {
+			timer := time.NewTimer(delay)
+			select {
+			case <-timer.C:
+			case <-ctx.Done():
+				timer.Stop()
+				return zero, fmt.Errorf("%w (last attempt: %w)", ctx.Err(), err)
+			}
+		}
+	}
+}
+
+// runAttempt calls fn once, bounding it by timeout if timeout is positive.
+func runAttempt[T any](ctx context.Context, timeout time.Duration, fn func(context.Context) (T, error)) (T, error) {
+	if timeout <= 0 {
+		return fn(ctx)
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	return fn(ctx)
+}
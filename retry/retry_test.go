@@ -0,0 +1,183 @@
+package retry
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// fixedBackoff yields the same delay a limited number of times.
+type fixedBackoff struct {
+	delay     time.Duration
+	remaining int
+}
+
+func (b *fixedBackoff) Next() (time.Duration, bool) {
+	if b.remaining == 0 {
+		return 0, false
+	}
+	b.remaining--
+	return b.delay, true
+}
+
+// failTimes returns an operation failing with err for the first n calls,
+// counting every call in calls.
+func failTimes(n int, err error, calls *int) func(context.Context) error {
+	return func(context.Context) error {
+		*calls++
+		if *calls <= n {
+			return err
+		}
+		return nil
+	}
+}
+
+func TestDo_SucceedsAfterRetries(t *testing.T) {
+	// Arrange
+	calls := 0
+
+	// Act
+	err := Do(context.Background(), Policy{MaxAttempts: 5}, failTimes(2, errors.New("flaky"), &calls))
+
+	// Assert
+	if err != nil {
+		t.Errorf("expected success, got %v", err)
+	}
+	if calls != 3 {
+		t.Errorf("expected 3 attempts, got %d", calls)
+	}
+}
+
+func TestDo_MaxAttempts(t *testing.T) {
+	// Arrange
+	cause := errors.New("down")
+	calls := 0
+
+	// Act
+	err := Do(context.Background(), Policy{MaxAttempts: 3}, failTimes(10, cause, &calls))
+
+	// Assert
+	var exhausted *ExhaustedError
+	if !errors.As(err, &exhausted) {
+		t.Fatalf("expected *ExhaustedError, got %T", err)
+	}
+	if exhausted.Attempts != 3 || calls != 3 {
+		t.Errorf("expected 3 attempts, got %d (called %d times)", exhausted.Attempts, calls)
+	}
+	if !errors.Is(err, cause) {
+		t.Errorf("expected error to wrap %v, got %v", cause, err)
+	}
+}
+
+func TestDo_NotRetryable(t *testing.T) {
+	// Arrange
+	permanent := errors.New("bad request")
+	calls := 0
+	policy := Policy{
+		MaxAttempts: 5,
+		Retryable:   func(err error) bool { return !errors.Is(err, permanent) },
+	}
+
+	// Act
+	err := Do(context.Background(), policy, failTimes(10, permanent, &calls))
+
+	// Assert
+	if err != permanent {
+		t.Errorf("expected the non-retryable error itself, got %v", err)
+	}
+	if calls != 1 {
+		t.Errorf("expected a single attempt, got %d", calls)
+	}
+}
+
+func TestDo_BackoffExhausted(t *testing.T) {
+	// Arrange
+	calls := 0
+	policy := Policy{Backoff: func() Backoff { return &fixedBackoff{delay: time.Millisecond, remaining: 2} }}
+
+	// Act
+	err := Do(context.Background(), policy, failTimes(10, errors.New("down"), &calls))
+
+	// Assert
+	var exhausted *ExhaustedError
+	if !errors.As(err, &exhausted) || exhausted.Attempts != 3 {
+		t.Errorf("expected exhaustion after 3 attempts, got %v", err)
+	}
+}
+
+func TestDo_BackoffPerCall(t *testing.T) {
+	// Arrange
+	policy := Policy{Backoff: func() Backoff { return &fixedBackoff{remaining: 1} }}
+	calls := 0
+
+	// Act
+	first := Do(context.Background(), policy, failTimes(1, errors.New("flaky"), &calls))
+	calls = 0
+	second := Do(context.Background(), policy, failTimes(1, errors.New("flaky"), &calls))
+
+	// Assert
+	if first != nil || second != nil {
+		t.Errorf("expected each call to get a fresh backoff, got %v and %v", first, second)
+	}
+}
+
+func TestDo_ContextCancelledDuringBackoff(t *testing.T) {
+	// Arrange
+	cause := errors.New("down")
+	calls := 0
+	policy := Policy{Backoff: func() Backoff { return &fixedBackoff{delay: time.Hour, remaining: 1} }}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	// Act
+	err := Do(ctx, policy, failTimes(10, cause, &calls))
+
+	// Assert
+	if !errors.Is(err, context.DeadlineExceeded) || !errors.Is(err, cause) {
+		t.Errorf("expected the context error wrapping the last error, got %v", err)
+	}
+}
+
+func TestDo_AttemptTimeout(t *testing.T) {
+	// Arrange
+	calls := 0
+	policy := Policy{MaxAttempts: 2, AttemptTimeout: 5 * time.Millisecond}
+
+	// Act
+	err := Do(context.Background(), policy, func(ctx context.Context) error {
+		calls++
+		if calls == 1 {
+			<-ctx.Done()
+			return ctx.Err()
+		}
+		return nil
+	})
+
+	// Assert
+	if err != nil {
+		t.Errorf("expected the timed-out attempt to be retried, got %v", err)
+	}
+	if calls != 2 {
+		t.Errorf("expected 2 attempts, got %d", calls)
+	}
+}
+
+func TestDoValue(t *testing.T) {
+	// Arrange
+	calls := 0
+
+	// Act
+	value, err := DoValue(context.Background(), Policy{MaxAttempts: 3}, func(context.Context) (string, error) {
+		calls++
+		if calls < 2 {
+			return "", errors.New("flaky")
+		}
+		return "ok", nil
+	})
+
+	// Assert
+	if err != nil || value != "ok" {
+		t.Errorf("expected (ok, nil), got (%q, %v)", value, err)
+	}
+}
@@ -0,0 +1,107 @@
+// Package slicesx provides slice operations that the standard slices
+// package stops short of. Lookups that may find nothing return an
+// optional.Option instead of a value and a flag or index.
+package slicesx
+
+import (
+	"github.com/zodimo/go-zbase-std/iterx"
+	"github.com/zodimo/go-zbase-std/optional"
+)
+
+// GroupBy groups the elements of s by the key returned by key, keeping the
+// order of s within each group.
+//
+// Example:
+//
+//	byStatus := slicesx.GroupBy(orders, func(o Order) Status { return o.Status })
+func GroupBy[T any, K comparable](s []T, key func(T) K) map[K][]T {
+	groups := make(map[K][]T)
+	for _, v := range s {
+		k := key(v)
+		groups[k] = append(groups[k], v)
+	}
+	return groups
+}
+
+// Chunk splits s into consecutive slices of n elements; the last may be
+// shorter. The chunks share the backing array of s but are capped, so
+// appending to one does not overwrite the next. Values below 1 for n are
+// treated as 1.
+func Chunk[T any](s []T, n int) [][]T {
+	n = max(n, 1)
+	chunks := make([][]T, 0, (len(s)+n-1)/n)
+	for i := 0; i < len(s); i += n {
+		end := min(i+n, len(s))
+		chunks = append(chunks, s[i:end:end])
+	}
+	return chunks
+}
+
+// Uniq returns the elements of s without duplicates, keeping the first
+// occurrence of each. s is not modified.
+func Uniq[T comparable](s []T) []T {
+	return UniqBy(s, func(v T) T { return v })
+}
+
+// UniqBy is like Uniq, but considers elements duplicates when key returns
+// the same value for them.
+func UniqBy[T any, K comparable](s []T, key func(T) K) []T {
+	seen := make(map[K]struct{}, len(s))
+	out := make([]T, 0, len(s))
+	for _, v := range s {
+		k := key(v)
+		if _, ok := seen[k]; ok {
+			continue
+		}
+		seen[k] = struct{}{}
+		out = append(out, v)
+	}
+	return out
+}
+
+// Partition splits s into the elements for which match returns true and
+// those for which it returns false, keeping their order.
+func Partition[T any](s []T, match func(T) bool) (matched, rest []T) {
+	for _, v := range s {
+		if match(v) {
+			matched = append(matched, v)
+		} else {
+			rest = append(rest, v)
+		}
+	}
+	return matched, rest
+}
+
+// Find returns the first element of s for which match returns true, or
+// None if there is none.
+func Find[T any](s []T, match func(T) bool) optional.Option[T] {
+	return iterx.Find(iterx.FromSlice(s), match)
+}
+
+// MaxBy returns the greatest element of s according to cmp, which returns
+// a negative number when a < b, zero when a == b and a positive number when
+// a > b. Of equal elements, the first is returned. It returns None if s is
+// empty.
+func MaxBy[T any](s []T, cmp func(a, b T) int) optional.Option[T] {
+	return best(s, func(a, b T) bool { return cmp(a, b) > 0 })
+}
+
+// MinBy returns the least element of s according to cmp, like MaxBy. Of
+// equal elements, the first is returned. It returns None if s is empty.
+func MinBy[T any](s []T, cmp func(a, b T) int) optional.Option[T] {
+	return best(s, func(a, b T) bool { return cmp(a, b) < 0 })
+}
+
+// best returns the first element of s not beaten by a later one.
+func best[T any](s []T, beats func(a, b T) bool) optional.Option[T] {
+	if len(s) == 0 {
+		return optional.None[T]()
+	}
+	winner := s[0]
+	for _, v := range s[1:] {
+		if beats(v, winner) {
+			winner = v
+		}
+	}
+	return optional.Some(winner)
+}
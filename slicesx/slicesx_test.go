@@ -0,0 +1,117 @@
+package slicesx
+
+import (
+	"cmp"
+	"maps"
+	"slices"
+	"testing"
+)
+
+type person struct {
+	name string
+	age  int
+}
+
+var people = []person{{"ada", 36}, {"bob", 25}, {"cy", 36}, {"di", 19}}
+
+func byAge(a, b person) int { return cmp.Compare(a.age, b.age) }
+
+func TestGroupBy(t *testing.T) {
+	// Arrange
+	key := func(p person) int { return p.age }
+
+	// Act
+	groups := GroupBy(people, key)
+
+	// Assert
+	if got := slices.Sorted(maps.Keys(groups)); !slices.Equal(got, []int{19, 25, 36}) {
+		t.Errorf("expected groups 19, 25 and 36, got %v", got)
+	}
+	if got := groups[36]; len(got) != 2 || got[0].name != "ada" || got[1].name != "cy" {
+		t.Errorf("expected ada then cy aged 36, got %v", got)
+	}
+}
+
+func TestChunk(t *testing.T) {
+	// Arrange
+	s := []int{1, 2, 3, 4, 5}
+
+	// Act
+	chunks := Chunk(s, 2)
+	chunks[0] = append(chunks[0], 99)
+
+	// Assert
+	if len(chunks) != 3 || !slices.Equal(chunks[2], []int{5}) {
+		t.Errorf("expected 3 chunks ending with [5], got %v", chunks)
+	}
+	if s[2] != 3 {
+		t.Errorf("expected appending to a chunk not to overwrite the next, got %v", s)
+	}
+}
+
+func TestUniq(t *testing.T) {
+	// Arrange
+	s := []string{"b", "a", "b", "c", "a"}
+
+	// Act
+	got := Uniq(s)
+	byFirstAge := UniqBy(people, func(p person) int { return p.age })
+
+	// Assert
+	if !slices.Equal(got, []string{"b", "a", "c"}) {
+		t.Errorf("expected [b a c], got %v", got)
+	}
+	if len(byFirstAge) != 3 || byFirstAge[0].name != "ada" {
+		t.Errorf("expected the first person of each age, got %v", byFirstAge)
+	}
+}
+
+func TestPartition(t *testing.T) {
+	// Arrange
+	adult := func(p person) bool { return p.age >= 21 }
+
+	// Act
+	adults, minors := Partition(people, adult)
+
+	// Assert
+	if len(adults) != 3 || len(minors) != 1 || minors[0].name != "di" {
+		t.Errorf("expected 3 adults and di, got %v and %v", adults, minors)
+	}
+}
+
+func TestFind(t *testing.T) {
+	// Arrange
+	named := func(name string) func(person) bool {
+		return func(p person) bool { return p.name == name }
+	}
+
+	// Act
+	found, missing := Find(people, named("bob")), Find(people, named("zed"))
+
+	// Assert
+	if p, ok := found.Value(); !ok || p.age != 25 {
+		t.Errorf("expected bob, got %v", p)
+	}
+	if _, ok := missing.Value(); ok {
+		t.Error("expected None for a missing person")
+	}
+}
+
+func TestMaxByMinBy(t *testing.T) {
+	// Arrange
+	var empty []person
+
+	// Act
+	oldest, youngest, none := MaxBy(people, byAge), MinBy(people, byAge), MaxBy(empty, byAge)
+
+	// Assert
+	if p, _ := oldest.Value(); p.name != "ada" {
+		t.Errorf("expected the first of the oldest, ada, got %v", p)
+	}
+	if p, _ := youngest.Value(); p.name != "di" {
+		t.Errorf("expected di, got %v", p)
+	}
+	if _, ok := none.Value(); ok {
+		t.Error("expected None for an empty slice")
+	}
+}
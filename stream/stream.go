@@ -0,0 +1,147 @@
+// Package stream offers a fluent, lazily evaluated API over iter.Seq for
+// callers who prefer chaining methods to nesting the free functions of the
+// iterx package.
+package stream
+
+import (
+	"iter"
+	"slices"
+
+	"github.com/zodimo/go-zbase-std/iterx"
+	"github.com/zodimo/go-zbase-std/optional"
+)
+
+// Stream is a lazily evaluated sequence of values. Intermediate operations
+// such as Filter and Map only describe work; nothing is pulled from the
+// source until a terminal operation such as Collect runs. A Stream can be
+// consumed as many times as its source allows.
+type Stream[T any] struct {
+	seq iter.Seq[T]
+}
+
+// Of creates a stream over src.
+//
+// Parameters:
+//   - src: The source sequence.
+//
+// Returns:
+//   - Stream[T]: The stream.
+//
+// Example:
+//
+//	names := stream.Of(maps.Values(users)).
+//		Filter(func(u User) bool { return u.Active }).
+//		Sorted(func(a, b User) int { return cmp.Compare(a.Name, b.Name) }).
+//		Limit(10).
+//		Collect()
+func Of[T any](src iter.Seq[T]) Stream[T] {
+	return Stream[T]{seq: src}
+}
+
+// FromSlice creates a stream over the given values.
+func FromSlice[T any](items ...T) Stream[T] {
+	return Of(slices.Values(items))
+}
+
+// Map transforms every value of s with fn, possibly to another type. It is
+// a function rather than a method because methods cannot introduce type
+// parameters.
+func Map[T, U any](s Stream[T], fn func(T) U) Stream[U] {
+	return Of(iterx.Map(s.seq, fn))
+}
+
+// Distinct drops values equal to one already seen. It is a function
+// rather than a method because it requires comparable values, which a
+// method of Stream[T any] cannot demand.
+func Distinct[T comparable](s Stream[T]) Stream[T] {
+	return DistinctBy(s, func(v T) T { return v })
+}
+
+// DistinctBy drops values whose key, as returned by key, equals the key of
+// a value already seen. It works for values that are not comparable, such
+// as slices, given a comparable key for them.
+func DistinctBy[T any, K comparable](s Stream[T], key func(T) K) Stream[T] {
+	return Of(func(yield func(T) bool) {
+		seen := make(map[K]struct{})
+		for v := range s.seq {
+			k := key(v)
+			if _, ok := seen[k]; ok {
+				continue
+			}
+			seen[k] = struct{}{}
+			if !yield(v) {
+				return
+			}
+		}
+	})
+}
+
+// Seq returns the stream as an iter.Seq, for use with range or iterx.
+func (s Stream[T]) Seq() iter.Seq[T] {
+	return s.seq
+}
+
+// Map transforms every value with fn. Use the Map function to change the
+// element type.
+func (s Stream[T]) Map(fn func(T) T) Stream[T] {
+	return Map(s, fn)
+}
+
+// Filter keeps the values for which keep returns true.
+func (s Stream[T]) Filter(keep func(T) bool) Stream[T] {
+	return Of(iterx.Filter(s.seq, keep))
+}
+
+// Sorted orders the values by cmp, which returns a negative number when
+// a < b, zero when a == b and a positive number when a > b. Sorting is
+// stable. It needs every value of the source, so the source must be
+// finite; it is read once the sorted stream is consumed.
+func (s Stream[T]) Sorted(cmp func(a, b T) int) Stream[T] {
+	return Of(func(yield func(T) bool) {
+		items := slices.Collect(s.seq)
+		slices.SortStableFunc(items, cmp)
+		for _, v := range items {
+			if !yield(v) {
+				return
+			}
+		}
+	})
+}
+
+// Limit keeps at most the first n values.
+func (s Stream[T]) Limit(n int) Stream[T] {
+	return Of(iterx.Take(s.seq, n))
+}
+
+// Skip drops the first n values.
+func (s Stream[T]) Skip(n int) Stream[T] {
+	return Of(iterx.Skip(s.seq, n))
+}
+
+// Collect returns the values in a slice.
+func (s Stream[T]) Collect() []T {
+	return iterx.Collect(s.seq)
+}
+
+// First returns the first value, or None if the stream is empty. Only the
+// first value is pulled from the source.
+func (s Stream[T]) First() optional.Option[T] {
+	return iterx.Find(s.seq, func(T) bool { return true })
+}
+
+// Reduce folds the values into a single result, starting from init.
+func (s Stream[T]) Reduce(init T, fn func(acc, v T) T) T {
+	return iterx.Reduce(s.seq, init, fn)
+}
+
+// Count returns the number of values.
+func (s Stream[T]) Count() int {
+	return iterx.Reduce(s.seq, 0, func(n int, _ T) int { return n + 1 })
+}
+
+// ForEach calls fn for every value.
+func (s Stream[T]) ForEach(fn func(T)) {
+	for v := range s.seq {
+		fn(v)
+	}
+}
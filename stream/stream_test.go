@@ -0,0 +1,111 @@
+package stream
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strconv"
+	"testing"
+)
+
+func TestStream_Chain(t *testing.T) {
+	// Arrange
+	s := FromSlice(5, 3, 8, 3, 1, 8, 2)
+
+	// Act
+	got := Distinct(s).
+		Filter(func(n int) bool { return n > 1 }).
+		Map(func(n int) int { return n * 10 }).
+		Sorted(cmp.Compare[int]).
+		Limit(3).
+		Collect()
+
+	// Assert
+	if want := []int{20, 30, 50}; !slices.Equal(got, want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+}
+
+func TestDistinctBy_NonComparableValues(t *testing.T) {
+	// Arrange
+	s := FromSlice([]int{1, 2}, []int{3}, []int{1, 2}, []int{3, 4})
+
+	// Act
+	got := DistinctBy(s, func(v []int) string { return fmt.Sprint(v) }).Collect()
+
+	// Assert
+	if len(got) != 3 || !slices.Equal(got[2], []int{3, 4}) {
+		t.Errorf("expected [[1 2] [3] [3 4]], got %v", got)
+	}
+}
+
+func TestMap_ChangesType(t *testing.T) {
+	// Arrange
+	s := FromSlice(1, 2, 3)
+
+	// Act
+	got := Map(s, strconv.Itoa).Collect()
+
+	// Assert
+	if want := []string{"1", "2", "3"}; !slices.Equal(got, want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+}
+
+func TestStream_IsLazy(t *testing.T) {
+	// Arrange
+	pulled := 0
+	s := Of(func(yield func(int) bool) {
+		for i := 0; ; i++ {
+			pulled++
+			if !yield(i) {
+				return
+			}
+		}
+	})
+
+	// Act
+	mapped := s.Map(func(n int) int { return n * n })
+	before := pulled
+	first := mapped.Filter(func(n int) bool { return n > 10 }).First()
+
+	// Assert
+	if before != 0 {
+		t.Errorf("expected no values pulled before a terminal operation, got %d", before)
+	}
+	if v, ok := first.Value(); !ok || v != 16 {
+		t.Errorf("expected 16, got %v", v)
+	}
+	if pulled != 5 {
+		t.Errorf("expected 5 values pulled, got %d", pulled)
+	}
+}
+
+func TestStream_FirstEmpty(t *testing.T) {
+	// Arrange
+	s := FromSlice[int]()
+
+	// Act
+	first := s.First()
+
+	// Assert
+	if v, ok := first.Value(); ok {
+		t.Errorf("expected None, got %v", v)
+	}
+}
+
+func TestStream_Terminals(t *testing.T) {
+	// Arrange
+	s := FromSlice(1, 2, 3, 4)
+	var seen []int
+
+	// Act
+	sum := s.Reduce(0, func(acc, n int) int { return acc + n })
+	count := s.Skip(1).Count()
+	s.ForEach(func(n int) { seen = append(seen, n) })
+
+	// Assert
+	if sum != 10 || count != 3 || !slices.Equal(seen, []int{1, 2, 3, 4}) {
+		t.Errorf("expected sum 10, count 3 and every value, got %d, %d and %v", sum, count, seen)
+	}
+}
@@ -0,0 +1,63 @@
+package syncx
+
+import "sync/atomic"
+
+// Atomic is a typed atomic value. Unlike atomic.Value it needs no type
+// assertions, accepts nil interface values and values of differing
+// concrete types when T is an interface, and loads the zero value of T
+// before the first Store. The zero value is ready to use. An Atomic must not
+// be copied after first use.
+type Atomic[T any] struct {
+	v atomic.Value // Holds a box[T].
+}
+
+// box gives every value stored in an Atomic the same concrete type, as
+// atomic.Value requires.
+type box[T any] struct {
+	value T
+}
+
+// NewAtomic creates an Atomic holding value.
+//
+// Example:
+//
+//	level := syncx.NewAtomic(slog.LevelInfo)
+//	level.Store(slog.LevelDebug)
+//	current := level.Load()
+func NewAtomic[T any](value T) *Atomic[T] {
+	a := &Atomic[T]{}
+	a.Store(value)
+	return a
+}
+
+// Load returns the current value, or the zero value of T if none has been
+// stored.
+func (a *Atomic[T]) Load() T {
+	b, _ := a.v.Load().(box[T])
+	return b.value
+}
+
+// Store sets the current value.
+func (a *Atomic[T]) Store(value T) {
+	a.v.Store(box[T]{value})
+}
+
+// Swap sets the current value and returns the previous one, or the zero
+// value of T if none had been stored.
+func (a *Atomic[T]) Swap(value T) T {
+	old, _ := a.v.Swap(box[T]{value}).(box[T])
+	return old.value
+}
+
+// CompareAndSwap sets the current value to new if it equals old, and
+// reports whether it did. Before the first Store, the current value is
+// taken to be the zero value of T. T must be comparable at run time;
+// otherwise CompareAndSwap panics.
+func (a *Atomic[T]) CompareAndSwap(old, new T) bool {
+	if a.v.CompareAndSwap(box[T]{old}, box[T]{new}) {
+		return true
+	}
+	// atomic.Value holds nil until the first Store.
+	var zero T
+	return any(old) == any(zero) && a.v.CompareAndSwap(nil, box[T]{new})
+}
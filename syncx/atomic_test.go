@@ -0,0 +1,86 @@
+package syncx
+
+import (
+	"errors"
+	"io"
+	"sync"
+	"testing"
+)
+
+func TestAtomic_ZeroValue(t *testing.T) {
+	// Arrange
+	var a Atomic[int]
+
+	// Act
+	loaded := a.Load()
+	swapped := a.Swap(5)
+
+	// Assert
+	if loaded != 0 || swapped != 0 {
+		t.Errorf("expected zero values before the first Store, got %d and %d", loaded, swapped)
+	}
+	if a.Load() != 5 {
+		t.Errorf("expected 5, got %d", a.Load())
+	}
+}
+
+func TestAtomic_InterfaceValues(t *testing.T) {
+	// Arrange
+	a := NewAtomic[error](io.EOF)
+
+	// Act
+	old := a.Swap(errors.New("other"))
+	a.Store(nil)
+
+	// Assert
+	if old != io.EOF {
+		t.Errorf("expected io.EOF, got %v", old)
+	}
+	if a.Load() != nil {
+		t.Errorf("expected nil after storing nil, got %v", a.Load())
+	}
+}
+
+func TestAtomic_CompareAndSwap(t *testing.T) {
+	// Arrange
+	var a Atomic[string]
+
+	// Act
+	fromZero := a.CompareAndSwap("", "a")
+	wrongOld := a.CompareAndSwap("x", "b")
+	rightOld := a.CompareAndSwap("a", "b")
+
+	// Assert
+	if !fromZero || wrongOld || !rightOld {
+		t.Errorf("expected CompareAndSwap true, false, true, got %v, %v, %v", fromZero, wrongOld, rightOld)
+	}
+	if a.Load() != "b" {
+		t.Errorf("expected b, got %q", a.Load())
+	}
+}
+
+func TestAtomic_ConcurrentCompareAndSwap(t *testing.T) {
+	// Arrange
+	var a Atomic[int]
+	var wg sync.WaitGroup
+
+	// Act
+	for range 50 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				v := a.Load()
+				if a.CompareAndSwap(v, v+1) {
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	// Assert
+	if a.Load() != 50 {
+		t.Errorf("expected 50 increments, got %d", a.Load())
+	}
+}
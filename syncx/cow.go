@@ -0,0 +1,148 @@
+package syncx
+
+import (
+	"iter"
+	"maps"
+	"slices"
+	"sync"
+	"sync/atomic"
+
+	"github.com/zodimo/go-zbase-std/optional"
+)
+
+// COWSlice is a copy-on-write slice. Reads are lock-free atomic loads of
+// the current version; writes are serialised, copy the slice, modify the
+// copy and publish it atomically. It suits read-heavy data such as
+// listener lists that change rarely. The zero value is an empty slice ready
+// to use. A COWSlice must not be copied after first use.
+type COWSlice[T any] struct {
+	mu      sync.Mutex // Serialises writers.
+	current atomic.Pointer[[]T]
+}
+
+// Load returns the current version of the slice. The returned slice is
+// shared with other readers and must not be modified.
+//
+// Example:
+//
+//	var listeners syncx.COWSlice[func(Event)]
+//	listeners.Append(logEvent)
+//	for _, listener := range listeners.Load() {
+//		listener(event)
+//	}
+func (s *COWSlice[T]) Load() []T {
+	if p := s.current.Load(); p != nil {
+		return *p
+	}
+	return nil
+}
+
+// Len returns the length of the current version.
+func (s *COWSlice[T]) Len() int {
+	return len(s.Load())
+}
+
+// All returns an iterator over the current version. Writes made during
+// iteration are not observed.
+func (s *COWSlice[T]) All() iter.Seq2[int, T] {
+	return slices.All(s.Load())
+}
+
+// Append publishes a version with values appended.
+func (s *COWSlice[T]) Append(values ...T) {
+	s.Update(func(items []T) []T {
+		return append(items, values...)
+	})
+}
+
+// DeleteFunc publishes a version without the elements for which del
+// returns true.
+func (s *COWSlice[T]) DeleteFunc(del func(T) bool) {
+	s.Update(func(items []T) []T {
+		return slices.DeleteFunc(items, del)
+	})
+}
+
+// Update publishes the slice returned by fn. fn receives a private copy of
+// the current version that it may modify, and runs while other writers are
+// excluded, so the update is atomic with respect to them.
+func (s *COWSlice[T]) Update(fn func(items []T) []T) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	next := fn(slices.Clone(s.Load()))
+	s.current.Store(&next)
+}
+
+// COWMap is a copy-on-write map. Reads are lock-free atomic loads of the
+// current version; writes are serialised, copy the map, modify the copy and
+// publish it atomically. It suits read-heavy data such as routing tables.
+// The zero value is an empty map ready to use. A COWMap must not be copied
+// after first use.
+type COWMap[K comparable, V any] struct {
+	mu      sync.Mutex // Serialises writers.
+	current atomic.Pointer[map[K]V]
+}
+
+// Load returns the value stored under key in the current version, or None
+// if the key is absent.
+func (m *COWMap[K, V]) Load(key K) optional.Option[V] {
+	if value, ok := m.Snapshot()[key]; ok {
+		return optional.Some(value)
+	}
+	return optional.None[V]()
+}
+
+// Snapshot returns the current version of the map. The returned map is
+// shared with other readers and must not be modified.
+func (m *COWMap[K, V]) Snapshot() map[K]V {
+	if p := m.current.Load(); p != nil {
+		return *p
+	}
+	return nil
+}
+
+// Len returns the number of entries in the current version.
+func (m *COWMap[K, V]) Len() int {
+	return len(m.Snapshot())
+}
+
+// All returns an iterator over the current version. Writes made during
+// iteration are not observed.
+func (m *COWMap[K, V]) All() iter.Seq2[K, V] {
+	return maps.All(m.Snapshot())
+}
+
+// Store publishes a version with value stored under key.
+func (m *COWMap[K, V]) Store(key K, value V) {
+	m.Update(func(entries map[K]V) {
+		entries[key] = value
+	})
+}
+
+// Delete publishes a version without key.
+func (m *COWMap[K, V]) Delete(key K) {
+	m.Update(func(entries map[K]V) {
+		delete(entries, key)
+	})
+}
+
+// Update publishes the map modified by fn. fn receives a private, non-nil
+// copy of the current version that it may modify, and runs while other
+// writers are excluded, so several changes can be published at once.
+//
+// Example:
+//
+//	routes.Update(func(table map[string]Handler) {
+//		delete(table, "/v1/users")
+//		table["/v2/users"] = usersV2
+//	})
+func (m *COWMap[K, V]) Update(fn func(entries map[K]V)) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	next := maps.Clone(m.Snapshot())
+	if next == nil {
+		next = make(map[K]V)
+	}
+	fn(next)
+	m.current.Store(&next)
+}
@@ -0,0 +1,144 @@
+package syncx
+
+import (
+	"slices"
+	"sync"
+	"testing"
+)
+
+func TestCOWSlice_WritesDoNotAffectLoadedVersions(t *testing.T) {
+	// Arrange
+	var s COWSlice[int]
+	s.Append(1, 2)
+	before := s.Load()
+
+	// Act
+	s.Append(3)
+	s.DeleteFunc(func(v int) bool { return v == 1 })
+
+	// Assert
+	if !slices.Equal(before, []int{1, 2}) {
+		t.Errorf("expected the loaded version to stay [1 2], got %v", before)
+	}
+	if got := s.Load(); !slices.Equal(got, []int{2, 3}) {
+		t.Errorf("expected [2 3], got %v", got)
+	}
+	if s.Len() != 2 {
+		t.Errorf("expected length 2, got %d", s.Len())
+	}
+}
+
+func TestCOWSlice_ZeroValue(t *testing.T) {
+	// Arrange
+	var s COWSlice[string]
+
+	// Act
+	count := 0
+	for range s.All() {
+		count++
+	}
+
+	// Assert
+	if s.Len() != 0 || count != 0 {
+		t.Errorf("expected an empty slice, got length %d and %d elements", s.Len(), count)
+	}
+}
+
+func TestCOWSlice_ConcurrentAppends(t *testing.T) {
+	// Arrange
+	var s COWSlice[int]
+	var wg sync.WaitGroup
+
+	// Act
+	for i := range 100 {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			s.Append(i)
+		}()
+		go func() {
+			defer wg.Done()
+			_ = s.Len()
+		}()
+	}
+	wg.Wait()
+
+	// Assert
+	if s.Len() != 100 {
+		t.Errorf("expected 100 elements, got %d", s.Len())
+	}
+}
+
+func TestCOWMap_StoreLoadDelete(t *testing.T) {
+	// Arrange
+	var m COWMap[string, int]
+	m.Store("a", 1)
+	m.Store("b", 2)
+	snapshot := m.Snapshot()
+
+	// Act
+	m.Delete("a")
+	deleted := m.Load("a")
+	kept := m.Load("b")
+
+	// Assert
+	if _, ok := deleted.Value(); ok {
+		t.Error("expected None for a deleted key")
+	}
+	if v, ok := kept.Value(); !ok || v != 2 {
+		t.Errorf("expected Some(2), got %v, %v", v, ok)
+	}
+	if len(snapshot) != 2 || snapshot["a"] != 1 {
+		t.Errorf("expected the snapshot to keep both entries, got %v", snapshot)
+	}
+}
+
+func TestCOWMap_UpdatePublishesAtOnce(t *testing.T) {
+	// Arrange
+	var m COWMap[string, int]
+	m.Store("old", 1)
+
+	// Act
+	m.Update(func(entries map[string]int) {
+		delete(entries, "old")
+		entries["new"] = 2
+		entries["other"] = 3
+	})
+
+	// Assert
+	if m.Len() != 2 {
+		t.Errorf("expected 2 entries, got %d", m.Len())
+	}
+	sum := 0
+	for _, v := range m.All() {
+		sum += v
+	}
+	if sum != 5 {
+		t.Errorf("expected value sum 5, got %d", sum)
+	}
+}
+
+func TestCOWMap_ConcurrentStores(t *testing.T) {
+	// Arrange
+	var m COWMap[int, int]
+	var wg sync.WaitGroup
+
+	// Act
+	for i := range 100 {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			m.Store(i, i)
+		}()
+		go func() {
+			defer wg.Done()
+			_ = m.Load(i)
+		}()
+	}
+	wg.Wait()
+
+	// Assert
+	if m.Len() != 100 {
+		t.Errorf("expected 100 entries, got %d", m.Len())
+	}
+}
@@ -0,0 +1,123 @@
+// Package syncx provides typed, generic counterparts to the concurrency
+// primitives of the sync and sync/atomic packages.
+package syncx
+
+import (
+	"iter"
+	"sync"
+	"sync/atomic"
+
+	"github.com/zodimo/go-zbase-std/optional"
+)
+
+// Map is a typed wrapper around sync.Map. It has the same performance
+// characteristics and is best suited to keys that are written once and read
+// many times, or to goroutines working on disjoint sets of keys. The zero
+// value is an empty map ready to use. A Map must not be copied after first
+// use.
+type Map[K comparable, V any] struct {
+	m sync.Map
+	n atomic.Int64 // Number of entries.
+}
+
+// Load returns the value stored under key, or None if the key is absent.
+//
+// Example:
+//
+//	var sessions syncx.Map[string, *Session]
+//	sessions.Store(id, session)
+//	found := sessions.Load(id)
+//	if s, ok := found.Value(); ok {
+//		// use s
+//	}
+func (m *Map[K, V]) Load(key K) optional.Option[V] {
+	value, ok := m.m.Load(key)
+	if !ok {
+		return optional.None[V]()
+	}
+	return optional.Some(typed[V](value))
+}
+
+// Store sets the value for key.
+func (m *Map[K, V]) Store(key K, value V) {
+	if _, loaded := m.m.Swap(key, value); !loaded {
+		m.n.Add(1)
+	}
+}
+
+// LoadOrStore returns the existing value for key if present. Otherwise, it
+// stores and returns value.
+//
+// Returns:
+//   - V: The existing value if loaded; value otherwise.
+//   - bool: True if the value was loaded; false if it was stored.
+func (m *Map[K, V]) LoadOrStore(key K, value V) (V, bool) {
+	actual, loaded := m.m.LoadOrStore(key, value)
+	if !loaded {
+		m.n.Add(1)
+	}
+	return typed[V](actual), loaded
+}
+
+// LoadAndDelete removes key and returns its previous value, or None if the
+// key was absent.
+func (m *Map[K, V]) LoadAndDelete(key K) optional.Option[V] {
+	value, loaded := m.m.LoadAndDelete(key)
+	if !loaded {
+		return optional.None[V]()
+	}
+	m.n.Add(-1)
+	return optional.Some(typed[V](value))
+}
+
+// Delete removes key.
+func (m *Map[K, V]) Delete(key K) {
+	m.LoadAndDelete(key)
+}
+
+// CompareAndSwap stores new under key if the value stored under key is
+// equal to old, and reports whether it did. The values must be of a
+// comparable type; otherwise CompareAndSwap panics.
+func (m *Map[K, V]) CompareAndSwap(key K, old, new V) bool {
+	return m.m.CompareAndSwap(key, old, new)
+}
+
+// CompareAndDelete removes key if the value stored under key is equal to
+// old, and reports whether it did. The values must be of a comparable type;
+// otherwise CompareAndDelete panics.
+func (m *Map[K, V]) CompareAndDelete(key K, old V) bool {
+	if !m.m.CompareAndDelete(key, old) {
+		return false
+	}
+	m.n.Add(-1)
+	return true
+}
+
+// Range calls fn for each entry until fn returns false. Like
+// sync.Map.Range, it does not correspond to a consistent snapshot of the
+// map when it is modified concurrently.
+func (m *Map[K, V]) Range(fn func(key K, value V) bool) {
+	m.m.Range(func(key, value any) bool {
+		return fn(typed[K](key), typed[V](value))
+	})
+}
+
+// All returns an iterator over the entries, with the same consistency as
+// Range.
+func (m *Map[K, V]) All() iter.Seq2[K, V] {
+	return m.Range
+}
+
+// Len returns the number of entries. While the map is being modified
+// concurrently, the count may briefly lag the modifications in flight.
+func (m *Map[K, V]) Len() int {
+	return int(m.n.Load())
+}
+
+// typed converts a value stored in the underlying sync.Map back to T. A nil
+// interface value, which sync.Map stores for a nil T when T is an
+// interface type, converts to the zero value instead of panicking.
+func typed[T any](value any) T {
+	v, _ := value.(T)
+	return v
+}
@@ -0,0 +1,179 @@
+package syncx
+
+import (
+	"errors"
+	"sync"
+	"testing"
+)
+
+func TestMap_LoadAndStore(t *testing.T) {
+	// Arrange
+	var m Map[string, int]
+	m.Store("a", 1)
+	m.Store("a", 2)
+
+	// Act
+	present := m.Load("a")
+	absent := m.Load("b")
+
+	// Assert
+	if v, ok := present.Value(); !ok || v != 2 {
+		t.Errorf("expected Some(2), got %v, %v", v, ok)
+	}
+	if _, ok := absent.Value(); ok {
+		t.Error("expected None for an absent key")
+	}
+	if m.Len() != 1 {
+		t.Errorf("expected length 1, got %d", m.Len())
+	}
+}
+
+func TestMap_LoadOrStore(t *testing.T) {
+	// Arrange
+	var m Map[string, int]
+
+	// Act
+	stored, storedLoaded := m.LoadOrStore("a", 1)
+	existing, existingLoaded := m.LoadOrStore("a", 2)
+
+	// Assert
+	if stored != 1 || storedLoaded {
+		t.Errorf("expected 1 to be stored, got %d, %v", stored, storedLoaded)
+	}
+	if existing != 1 || !existingLoaded {
+		t.Errorf("expected 1 to be loaded, got %d, %v", existing, existingLoaded)
+	}
+	if m.Len() != 1 {
+		t.Errorf("expected length 1, got %d", m.Len())
+	}
+}
+
+func TestMap_DeleteVariants(t *testing.T) {
+	// Arrange
+	var m Map[string, int]
+	m.Store("a", 1)
+	m.Store("b", 2)
+	m.Store("c", 3)
+
+	// Act
+	removed := m.LoadAndDelete("a")
+	missing := m.LoadAndDelete("a")
+	wrongOld := m.CompareAndDelete("b", 9)
+	rightOld := m.CompareAndDelete("b", 2)
+	m.Delete("c")
+	m.Delete("c")
+
+	// Assert
+	if v, ok := removed.Value(); !ok || v != 1 {
+		t.Errorf("expected Some(1), got %v, %v", v, ok)
+	}
+	if _, ok := missing.Value(); ok {
+		t.Error("expected None for a deleted key")
+	}
+	if wrongOld || !rightOld {
+		t.Errorf("expected CompareAndDelete false, true, got %v, %v", wrongOld, rightOld)
+	}
+	if m.Len() != 0 {
+		t.Errorf("expected an empty map, got length %d", m.Len())
+	}
+}
+
+func TestMap_CompareAndSwap(t *testing.T) {
+	// Arrange
+	var m Map[string, int]
+	m.Store("a", 1)
+
+	// Act
+	wrongOld := m.CompareAndSwap("a", 9, 2)
+	rightOld := m.CompareAndSwap("a", 1, 2)
+
+	// Assert
+	if wrongOld || !rightOld {
+		t.Errorf("expected CompareAndSwap false, true, got %v, %v", wrongOld, rightOld)
+	}
+	current := m.Load("a")
+	if v, _ := current.Value(); v != 2 {
+		t.Errorf("expected 2, got %d", v)
+	}
+}
+
+func TestMap_RangeAndAll(t *testing.T) {
+	// Arrange
+	var m Map[int, int]
+	for i := range 10 {
+		m.Store(i, i*i)
+	}
+
+	// Act
+	sum := 0
+	for k, v := range m.All() {
+		if v != k*k {
+			t.Errorf("expected %d for key %d, got %d", k*k, k, v)
+		}
+		sum += k
+	}
+	visited := 0
+	m.Range(func(int, int) bool {
+		visited++
+		return visited < 3
+	})
+
+	// Assert
+	if sum != 45 {
+		t.Errorf("expected key sum 45, got %d", sum)
+	}
+	if visited != 3 {
+		t.Errorf("expected Range to stop after 3 entries, got %d", visited)
+	}
+}
+
+func TestMap_ConcurrentLen(t *testing.T) {
+	// Arrange
+	var m Map[int, int]
+	var wg sync.WaitGroup
+
+	// Act
+	for i := range 100 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			m.Store(i%50, i)
+		}()
+	}
+	wg.Wait()
+
+	// Assert
+	if m.Len() != 50 {
+		t.Errorf("expected length 50, got %d", m.Len())
+	}
+}
+
+func TestMap_NilInterfaceValue(t *testing.T) {
+	// Arrange
+	var m Map[string, error]
+	m.Store("a", nil)
+
+	// Act
+	loaded := m.Load("a")
+	actual, wasLoaded := m.LoadOrStore("a", errors.New("unused"))
+	var ranged []error
+	m.Range(func(_ string, err error) bool {
+		ranged = append(ranged, err)
+		return true
+	})
+	deleted := m.LoadAndDelete("a")
+
+	// Assert
+	if err, ok := loaded.Value(); !ok || err != nil {
+		t.Errorf("expected Some(nil) from Load, got %v, %v", err, ok)
+	}
+	if !wasLoaded || actual != nil {
+		t.Errorf("expected LoadOrStore to load nil, got %v, %v", actual, wasLoaded)
+	}
+	if len(ranged) != 1 || ranged[0] != nil {
+		t.Errorf("expected Range to visit one nil value, got %v", ranged)
+	}
+	if err, ok := deleted.Value(); !ok || err != nil {
+		t.Errorf("expected Some(nil) from LoadAndDelete, got %v, %v", err, ok)
+	}
+}
@@ -0,0 +1,59 @@
+package syncx
+
+import "sync"
+
+// Replaceable holds an implementation that can be hot-swapped while it is
+// in use, such as a global registry or the current configuration. Loads
+// are lock-free; replacements are serialised so that Update can derive the
+// new value from the current one without losing concurrent updates. The
+// zero value holds the zero value of T. A Replaceable must not be copied
+// after first use.
+type Replaceable[T any] struct {
+	mu      sync.Mutex // Serialises Replace and Update.
+	current Atomic[T]
+}
+
+// NewReplaceable creates a Replaceable holding initial.
+//
+// Example:
+//
+//	var config = syncx.NewReplaceable(loadConfig())
+//
+//	func onReload() {
+//		config.Replace(loadConfig())
+//	}
+func NewReplaceable[T any](initial T) *Replaceable[T] {
+	r := &Replaceable[T]{}
+	r.current.Store(initial)
+	return r
+}
+
+// Load returns the current value.
+func (r *Replaceable[T]) Load() T {
+	return r.current.Load()
+}
+
+// Replace installs value and returns the value it replaced.
+func (r *Replaceable[T]) Replace(value T) T {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.current.Swap(value)
+}
+
+// Update installs the value returned by fn, which receives the current
+// value, and returns the installed value. Other replacements wait while fn
+// runs, so fn should be quick and must not call Replace or Update on r.
+//
+// Example:
+//
+//	limits.Update(func(current Limits) Limits {
+//		current.MaxConns *= 2
+//		return current
+//	})
+func (r *Replaceable[T]) Update(fn func(current T) T) T {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	next := fn(r.current.Load())
+	r.current.Store(next)
+	return next
+}
@@ -0,0 +1,60 @@
+package syncx
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestReplaceable_LoadAndReplace(t *testing.T) {
+	// Arrange
+	r := NewReplaceable("v1")
+
+	// Act
+	old := r.Replace("v2")
+
+	// Assert
+	if old != "v1" {
+		t.Errorf("expected the replaced value v1, got %q", old)
+	}
+	if r.Load() != "v2" {
+		t.Errorf("expected v2, got %q", r.Load())
+	}
+}
+
+func TestReplaceable_ZeroValue(t *testing.T) {
+	// Arrange
+	var r Replaceable[*int]
+
+	// Act
+	loaded := r.Load()
+
+	// Assert
+	if loaded != nil {
+		t.Errorf("expected nil, got %v", loaded)
+	}
+}
+
+func TestReplaceable_ConcurrentUpdates(t *testing.T) {
+	// Arrange
+	r := NewReplaceable(0)
+	var wg sync.WaitGroup
+
+	// Act
+	for range 100 {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			r.Update(func(current int) int { return current + 1 })
+		}()
+		go func() {
+			defer wg.Done()
+			_ = r.Load()
+		}()
+	}
+	wg.Wait()
+
+	// Assert
+	if r.Load() != 100 {
+		t.Errorf("expected 100 updates, got %d", r.Load())
+	}
+}
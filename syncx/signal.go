@@ -0,0 +1,155 @@
+package syncx
+
+import (
+	"context"
+	"sync"
+)
+
+// Signal is a one-shot event: once Set, it stays set and every current and
+// future waiter is released. It replaces hand-closed channels guarded by
+// sync.Once and condition variables that cannot be combined with a
+// context. The zero value is an unset signal ready to use. A Signal must
+// not be copied after first use.
+type Signal struct {
+	mu  sync.Mutex
+	ch  chan struct{} // Created on first use; closed by Set.
+	set bool
+}
+
+// Set sets the signal, releasing its waiters. It reports whether this
+// call set it, so exactly one of several concurrent callers sees true.
+//
+// Example:
+//
+//	var ready syncx.Signal
+//	go func() {
+//		warmUp()
+//		ready.Set()
+//	}()
+//	if err := ready.Wait(ctx); err != nil {
+//		return err
+//	}
+func (s *Signal) Set() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.set {
+		return false
+	}
+	s.set = true
+	close(s.chanLocked())
+	return true
+}
+
+// IsSet reports whether the signal has been set.
+func (s *Signal) IsSet() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.set
+}
+
+// Done returns a channel that is closed once the signal is set, for use in
+// select statements.
+func (s *Signal) Done() <-chan struct{} {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.chanLocked()
+}
+
+// Wait blocks until the signal is set or ctx is done.
+//
+// Returns:
+//   - error: ctx.Err() if ctx is done before the signal is set; nil
+//     otherwise.
+func (s *Signal) Wait(ctx context.Context) error {
+	return wait(ctx, s.Done())
+}
+
+// chanLocked returns the channel, creating it if needed. s.mu must be
+// held.
+func (s *Signal) chanLocked() chan struct{} {
+	if s.ch == nil {
+		s.ch = make(chan struct{})
+	}
+	return s.ch
+}
+
+// ManualResetEvent is an event that stays set until it is explicitly
+// Reset, releasing every waiter while set. Unlike Signal it can be set
+// repeatedly, for conditions that come and go, such as a connection being
+// up. The zero value is an unset event ready to use. A ManualResetEvent
+// must not be copied after first use.
+type ManualResetEvent struct {
+	mu  sync.Mutex
+	ch  chan struct{} // The current generation; closed while set.
+	set bool
+}
+
+// Set sets the event, releasing its waiters. It reports whether the event
+// was unset before.
+func (e *ManualResetEvent) Set() bool {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.set {
+		return false
+	}
+	e.set = true
+	close(e.chanLocked())
+	return true
+}
+
+// Reset unsets the event, so later waiters block until the next Set. It
+// reports whether the event was set before.
+func (e *ManualResetEvent) Reset() bool {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if !e.set {
+		return false
+	}
+	e.set = false
+	e.ch = make(chan struct{})
+	return true
+}
+
+// IsSet reports whether the event is set.
+func (e *ManualResetEvent) IsSet() bool {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.set
+}
+
+// Done returns a channel that is closed once the event is set. A channel
+// obtained while the event is set stays closed after a Reset; obtain a new
+// one to wait for the next Set.
+func (e *ManualResetEvent) Done() <-chan struct{} {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.chanLocked()
+}
+
+// Wait blocks until the event is set or ctx is done.
+//
+// Returns:
+//   - error: ctx.Err() if ctx is done before the event is set; nil
+//     otherwise.
+func (e *ManualResetEvent) Wait(ctx context.Context) error {
+	return wait(ctx, e.Done())
+}
+
+// chanLocked returns the channel of the current generation, creating it
+// if needed. e.mu must be held.
+func (e *ManualResetEvent) chanLocked() chan struct{} {
+	if e.ch == nil {
+		e.ch = make(chan struct{})
+	}
+	return e.ch
+}
+
+// wait blocks until done is closed or ctx is done.
+func wait(ctx context.Context, done <-chan struct{}) error {
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
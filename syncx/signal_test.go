@@ -0,0 +1,105 @@
+package syncx
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestSignal_ReleasesAllWaiters(t *testing.T) {
+	// Arrange
+	var s Signal
+	var released atomic.Int32
+	var wg sync.WaitGroup
+	for range 5 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if s.Wait(context.Background()) == nil {
+				released.Add(1)
+			}
+		}()
+	}
+
+	// Act
+	first, second := s.Set(), s.Set()
+	wg.Wait()
+
+	// Assert
+	if !first || second {
+		t.Errorf("expected only the first Set to report true, got %v and %v", first, second)
+	}
+	if released.Load() != 5 || !s.IsSet() {
+		t.Errorf("expected 5 waiters released, got %d", released.Load())
+	}
+	select {
+	case <-s.Done():
+	default:
+		t.Error("expected Done to be closed after Set")
+	}
+}
+
+func TestSignal_WaitContextDone(t *testing.T) {
+	// Arrange
+	var s Signal
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	// Act
+	err := s.Wait(ctx)
+
+	// Assert
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected context.DeadlineExceeded, got %v", err)
+	}
+}
+
+func TestManualResetEvent_SetAndReset(t *testing.T) {
+	// Arrange
+	var e ManualResetEvent
+	e.Set()
+	setDone := e.Done()
+
+	// Act
+	reset := e.Reset()
+	resetAgain := e.Reset()
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	err := e.Wait(ctx)
+
+	// Assert
+	if !reset || resetAgain || e.IsSet() {
+		t.Errorf("expected one effective Reset, got %v and %v", reset, resetAgain)
+	}
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected Wait to block after Reset, got %v", err)
+	}
+	select {
+	case <-setDone:
+	default:
+		t.Error("expected a channel obtained while set to stay closed")
+	}
+}
+
+func TestManualResetEvent_ReleasesWaiterOnNextSet(t *testing.T) {
+	// Arrange
+	var e ManualResetEvent
+	e.Set()
+	e.Reset()
+	done := make(chan error)
+	go func() { done <- e.Wait(context.Background()) }()
+
+	// Act
+	set := e.Set()
+
+	// Assert
+	if !set {
+		t.Error("expected Set to report the event was unset")
+	}
+	if err := <-done; err != nil {
+		t.Errorf("expected no error, got %v", err)
+	}
+}
@@ -0,0 +1,258 @@
+// Package taskgraph runs tasks that depend on each other, such as the
+// steps of application startup, with as much parallelism as their
+// dependencies allow.
+package taskgraph
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/zodimo/go-zbase-std/errcode"
+	"github.com/zodimo/go-zbase-std/future"
+	"github.com/zodimo/go-zbase-std/multierr"
+)
+
+// InvalidGraphError is returned by Run, before any task starts, when a
+// task name is used twice, a dependency names no task, or dependencies
+// form a cycle.
+var InvalidGraphError = errcode.New(errcode.Invalid, "taskgraph: invalid graph")
+
+// DependencyFailedError settles the futures of tasks that were not run
+// because a task they depend on failed or was not run itself.
+var DependencyFailedError = errcode.New(errcode.Canceled, "taskgraph: dependency failed")
+
+// AlreadyRunError is returned by Run when the graph has been run before.
+var AlreadyRunError = errcode.New(errcode.Conflict, "taskgraph: graph already run")
+
+// Graph is a set of named tasks and the dependencies between them. Build
+// it with Add and AddValue, then call Run once. It is safe for concurrent
+// use.
+type Graph struct {
+	mu    sync.Mutex
+	nodes []*node
+	ran   bool
+}
+
+// node is a task in the graph.
+type node struct {
+	name   string
+	deps   []string
+	run    func(ctx context.Context) error // Settles the future on success.
+	reject func(err error)                 // Settles the future on failure.
+
+	done chan struct{} // Closed once the task has finished or been skipped.
+	err  error         // Why the task failed or was skipped; set before done closes.
+}
+
+// New creates an empty Graph.
+//
+// Example:
+//
+//	g := taskgraph.New()
+//	db := taskgraph.AddValue(g, "db", openDB)
+//	g.Add("migrate", func(ctx context.Context) error {
+//		conn, _ := db.Await(ctx)
+//		return migrate(ctx, conn)
+//	}, "db")
+//	g.Add("cache", warmCache)
+//	if err := g.Run(ctx); err != nil {
+//		return err
+//	}
+func New() *Graph {
+	return &Graph{}
+}
+
+// Add adds a task that runs fn once every task named in deps has
+// succeeded.
+//
+// Parameters:
+//   - name: The unique name of the task, used by other tasks' deps.
+//   - fn: The task.
+//   - deps: The names of the tasks that must succeed first.
+//
+// Returns:
+//   - *future.Future[struct{}]: Settled when the task has run or been
+//     skipped.
+func (g *Graph) Add(name string, fn func(ctx context.Context) error, deps ...string) *future.Future[struct{}] {
+	return AddValue(g, name, func(ctx context.Context) (struct{}, error) {
+		return struct{}{}, fn(ctx)
+	}, deps...)
+}
+
+// AddValue adds a task that produces a value, like Add. Tasks depending on
+// it can read the value by awaiting the returned future, which is settled
+// before they start.
+func AddValue[T any](g *Graph, name string, fn func(ctx context.Context) (T, error), deps ...string) *future.Future[T] {
+	p, f := future.New[T]()
+	n := &node{
+		name: name,
+		deps: deps,
+		run: func(ctx context.Context) error {
+			value, err := fn(ctx)
+			if err == nil {
+				p.Resolve(value)
+			}
+			return err
+		},
+		reject: func(err error) { p.Reject(err) },
+		done:   make(chan struct{}),
+	}
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.nodes = append(g.nodes, n)
+	return f
+}
+
+// Run runs every task, each as soon as its dependencies have succeeded,
+// and waits for all of them. A task that fails, or panics, causes the
+// tasks depending on it, directly or not, to be skipped; other tasks keep
+// running.
+//
+// Parameters:
+//   - ctx: Passed to the tasks. Tasks not yet started when it is done are
+//     skipped with its error.
+//
+// Returns:
+//   - error: InvalidGraphError or AlreadyRunError if nothing could run;
+//     otherwise nil if every task succeeded, or the errors of the failed
+//     tasks, and ctx's error if tasks were skipped because of it, combined
+//     by multierr.
+func (g *Graph) Run(ctx context.Context) error {
+	g.mu.Lock()
+	if g.ran {
+		g.mu.Unlock()
+		return AlreadyRunError
+	}
+	g.ran = true
+	nodes := g.nodes
+	g.mu.Unlock()
+
+	byName, err := index(nodes)
+	if err != nil {
+		for _, n := range nodes {
+			n.reject(err)
+		}
+		return err
+	}
+
+	var wg sync.WaitGroup
+	var errs multierr.Error
+	var cancelled sync.Once
+	for _, n := range nodes {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer close(n.done)
+			if err := awaitDeps(ctx, n, byName); err != nil {
+				if !errors.Is(err, DependencyFailedError) {
+					cancelled.Do(func() { errs.Append(err) })
+				}
+				n.err = err
+				n.reject(err)
+				return
+			}
+			if err := call(ctx, n.run); err != nil {
+				n.err = fmt.Errorf("taskgraph: task %q: %w", n.name, err)
+				n.reject(n.err)
+				errs.Append(n.err)
+			}
+		}()
+	}
+	wg.Wait()
+	return errs.ErrorOrNil()
+}
+
+// awaitDeps waits for the dependencies of n, returning why n must be
+// skipped, if it must.
+func awaitDeps(ctx context.Context, n *node, byName map[string]*node) error {
+	for _, name := range n.deps {
+		dep := byName[name]
+		select {
+		case <-dep.done:
+			if dep.err != nil {
+				return fmt.Errorf("%w: task %q depends on %q", DependencyFailedError, n.name, name)
+			}
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	return ctx.Err()
+}
+
+// index maps task names to their nodes, checking that names are unique,
+// dependencies exist and there are no cycles.
+func index(nodes []*node) (map[string]*node, error) {
+	byName := make(map[string]*node, len(nodes))
+	for _, n := range nodes {
+		if _, ok := byName[n.name]; ok {
+			return nil, fmt.Errorf("%w: task %q added twice", InvalidGraphError, n.name)
+		}
+		byName[n.name] = n
+	}
+	for _, n := range nodes {
+		for _, dep := range n.deps {
+			if _, ok := byName[dep]; !ok {
+				return nil, fmt.Errorf("%w: task %q depends on unknown task %q", InvalidGraphError, n.name, dep)
+			}
+		}
+	}
+	if cycle := findCycle(nodes, byName); cycle != nil {
+		return nil, fmt.Errorf("%w: cycle %s", InvalidGraphError, strings.Join(cycle, " -> "))
+	}
+	return byName, nil
+}
+
+// findCycle returns the names along a dependency cycle, first name
+// repeated at the end, or nil if there is none.
+func findCycle(nodes []*node, byName map[string]*node) []string {
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+	state := make(map[string]int, len(nodes))
+	var path []string
+	var visit func(n *node) []string
+	visit = func(n *node) []string {
+		state[n.name] = visiting
+		path = append(path, n.name)
+		for _, name := range n.deps {
+			switch state[name] {
+			case visiting:
+				start := 0
+				for path[start] != name {
+					start++
+				}
+				return append(append([]string(nil), path[start:]...), name)
+			case unvisited:
+				if cycle := visit(byName[name]); cycle != nil {
+					return cycle
+				}
+			}
+		}
+		path = path[:len(path)-1]
+		state[n.name] = visited
+		return nil
+	}
+	for _, n := range nodes {
+		if state[n.name] == unvisited {
+			if cycle := visit(n); cycle != nil {
+				return cycle
+			}
+		}
+	}
+	return nil
+}
+
+// call runs fn, converting a panic into an error.
+func call(ctx context.Context, fn func(ctx context.Context) error) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panicked: %v", r)
+		}
+	}()
+	return fn(ctx)
+}
@@ -0,0 +1,226 @@
+package taskgraph
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/zodimo/go-zbase-std/future"
+)
+
+// recorder records the order in which tasks ran.
+type recorder struct {
+	mu    sync.Mutex
+	order []string
+}
+
+func (r *recorder) task(name string) func(context.Context) error {
+	return func(context.Context) error {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		r.order = append(r.order, name)
+		return nil
+	}
+}
+
+func (r *recorder) before(a, b string) bool {
+	return slices.Index(r.order, a) < slices.Index(r.order, b)
+}
+
+func TestGraph_RunRespectsDependencies(t *testing.T) {
+	// Arrange
+	g := New()
+	r := &recorder{}
+	g.Add("migrate", r.task("migrate"), "db")
+	g.Add("server", r.task("server"), "migrate", "cache")
+	g.Add("db", r.task("db"))
+	g.Add("cache", r.task("cache"))
+
+	// Act
+	err := g.Run(context.Background())
+
+	// Assert
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(r.order) != 4 || !r.before("db", "migrate") || !r.before("migrate", "server") || !r.before("cache", "server") {
+		t.Errorf("expected dependencies to run first, got %v", r.order)
+	}
+}
+
+func TestGraph_RunsIndependentTasksInParallel(t *testing.T) {
+	// Arrange
+	g := New()
+	var running, peak atomic.Int32
+	release := make(chan struct{})
+	for _, name := range []string{"a", "b", "c"} {
+		g.Add(name, func(context.Context) error {
+			n := running.Add(1)
+			for {
+				p := peak.Load()
+				if n <= p || peak.CompareAndSwap(p, n) {
+					break
+				}
+			}
+			if n == 3 {
+				close(release)
+			}
+			<-release
+			running.Add(-1)
+			return nil
+		})
+	}
+
+	// Act
+	done := make(chan error)
+	go func() { done <- g.Run(context.Background()) }()
+
+	// Assert
+	select {
+	case err := <-done:
+		if err != nil || peak.Load() != 3 {
+			t.Errorf("expected 3 tasks at once, got %d and %v", peak.Load(), err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("expected independent tasks to run concurrently")
+	}
+}
+
+func TestGraph_FailureSkipsDownstream(t *testing.T) {
+	// Arrange
+	g := New()
+	r := &recorder{}
+	failure := errors.New("connection refused")
+	db := g.Add("db", func(context.Context) error { return failure })
+	migrate := g.Add("migrate", r.task("migrate"), "db")
+	server := g.Add("server", r.task("server"), "migrate")
+	g.Add("cache", r.task("cache"))
+
+	// Act
+	err := g.Run(context.Background())
+
+	// Assert
+	if !errors.Is(err, failure) || !strings.Contains(err.Error(), `task "db"`) {
+		t.Errorf("expected the db failure, got %v", err)
+	}
+	if !slices.Equal(r.order, []string{"cache"}) {
+		t.Errorf("expected only the independent task to run, got %v", r.order)
+	}
+	if _, err := db.Await(context.Background()); !errors.Is(err, failure) {
+		t.Errorf("expected the db future to hold the failure, got %v", err)
+	}
+	for _, f := range []*future.Future[struct{}]{migrate, server} {
+		if _, err := f.Await(context.Background()); !errors.Is(err, DependencyFailedError) {
+			t.Errorf("expected DependencyFailedError, got %v", err)
+		}
+	}
+}
+
+func TestAddValue_PassesValueDownstream(t *testing.T) {
+	// Arrange
+	g := New()
+	port := AddValue(g, "config", func(context.Context) (int, error) { return 8080, nil })
+	var got int
+	g.Add("server", func(ctx context.Context) error {
+		v, err := port.Await(ctx)
+		got = v
+		return err
+	}, "config")
+
+	// Act
+	err := g.Run(context.Background())
+
+	// Assert
+	if err != nil || got != 8080 {
+		t.Errorf("expected the config value 8080, got %d and %v", got, err)
+	}
+}
+
+func TestGraph_RunPanicIsFailure(t *testing.T) {
+	// Arrange
+	g := New()
+	g.Add("boom", func(context.Context) error { panic("boom") })
+
+	// Act
+	err := g.Run(context.Background())
+
+	// Assert
+	if err == nil || !strings.Contains(err.Error(), "panicked: boom") {
+		t.Errorf("expected the panic as an error, got %v", err)
+	}
+}
+
+func TestGraph_RunInvalid(t *testing.T) {
+	for _, tc := range []struct {
+		name  string
+		build func(g *Graph)
+		want  string
+	}{
+		{"duplicate", func(g *Graph) {
+			g.Add("a", noop)
+			g.Add("a", noop)
+		}, `task "a" added twice`},
+		{"unknown dependency", func(g *Graph) {
+			g.Add("a", noop, "missing")
+		}, `unknown task "missing"`},
+		{"cycle", func(g *Graph) {
+			g.Add("a", noop, "c")
+			g.Add("b", noop, "a")
+			g.Add("c", noop, "b")
+		}, "cycle a -> c -> b -> a"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			g := New()
+			tc.build(g)
+
+			// Act
+			err := g.Run(context.Background())
+
+			// Assert
+			if !errors.Is(err, InvalidGraphError) || !strings.Contains(err.Error(), tc.want) {
+				t.Errorf("expected InvalidGraphError mentioning %q, got %v", tc.want, err)
+			}
+		})
+	}
+}
+
+func TestGraph_RunTwice(t *testing.T) {
+	// Arrange
+	g := New()
+	_ = g.Run(context.Background())
+
+	// Act
+	err := g.Run(context.Background())
+
+	// Assert
+	if !errors.Is(err, AlreadyRunError) {
+		t.Errorf("expected AlreadyRunError, got %v", err)
+	}
+}
+
+func TestGraph_RunCancelled(t *testing.T) {
+	// Arrange
+	g := New()
+	ctx, cancel := context.WithCancel(context.Background())
+	g.Add("first", func(context.Context) error { cancel(); return nil })
+	second := g.Add("second", noop, "first")
+
+	// Act
+	err := g.Run(ctx)
+
+	// Assert
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context.Canceled, got %v", err)
+	}
+	if _, err := second.Await(context.Background()); !errors.Is(err, context.Canceled) {
+		t.Errorf("expected the skipped task to hold context.Canceled, got %v", err)
+	}
+}
+
+func noop(context.Context) error { return nil }
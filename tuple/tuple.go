@@ -0,0 +1,77 @@
+// Package tuple provides generic Pair and Triple types for grouping values
+// of different types, such as the elements produced by Zip-style functions.
+package tuple
+
+// Pair holds two values of possibly different types.
+type Pair[A, B any] struct {
+	first  A // The first element.
+	second B // The second element.
+}
+
+// NewPair initializes a Pair from its two elements.
+//
+// Example:
+//
+//	p := NewPair("answer", 42)
+func NewPair[A, B any](first A, second B) Pair[A, B] {
+	return Pair[A, B]{first: first, second: second}
+}
+
+// First returns the first element of the Pair.
+func (p Pair[A, B]) First() A {
+	return p.first
+}
+
+// Second returns the second element of the Pair.
+func (p Pair[A, B]) Second() B {
+	return p.second
+}
+
+// Unpack returns both elements of the Pair.
+//
+// Example:
+//
+//	name, value := p.Unpack()
+func (p Pair[A, B]) Unpack() (A, B) {
+	return p.first, p.second
+}
+
+// Triple holds three values of possibly different types.
+type Triple[A, B, C any] struct {
+	first  A // The first element.
+	second B // The second element.
+	third  C // The third element.
+}
+
+// NewTriple initializes a Triple from its three elements.
+//
+// Example:
+//
+//	t := NewTriple("x", 1, true)
+func NewTriple[A, B, C any](first A, second B, third C) Triple[A, B, C] {
+	return Triple[A, B, C]{first: first, second: second, third: third}
+}
+
+// First returns the first element of the Triple.
+func (t Triple[A, B, C]) First() A {
+	return t.first
+}
+
+// Second returns the second element of the Triple.
+func (t Triple[A, B, C]) Second() B {
+	return t.second
+}
+
+// Third returns the third element of the Triple.
+func (t Triple[A, B, C]) Third() C {
+	return t.third
+}
+
+// Unpack returns all three elements of the Triple.
+//
+// Example:
+//
+//	name, count, ok := t.Unpack()
+func (t Triple[A, B, C]) Unpack() (A, B, C) {
+	return t.first, t.second, t.third
+}
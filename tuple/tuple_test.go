@@ -0,0 +1,48 @@
+package tuple
+
+import "testing"
+
+func TestPair(t *testing.T) {
+	// Act
+	p := NewPair("answer", 42)
+
+	// Assert
+	if p.First() != "answer" {
+		t.Errorf("expected first element answer, got %q", p.First())
+	}
+	if p.Second() != 42 {
+		t.Errorf("expected second element 42, got %v", p.Second())
+	}
+	first, second := p.Unpack()
+	if first != "answer" || second != 42 {
+		t.Errorf("expected Unpack to return (answer, 42), got (%q, %v)", first, second)
+	}
+}
+
+func TestTriple(t *testing.T) {
+	// Act
+	tr := NewTriple("x", 1, true)
+
+	// Assert
+	if tr.First() != "x" || tr.Second() != 1 || !tr.Third() {
+		t.Errorf("expected elements (x, 1, true), got (%q, %v, %v)", tr.First(), tr.Second(), tr.Third())
+	}
+	first, second, third := tr.Unpack()
+	if first != "x" || second != 1 || !third {
+		t.Errorf("expected Unpack to return (x, 1, true), got (%q, %v, %v)", first, second, third)
+	}
+}
+
+func TestPair_Comparable(t *testing.T) {
+	// Arrange
+	a := NewPair("k", 1)
+	b := NewPair("k", 1)
+
+	// Act
+	seen := map[Pair[string, int]]bool{a: true}
+
+	// Assert
+	if a != b || !seen[b] {
+		t.Error("expected pairs of comparable elements to be comparable map keys")
+	}
+}
@@ -0,0 +1,72 @@
+package validate
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/zodimo/go-zbase-std/errcode"
+	"github.com/zodimo/go-zbase-std/multierr"
+)
+
+// FieldError is a rule violation at a path within the validated value,
+// such as "address.street" or "tags[2]". The path is empty for a violation
+// of the value as a whole.
+type FieldError struct {
+	// Path locates the invalid field.
+	Path string
+
+	// Err describes the violation.
+	Err error
+}
+
+func (e *FieldError) Error() string {
+	if e.Path == "" {
+		return e.Err.Error()
+	}
+	return e.Path + ": " + e.Err.Error()
+}
+
+// Unwrap returns the violation.
+func (e *FieldError) Unwrap() error {
+	return e.Err
+}
+
+// Code classifies the violation as Invalid for the errcode package.
+func (e *FieldError) Code() errcode.Code {
+	return errcode.Invalid
+}
+
+// atPath places the violations in err under path, extending the paths of
+// FieldErrors and wrapping other errors in a FieldError.
+func atPath(path string, err error) error {
+	if err == nil {
+		return nil
+	}
+	var merr *multierr.Error
+	if errors.As(err, &merr) {
+		var placed multierr.Error
+		for _, e := range merr.Errors() {
+			placed.Append(atPath(path, e))
+		}
+		return placed.ErrorOrNil()
+	}
+	var ferr *FieldError
+	if errors.As(err, &ferr) {
+		return &FieldError{Path: joinPath(path, ferr.Path), Err: ferr.Err}
+	}
+	return &FieldError{Path: path, Err: err}
+}
+
+// joinPath appends a field name or index to a path.
+func joinPath(path, sub string) string {
+	switch {
+	case path == "":
+		return sub
+	case sub == "":
+		return path
+	case strings.HasPrefix(sub, "["):
+		return path + sub
+	default:
+		return path + "." + sub
+	}
+}
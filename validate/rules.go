@@ -0,0 +1,110 @@
+package validate
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+
+	"github.com/zodimo/go-zbase-std/multierr"
+)
+
+// Rule checks a value, returning nil if it is valid or an error describing
+// the violation. Any func(T) error can be used as a custom rule.
+type Rule[T any] func(v T) error
+
+// EmptyError is reported by NonEmpty.
+var EmptyError = errors.New("must not be empty")
+
+// NonEmpty requires the value to differ from the zero value of its type,
+// such as the empty string or a nil pointer.
+func NonEmpty[T comparable]() Rule[T] {
+	return func(v T) error {
+		var zero T
+		if v == zero {
+			return EmptyError
+		}
+		return nil
+	}
+}
+
+// Range requires the value to lie between lo and hi inclusive.
+func Range[T cmp.Ordered](lo, hi T) Rule[T] {
+	return func(v T) error {
+		if v < lo || v > hi {
+			return fmt.Errorf("must be between %v and %v", lo, hi)
+		}
+		return nil
+	}
+}
+
+// MatchRegex requires the value to match re.
+func MatchRegex[T ~string](re *regexp.Regexp) Rule[T] {
+	return func(v T) error {
+		if !re.MatchString(string(v)) {
+			return fmt.Errorf("must match %s", re)
+		}
+		return nil
+	}
+}
+
+// Func makes a rule from a predicate, reporting message when it returns
+// false.
+func Func[T any](message string, ok func(v T) bool) Rule[T] {
+	return func(v T) error {
+		if !ok(v) {
+			return errors.New(message)
+		}
+		return nil
+	}
+}
+
+// All applies every rule to the value and collects their violations.
+func All[T any](rules ...Rule[T]) Rule[T] {
+	return func(v T) error {
+		var errs multierr.Error
+		for _, rule := range rules {
+			errs.Append(rule(v))
+		}
+		return errs.ErrorOrNil()
+	}
+}
+
+// Each applies the rules to every element of a slice, reporting violations
+// at the element's index, as in "[2]".
+func Each[T any](rules ...Rule[T]) Rule[[]T] {
+	rule := All(rules...)
+	return func(vs []T) error {
+		var errs multierr.Error
+		for i, v := range vs {
+			errs.Append(atPath("["+strconv.Itoa(i)+"]", rule(v)))
+		}
+		return errs.ErrorOrNil()
+	}
+}
+
+// Field applies rules to the field of a struct returned by get, reporting
+// violations under name. Nesting Field rules builds dotted paths such as
+// "address.street".
+//
+// Example:
+//
+//	var userRules = validate.New(
+//		validate.Field("name", func(u User) string { return u.Name }, validate.NonEmpty[string]()),
+//		validate.Field("age", func(u User) int { return u.Age }, validate.Range(0, 150)),
+//	)
+func Field[T, F any](name string, get func(v T) F, rules ...Rule[F]) Rule[T] {
+	rule := All(rules...)
+	return func(v T) error {
+		return atPath(name, rule(get(v)))
+	}
+}
+
+// Valid applies the value's own Validate method, so types with attached
+// rules can be nested in the rules of other types.
+func Valid[T Validatable]() Rule[T] {
+	return func(v T) error {
+		return v.Validate()
+	}
+}
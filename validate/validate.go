@@ -0,0 +1,79 @@
+// Package validate provides composable rules that check the semantic
+// validity of values and report violations with field paths. It
+// complements the complete package, which only checks that values are
+// structurally complete.
+package validate
+
+import (
+	"github.com/zodimo/go-zbase-std/complete"
+)
+
+// Validatable is implemented by types with attached rules, usually by
+// delegating to a package-level Validator.
+type Validatable interface {
+	// Validate returns nil if the value is valid, or its violations,
+	// typically as FieldErrors combined by multierr.
+	Validate() error
+}
+
+// Validator is a reusable set of rules for values of type T.
+type Validator[T any] struct {
+	rule Rule[T]
+}
+
+// New creates a Validator applying every given rule.
+//
+// Parameters:
+//   - rules: The rules to apply, usually built with Field.
+//
+// Returns:
+//   - *Validator[T]: The new validator.
+//
+// Example:
+//
+//	var userRules = validate.New(
+//		validate.Field("email", func(u User) string { return u.Email }, validate.MatchRegex[string](emailPattern)),
+//	)
+//
+//	func (u User) Validate() error { return userRules.Validate(u) }
+func New[T any](rules ...Rule[T]) *Validator[T] {
+	return &Validator[T]{rule: All(rules...)}
+}
+
+// Validate applies the rules to v.
+//
+// Returns:
+//   - error: nil if v is valid; a *FieldError or a *multierr.Error of
+//     FieldErrors otherwise.
+func (val *Validator[T]) Validate(v T) error {
+	return atPath("", val.rule(v))
+}
+
+// Rule returns the validator as a rule, so it can be nested in Field or
+// Each.
+func (val *Validator[T]) Rule() Rule[T] {
+	return val.rule
+}
+
+// Complete adapts v to complete.Complete, reporting it complete only if it
+// passes the rules, so it can be checked by complete.ValidateCompleteness.
+func (val *Validator[T]) Complete(v T) complete.Complete {
+	return checked[T]{Value: v, validate: val.Validate}
+}
+
+// AsComplete adapts a Validatable to complete.Complete, reporting it
+// complete only if Validate returns nil.
+func AsComplete[T Validatable](v T) complete.Complete {
+	return checked[T]{Value: v, validate: T.Validate}
+}
+
+// checked implements complete.Complete for a value and its rules.
+type checked[T any] struct {
+	Value    T
+	validate func(T) error
+}
+
+// Complete reports whether the value passes its rules.
+func (c checked[T]) Complete() bool {
+	return c.validate(c.Value) == nil
+}
@@ -0,0 +1,167 @@
+package validate
+
+import (
+	"errors"
+	"regexp"
+	"slices"
+	"testing"
+
+	"github.com/zodimo/go-zbase-std/complete"
+	"github.com/zodimo/go-zbase-std/errcode"
+	"github.com/zodimo/go-zbase-std/multierr"
+)
+
+type address struct {
+	Street string
+	Zip    string
+}
+
+type user struct {
+	Name    string
+	Age     int
+	Tags    []string
+	Address address
+}
+
+var addressRules = New(
+	Field("street", func(a address) string { return a.Street }, NonEmpty[string]()),
+	Field("zip", func(a address) string { return a.Zip }, MatchRegex[string](regexp.MustCompile(`^\d{4}$`))),
+)
+
+var userRules = New(
+	Field("name", func(u user) string { return u.Name }, NonEmpty[string]()),
+	Field("age", func(u user) int { return u.Age }, Range(0, 150)),
+	Field("tags", func(u user) []string { return u.Tags }, Each(NonEmpty[string]())),
+	Field("address", func(u user) address { return u.Address }, addressRules.Rule()),
+)
+
+func (u user) Validate() error { return userRules.Validate(u) }
+
+func validUser() user {
+	return user{Name: "Ada", Age: 36, Tags: []string{"admin"}, Address: address{Street: "Main", Zip: "1234"}}
+}
+
+// paths returns the paths of the FieldErrors in err.
+func paths(err error) []string {
+	var merr *multierr.Error
+	if !errors.As(err, &merr) {
+		var ferr *FieldError
+		if errors.As(err, &ferr) {
+			return []string{ferr.Path}
+		}
+		return nil
+	}
+	var out []string
+	for _, e := range merr.Errors() {
+		out = append(out, paths(e)...)
+	}
+	return out
+}
+
+func TestValidator_Valid(t *testing.T) {
+	// Arrange
+	u := validUser()
+
+	// Act
+	err := u.Validate()
+
+	// Assert
+	if err != nil {
+		t.Errorf("expected no error, got %v", err)
+	}
+}
+
+func TestValidator_ReportsFieldPaths(t *testing.T) {
+	// Arrange
+	u := validUser()
+	u.Name = ""
+	u.Age = 200
+	u.Tags = []string{"admin", ""}
+	u.Address.Zip = "abc"
+
+	// Act
+	err := u.Validate()
+
+	// Assert
+	want := []string{"name", "age", "tags[1]", "address.zip"}
+	if got := paths(err); !slices.Equal(got, want) {
+		t.Errorf("expected paths %v, got %v", want, got)
+	}
+	if !errors.Is(err, EmptyError) {
+		t.Errorf("expected EmptyError among the violations, got %v", err)
+	}
+	if errcode.Of(err) != errcode.Invalid {
+		t.Errorf("expected code %v, got %v", errcode.Invalid, errcode.Of(err))
+	}
+}
+
+func TestFieldError_Error(t *testing.T) {
+	// Arrange
+	err := &FieldError{Path: "address.zip", Err: errors.New("must match x")}
+
+	// Act
+	got := err.Error()
+
+	// Assert
+	if got != "address.zip: must match x" {
+		t.Errorf("expected %q, got %q", "address.zip: must match x", got)
+	}
+}
+
+func TestRules(t *testing.T) {
+	even := Func("must be even", func(n int) bool { return n%2 == 0 })
+	for _, tc := range []struct {
+		name  string
+		err   error
+		valid bool
+	}{
+		{"non-empty", NonEmpty[string]()("x"), true},
+		{"empty", NonEmpty[string]()(""), false},
+		{"in range", Range(1, 3)(3), true},
+		{"out of range", Range(1, 3)(4), false},
+		{"matches", MatchRegex[string](regexp.MustCompile(`^a`))("abc"), true},
+		{"does not match", MatchRegex[string](regexp.MustCompile(`^a`))("cba"), false},
+		{"custom passes", even(2), true},
+		{"custom fails", even(3), false},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if (tc.err == nil) != tc.valid {
+				t.Errorf("expected valid %v, got %v", tc.valid, tc.err)
+			}
+		})
+	}
+}
+
+func TestValid_NestsValidatable(t *testing.T) {
+	// Arrange
+	rule := Field("owner", func(u user) user { return u }, Valid[user]())
+	u := validUser()
+	u.Address.Street = ""
+
+	// Act
+	err := New(rule).Validate(u)
+
+	// Assert
+	if got := paths(err); !slices.Equal(got, []string{"owner.address.street"}) {
+		t.Errorf("expected path owner.address.street, got %v", got)
+	}
+}
+
+func TestAsComplete(t *testing.T) {
+	// Arrange
+	invalid := validUser()
+	invalid.Name = ""
+
+	// Act
+	err := complete.ValidateCompleteness(AsComplete(validUser()), AsComplete(invalid))
+	validatorErr := complete.ValidateCompleteness(addressRules.Complete(address{Street: "Main", Zip: "1234"}))
+
+	// Assert
+	var incomplete *complete.IncompleteTypeError
+	if !errors.As(err, &incomplete) {
+		t.Errorf("expected an IncompleteTypeError for the invalid user, got %v", err)
+	}
+	if validatorErr != nil {
+		t.Errorf("expected a valid address to be complete, got %v", validatorErr)
+	}
+}